@@ -0,0 +1,272 @@
+package id
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"time"
+)
+
+// timedID pairs an ID with its parsed timestamp in milliseconds
+type timedID struct {
+	id string
+	ms uint64
+}
+
+// parseTimed parses every valid ULID in ids, preserving input order and skipping invalid entries
+func parseTimed(ids []string) []timedID {
+	parsed, errs := ParseBatch(ids)
+	result := make([]timedID, 0, len(ids))
+	for i, id := range ids {
+		if errs[i] == nil {
+			result = append(result, timedID{id: id, ms: parsed[i].Time()})
+		}
+	}
+	return result
+}
+
+// SampleByTime returns up to n IDs spread evenly across the time span of ids.
+// The span from the earliest to the latest timestamp is divided into n equal
+// strata and the ID nearest the middle of each stratum is chosen, so sparse
+// periods are represented as well as busy ones. Strata with no IDs are
+// skipped, so fewer than n IDs may be returned. Invalid IDs are ignored and
+// the sample is returned in chronological order.
+func SampleByTime(ids []string, n int) []string {
+	if n <= 0 {
+		return []string{}
+	}
+
+	timed := parseTimed(ids)
+	sort.SliceStable(timed, func(i, j int) bool {
+		return timed[i].ms < timed[j].ms
+	})
+	return sampleSorted(timed, n)
+}
+
+// sampleSorted implements SampleByTime over valid IDs in chronological order
+func sampleSorted(timed []timedID, n int) []string {
+	if len(timed) <= n {
+		result := make([]string, len(timed))
+		for i, t := range timed {
+			result[i] = t.id
+		}
+		return result
+	}
+
+	first := timed[0].ms
+	span := float64(timed[len(timed)-1].ms - first)
+	width := span / float64(n)
+	result := make([]string, 0, n)
+	lastPicked := -1
+
+	for s := 0; s < n; s++ {
+		lo := first + uint64(width*float64(s))
+		hi := first + uint64(width*float64(s+1))
+		if s == n-1 {
+			hi = timed[len(timed)-1].ms
+		}
+		mid := lo + (hi-lo)/2
+
+		// Nearest ID to the stratum midpoint that still falls inside the stratum
+		i := sort.Search(len(timed), func(i int) bool { return timed[i].ms >= mid })
+		best := -1
+		for _, c := range []int{i - 1, i} {
+			if c <= lastPicked || c < 0 || c >= len(timed) || timed[c].ms < lo || timed[c].ms > hi {
+				continue
+			}
+			if best < 0 || absDiff(timed[c].ms, mid) < absDiff(timed[best].ms, mid) {
+				best = c
+			}
+		}
+		if best < 0 {
+			continue
+		}
+
+		result = append(result, timed[best].id)
+		lastPicked = best
+	}
+
+	return result
+}
+
+// absDiff returns |a - b| for unsigned values
+func absDiff(a, b uint64) uint64 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+// DefaultOutlierSensitivity is the modified z-score threshold FindOutliers uses for non-positive sensitivity
+const DefaultOutlierSensitivity = 3.5
+
+// outlierWindow is the number of neighbors on each side FindOutliers compares against
+const outlierWindow = 5
+
+// FindOutliers flags IDs whose timestamps deviate wildly from their neighbors
+// in input (arrival) order, such as IDs from clock-skewed producers or forged
+// IDs. Each ID is compared to the median timestamp of up to five neighbors on
+// either side, and the deviation is scored with the modified z-score against
+// the neighborhood's median absolute deviation. IDs scoring above
+// sensitivity are returned in input order; lower sensitivity flags more IDs.
+// Invalid IDs are ignored.
+func FindOutliers(ids []string, sensitivity float64) []string {
+	if sensitivity <= 0 {
+		sensitivity = DefaultOutlierSensitivity
+	}
+
+	timed := parseTimed(ids)
+	if len(timed) < 3 {
+		return []string{}
+	}
+
+	result := []string{}
+	neighbors := make([]float64, 0, 2*outlierWindow)
+	spread := make([]float64, 0, 2*outlierWindow)
+	for i := range timed {
+		neighbors = neighbors[:0]
+		for j := max(0, i-outlierWindow); j <= min(len(timed)-1, i+outlierWindow); j++ {
+			if j != i {
+				neighbors = append(neighbors, float64(timed[j].ms))
+			}
+		}
+		center := median(neighbors)
+
+		// Median absolute deviation of the neighborhood, floored at 1ms so
+		// bursts sharing a millisecond don't divide by zero
+		spread = spread[:0]
+		for _, n := range neighbors {
+			spread = append(spread, absFloat(n-center))
+		}
+		mad := median(spread)
+		if mad < 1 {
+			mad = 1
+		}
+
+		if 0.6745*absFloat(float64(timed[i].ms)-center)/mad > sensitivity {
+			result = append(result, timed[i].id)
+		}
+	}
+	return result
+}
+
+// median returns the median of values, reordering them in place
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sort.Float64s(values)
+	mid := len(values) / 2
+	if len(values)%2 == 0 {
+		return (values[mid-1] + values[mid]) / 2
+	}
+	return values[mid]
+}
+
+// absFloat returns |v|
+func absFloat(v float64) float64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// GapStats summarizes the time between consecutive IDs in chronological order
+type GapStats struct {
+	// Count is the number of gaps, one fewer than the number of valid IDs
+	Count int
+	Min   time.Duration
+	Max   time.Duration
+	Mean  time.Duration
+	// P50, P90, and P99 are nearest-rank percentiles
+	P50 time.Duration
+	P90 time.Duration
+	P99 time.Duration
+}
+
+// AnalyzeGaps measures the gaps between consecutive valid IDs once sorted by
+// timestamp, so stalls in an event backlog show up as outsized upper
+// percentiles. Invalid IDs are ignored; fewer than two valid IDs have no gaps.
+func AnalyzeGaps(ids []string) GapStats {
+	parsed, errs := ParseBatch(ids)
+	times := make([]uint64, 0, len(ids))
+	for i, err := range errs {
+		if err == nil {
+			times = append(times, parsed[i].Time())
+		}
+	}
+	if len(times) < 2 {
+		return GapStats{}
+	}
+	slices.Sort(times)
+
+	gaps := make([]time.Duration, len(times)-1)
+	var total time.Duration
+	for i := range gaps {
+		gaps[i] = time.Duration(times[i+1]-times[i]) * time.Millisecond //nolint:gosec // G115: ULID timestamps are 48 bits
+		total += gaps[i]
+	}
+	slices.Sort(gaps)
+
+	return GapStats{
+		Count: len(gaps),
+		Min:   gaps[0],
+		Max:   gaps[len(gaps)-1],
+		Mean:  total / time.Duration(len(gaps)),
+		P50:   nearestRank(gaps, 0.50),
+		P90:   nearestRank(gaps, 0.90),
+		P99:   nearestRank(gaps, 0.99),
+	}
+}
+
+// nearestRank returns the p-th percentile of sorted, non-empty values
+func nearestRank(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p * float64(len(sorted))))
+	return sorted[min(max(rank, 1), len(sorted))-1]
+}
+
+// FindDuplicates returns each valid ID that occurs more than once, ignoring
+// case, as first given and in order of first occurrence
+func FindDuplicates(ids []string) []string {
+	parsed, errs := ParseBatch(ids)
+	// first maps each ID to the input position of its first occurrence
+	first := make(map[ID]int, len(ids))
+	reported := make(map[ID]bool)
+	result := []string{}
+	for i, err := range errs {
+		if err != nil {
+			continue
+		}
+		j, ok := first[parsed[i]]
+		switch {
+		case !ok:
+			first[parsed[i]] = i
+		case !reported[parsed[i]]:
+			reported[parsed[i]] = true
+			result = append(result, ids[j])
+		}
+	}
+	return result
+}
+
+// MonotonicityViolations counts valid IDs, in input order, that sort before
+// the highest ID earlier in the input, such as events delivered late or
+// issued by a producer with a lagging clock. Repeats of the highest ID so far
+// are not counted; FindDuplicates reports those.
+func MonotonicityViolations(ids []string) int {
+	parsed, errs := ParseBatch(ids)
+	var highest ID
+	violations, found := 0, false
+	for i, err := range errs {
+		if err != nil {
+			continue
+		}
+		switch {
+		case !found || parsed[i].Compare(highest) > 0:
+			highest, found = parsed[i], true
+		case parsed[i].Compare(highest) < 0:
+			violations++
+		}
+	}
+	return violations
+}
@@ -0,0 +1,122 @@
+package id_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SampleByTime(t *testing.T) {
+	gen := id.NewGenerator()
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// A dense burst in the first hour and a sparse tail over the next day
+	ids := gen.GenerateRange(start, start.Add(time.Hour), 1000)
+	ids = append(ids, gen.GenerateRange(start.Add(time.Hour), start.Add(25*time.Hour), 10)...)
+
+	// Act
+	sample := id.SampleByTime(ids, 5)
+
+	// Assert
+	require.Len(t, sample, 5)
+	assert.Equal(t, sample, id.SortChronologically(sample))
+	last, err := gen.ExtractTimestamp(sample[len(sample)-1])
+	require.NoError(t, err)
+	assert.True(t, last.After(start.Add(20*time.Hour)), "sample should reach the sparse tail")
+}
+
+func Test_SampleByTime_EdgeCases(t *testing.T) {
+	gen := id.NewGenerator()
+	ids := gen.GenerateBatch(3)
+
+	// Act & Assert
+	assert.Empty(t, id.SampleByTime(ids, 0))
+	assert.Len(t, id.SampleByTime(append(ids, "invalid"), 10), 3)
+	assert.Empty(t, id.SampleByTime(nil, 5))
+}
+
+func Test_FindOutliers(t *testing.T) {
+	gen := id.NewGenerator()
+	start := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	ids := make([]string, 0, 40)
+	for i := 0; i < 40; i++ {
+		ids = append(ids, gen.GenerateWithTime(start.Add(time.Duration(i)*time.Second)))
+	}
+	skewed := gen.GenerateWithTime(start.Add(-72 * time.Hour))
+	forged := gen.GenerateWithTime(start.Add(365 * 24 * time.Hour))
+	ids[10] = skewed
+	ids[30] = forged
+
+	// Act
+	outliers := id.FindOutliers(append(ids, "invalid"), 0)
+
+	// Assert
+	assert.Equal(t, []string{skewed, forged}, outliers)
+}
+
+func Test_FindOutliers_Uniform(t *testing.T) {
+	gen := id.NewGenerator()
+	start := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	ids := gen.GenerateRange(start, start.Add(time.Hour), 100)
+
+	// Act & Assert
+	assert.Empty(t, id.FindOutliers(ids, id.DefaultOutlierSensitivity))
+	assert.Empty(t, id.FindOutliers(ids[:2], 1))
+}
+
+func Test_AnalyzeGaps(t *testing.T) {
+	gen := id.NewGenerator()
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	// Nine one-second gaps followed by a one-minute stall, out of order
+	var ids []string
+	for i := range 10 {
+		ids = append(ids, gen.GenerateWithTime(start.Add(time.Duration(i)*time.Second)))
+	}
+	ids = append(ids, gen.GenerateWithTime(start.Add(9*time.Second+time.Minute)), "invalid")
+	ids[0], ids[10] = ids[10], ids[0]
+
+	// Act
+	gaps := id.AnalyzeGaps(ids)
+
+	// Assert
+	assert.Equal(t, 10, gaps.Count)
+	assert.Equal(t, time.Second, gaps.Min)
+	assert.Equal(t, time.Minute, gaps.Max)
+	assert.Equal(t, 6900*time.Millisecond, gaps.Mean)
+	assert.Equal(t, time.Second, gaps.P50)
+	assert.Equal(t, time.Second, gaps.P90)
+	assert.Equal(t, time.Minute, gaps.P99)
+}
+
+func Test_AnalyzeGaps_TooFew(t *testing.T) {
+	assert.Equal(t, id.GapStats{}, id.AnalyzeGaps(nil))
+	assert.Equal(t, id.GapStats{}, id.AnalyzeGaps([]string{id.NewGenerator().Generate(), "invalid"}))
+}
+
+func Test_FindDuplicates(t *testing.T) {
+	gen := id.NewGenerator()
+	a, b, c := gen.Generate(), gen.Generate(), gen.Generate()
+	ids := []string{b, a, "invalid", strings.ToLower(a), c, b, a, "invalid"}
+
+	// Act
+	dups := id.FindDuplicates(ids)
+
+	// Assert
+	assert.Equal(t, []string{a, b}, dups)
+	assert.Empty(t, id.FindDuplicates([]string{a, b, c}))
+}
+
+func Test_MonotonicityViolations(t *testing.T) {
+	gen := id.NewGenerator()
+	ids := gen.GenerateBatch(5)
+
+	// Act & Assert
+	assert.Zero(t, id.MonotonicityViolations(ids))
+	assert.Zero(t, id.MonotonicityViolations([]string{ids[0], ids[0], "invalid", ids[1]}))
+	assert.Equal(t, 2, id.MonotonicityViolations([]string{ids[2], ids[0], ids[3], ids[1], ids[4]}))
+	assert.Equal(t, 2, id.MonotonicityViolations([]string{ids[1], ids[0], ids[0]}))
+}
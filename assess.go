@@ -0,0 +1,106 @@
+package id
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/oklog/ulid"
+)
+
+// Policy configures the checks Assess applies to inbound IDs at API boundaries
+type Policy struct {
+	// MaxAge rejects IDs older than this; 0 disables the check
+	MaxAge time.Duration
+	// MaxFutureSkew is how far ahead of now a timestamp may be before it is
+	// rejected, to tolerate clock drift between producers
+	MaxFutureSkew time.Duration
+	// RejectDegenerateEntropy rejects IDs whose entropy is all zeros or all ones
+	RejectDegenerateEntropy bool
+	// RequireChecksum rejects IDs without a valid trailing Crockford check symbol
+	RequireChecksum bool
+	// Clock supplies the reference time; nil uses SystemClock
+	Clock Clock
+}
+
+// ReasonCode classifies why Assess rejected an ID
+type ReasonCode string
+
+const (
+	ReasonMalformed         ReasonCode = "malformed"
+	ReasonFuture            ReasonCode = "future"
+	ReasonTooOld            ReasonCode = "too_old"
+	ReasonDegenerateEntropy ReasonCode = "degenerate_entropy"
+	ReasonMissingChecksum   ReasonCode = "missing_checksum"
+)
+
+// Reason is one failed check with a human-readable detail
+type Reason struct {
+	Code   ReasonCode
+	Detail string
+}
+
+// Assessment is the outcome of checking an inbound ID against a Policy
+type Assessment struct {
+	// ID is the canonical form of the input, empty if it could not be parsed
+	ID        string
+	Timestamp time.Time
+	Reasons   []Reason
+}
+
+// Accepted reports whether the ID passed every check
+func (a Assessment) Accepted() bool {
+	return len(a.Reasons) == 0
+}
+
+// Has reports whether the assessment includes a reason with the given code
+func (a Assessment) Has(code ReasonCode) bool {
+	for _, r := range a.Reasons {
+		if r.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// Assess checks an inbound ID against policy and reports every check it fails,
+// so API boundaries can reject replayed or forged IDs with structured reasons
+func Assess(id string, policy Policy) Assessment {
+	var a Assessment
+
+	parsed, err := parseFormatted(id)
+	if err != nil {
+		a.Reasons = append(a.Reasons, Reason{Code: ReasonMalformed, Detail: err.Error()})
+		return a
+	}
+	a.ID = parsed.String()
+	a.Timestamp = ulid.Time(parsed.Time())
+
+	now := clockOrSystem(policy.Clock).Now()
+
+	if skew := a.Timestamp.Sub(now); skew > policy.MaxFutureSkew {
+		a.Reasons = append(a.Reasons, Reason{
+			Code:   ReasonFuture,
+			Detail: fmt.Sprintf("timestamp is %v in the future", skew),
+		})
+	}
+	if age := now.Sub(a.Timestamp); policy.MaxAge > 0 && age > policy.MaxAge {
+		a.Reasons = append(a.Reasons, Reason{
+			Code:   ReasonTooOld,
+			Detail: fmt.Sprintf("age %v exceeds %v", age, policy.MaxAge),
+		})
+	}
+	if policy.RejectDegenerateEntropy {
+		switch {
+		case allBytes(parsed[6:], 0x00):
+			a.Reasons = append(a.Reasons, Reason{Code: ReasonDegenerateEntropy, Detail: "entropy is all zeros"})
+		case allBytes(parsed[6:], 0xFF):
+			a.Reasons = append(a.Reasons, Reason{Code: ReasonDegenerateEntropy, Detail: "entropy is all ones"})
+		}
+	}
+	if policy.RequireChecksum && len(strings.ReplaceAll(id, "-", "")) != ulid.EncodedSize+1 {
+		a.Reasons = append(a.Reasons, Reason{Code: ReasonMissingChecksum, Detail: "no check symbol present"})
+	}
+
+	return a
+}
@@ -0,0 +1,66 @@
+package id_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Assess_Accepts(t *testing.T) {
+	gen := id.NewGenerator()
+	policy := id.Policy{MaxAge: time.Hour, MaxFutureSkew: time.Second, RejectDegenerateEntropy: true}
+	ulid := gen.Generate()
+
+	// Act
+	a := id.Assess(ulid, policy)
+
+	// Assert
+	assert.True(t, a.Accepted())
+	assert.Equal(t, ulid, a.ID)
+	assert.Empty(t, a.Reasons)
+}
+
+func Test_Assess_Rejects(t *testing.T) {
+	gen := id.NewGenerator()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	policy := id.Policy{
+		MaxAge:                  24 * time.Hour,
+		MaxFutureSkew:           time.Minute,
+		RejectDegenerateEntropy: true,
+		RequireChecksum:         true,
+		Clock:                   id.NewFakeClock(now),
+	}
+
+	cases := map[string][]id.ReasonCode{
+		"invalid":                                           {id.ReasonMalformed},
+		gen.GenerateWithTime(now.Add(time.Hour)):            {id.ReasonFuture, id.ReasonMissingChecksum},
+		gen.GenerateWithTime(now.Add(-48 * time.Hour)):      {id.ReasonTooOld, id.ReasonMissingChecksum},
+		gen.GenerateWithTime(now)[:10] + "0000000000000000": {id.ReasonDegenerateEntropy, id.ReasonMissingChecksum},
+	}
+
+	for input, codes := range cases {
+		// Act
+		a := id.Assess(input, policy)
+
+		// Assert
+		assert.False(t, a.Accepted(), input)
+		require.Len(t, a.Reasons, len(codes), input)
+		for _, code := range codes {
+			assert.True(t, a.Has(code), "%s should have %s", input, code)
+		}
+	}
+}
+
+func Test_Assess_Checksum(t *testing.T) {
+	withCheck, err := id.Reformat(id.NewGenerator().Generate(), id.FormatProfile{Checksum: true})
+	require.NoError(t, err)
+
+	// Act
+	a := id.Assess(withCheck, id.Policy{RequireChecksum: true})
+
+	// Assert
+	assert.True(t, a.Accepted())
+}
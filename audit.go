@@ -0,0 +1,302 @@
+package id
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/bits"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultAuditSampleSize is how many IDs an AuditingGenerator keeps for
+	// its report
+	DefaultAuditSampleSize = 4096
+
+	// maxDerivedStep is the largest entropy increase treated as derived
+	// from the previous ID rather than freshly drawn. ulid.Monotonic steps
+	// by under 2^32, and sampled IDs may be many steps apart, while two
+	// random draws land this close with probability 2^-31.
+	maxDerivedStep = 1 << 48
+	// minChiSquareBytes is the fewest fresh entropy bytes for which the
+	// chi-square check is meaningful: an expected 5 per byte value
+	minChiSquareBytes = 5 * 256
+	// maxChiSquareZ is how many standard deviations above its mean the
+	// chi-square statistic may be before the byte distribution is flagged
+	maxChiSquareZ = 5
+	// minStuckSamples is the fewest fresh entropy blocks needed to flag a
+	// byte position that never changes
+	minStuckSamples = 64
+)
+
+// AnomalyCode classifies a problem Audit found in a collection's entropy
+type AnomalyCode string
+
+const (
+	AnomalyRepeatedEntropy AnomalyCode = "repeated_entropy"
+	AnomalyByteBias        AnomalyCode = "byte_bias"
+	AnomalyStuckByte       AnomalyCode = "stuck_byte"
+	AnomalyCounterEntropy  AnomalyCode = "counter_entropy"
+)
+
+// Anomaly is one problem Audit found, with a human-readable detail
+type Anomaly struct {
+	Code   AnomalyCode
+	Detail string
+}
+
+// EntropyReport summarizes the random portions of a collection of IDs.
+// Entropy that ulid.Monotonic derived by incrementing the previous ID in
+// the same millisecond is counted in the monotonic fields but excluded from
+// the distribution statistics, which describe fresh draws only.
+type EntropyReport struct {
+	// Samples is the number of valid IDs analyzed
+	Samples int
+	// Invalid is the number of IDs skipped because they could not be parsed
+	Invalid int
+	// FreshBlocks is the number of IDs whose entropy was a fresh draw
+	FreshBlocks int
+	// ByteCounts counts each byte value across the fresh entropy
+	ByteCounts [256]int
+	// DistinctBytes is the number of byte values seen in the fresh entropy
+	DistinctBytes int
+	// ChiSquare is the chi-square statistic of ByteCounts against a uniform
+	// distribution. With 255 degrees of freedom it averages about 255 for
+	// random bytes; much larger values mean bias.
+	ChiSquare float64
+	// RepeatedBlocks counts IDs whose entropy equals an earlier ID's
+	RepeatedBlocks int
+	// MonotonicSteps counts IDs whose entropy increments the previous ID's
+	// within the same millisecond, as monotonic generators do. Sampled IDs
+	// may be several steps apart.
+	MonotonicSteps int
+	// LongestMonotonicRun is the most IDs in one chain of monotonic steps
+	LongestMonotonicRun int
+	// CounterCarryovers counts IDs whose entropy increments the previous
+	// ID's across a millisecond boundary, where a fresh draw was expected
+	CounterCarryovers int
+	// Anomalies lists every problem found
+	Anomalies []Anomaly
+}
+
+// OK reports whether Audit found no anomalies
+func (r EntropyReport) OK() bool {
+	return len(r.Anomalies) == 0
+}
+
+// Has reports whether the report includes an anomaly with the given code
+func (r EntropyReport) Has(code AnomalyCode) bool {
+	for _, a := range r.Anomalies {
+		if a.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// Audit analyzes the random portions of ids, in any order and either case,
+// for evidence that the entropy source is degenerate: skewed byte values,
+// byte positions that never change, repeated blocks, and counters posing as
+// randomness. Statistical checks need enough samples to be conclusive and
+// are skipped below their thresholds; a few thousand IDs suffice for all.
+func Audit(ids []string) EntropyReport {
+	var r EntropyReport
+	parsed, errs := ParseBatch(ids)
+	valid := make([]ID, 0, len(parsed))
+	for i, p := range parsed {
+		if errs[i] != nil {
+			r.Invalid++
+			continue
+		}
+		valid = append(valid, p)
+	}
+	slices.SortFunc(valid, func(a, b ID) int { return bytes.Compare(a[:], b[:]) })
+	r.Samples = len(valid)
+
+	seen := make(map[[entropySize]byte]struct{}, len(valid))
+	var positions [entropySize]map[byte]struct{}
+	for i := range positions {
+		positions[i] = make(map[byte]struct{})
+	}
+	run := 1
+	for i, u := range valid {
+		var block [entropySize]byte
+		copy(block[:], u[6:])
+		if _, dup := seen[block]; dup {
+			r.RepeatedBlocks++
+		}
+		seen[block] = struct{}{}
+
+		if i > 0 && isIncrement(valid[i-1], u) {
+			if valid[i-1].Time() == u.Time() {
+				r.MonotonicSteps++
+				run++
+				r.LongestMonotonicRun = max(r.LongestMonotonicRun, run)
+				continue
+			}
+			r.CounterCarryovers++
+		}
+		run = 1
+
+		r.FreshBlocks++
+		for j, b := range block {
+			r.ByteCounts[b]++
+			positions[j][b] = struct{}{}
+		}
+	}
+
+	for _, n := range r.ByteCounts {
+		if n > 0 {
+			r.DistinctBytes++
+		}
+	}
+	freshBytes := r.FreshBlocks * entropySize
+	if freshBytes > 0 {
+		expected := float64(freshBytes) / 256
+		for _, n := range r.ByteCounts {
+			d := float64(n) - expected
+			r.ChiSquare += d * d / expected
+		}
+	}
+
+	if r.RepeatedBlocks > 0 {
+		r.Anomalies = append(r.Anomalies, Anomaly{
+			Code:   AnomalyRepeatedEntropy,
+			Detail: fmt.Sprintf("%d IDs repeat an earlier ID's entropy", r.RepeatedBlocks),
+		})
+	}
+	// For 255 degrees of freedom the statistic has mean 255 and standard deviation sqrt(510)
+	if z := (r.ChiSquare - 255) / math.Sqrt(510); freshBytes >= minChiSquareBytes && z > maxChiSquareZ {
+		r.Anomalies = append(r.Anomalies, Anomaly{
+			Code:   AnomalyByteBias,
+			Detail: fmt.Sprintf("chi-square %.1f is %.1f standard deviations above uniform", r.ChiSquare, z),
+		})
+	}
+	if r.FreshBlocks >= minStuckSamples {
+		for j, values := range positions {
+			if len(values) == 1 {
+				r.Anomalies = append(r.Anomalies, Anomaly{
+					Code:   AnomalyStuckByte,
+					Detail: fmt.Sprintf("entropy byte %d never changes across %d draws", j, r.FreshBlocks),
+				})
+			}
+		}
+	}
+	if r.CounterCarryovers > 0 {
+		r.Anomalies = append(r.Anomalies, Anomaly{
+			Code:   AnomalyCounterEntropy,
+			Detail: fmt.Sprintf("%d IDs continue the previous ID's entropy into a new millisecond", r.CounterCarryovers),
+		})
+	}
+	return r
+}
+
+// isIncrement reports whether b's entropy exceeds a's by at most maxDerivedStep
+func isIncrement(a, b ID) bool {
+	aHi, aLo := binary.BigEndian.Uint16(a[6:]), binary.BigEndian.Uint64(a[8:])
+	bHi, bLo := binary.BigEndian.Uint16(b[6:]), binary.BigEndian.Uint64(b[8:])
+	lo, borrow := bits.Sub64(bLo, aLo, 0)
+	hi := uint64(bHi) - uint64(aHi) - borrow
+	return hi == 0 && lo > 0 && lo <= maxDerivedStep
+}
+
+// AuditingGenerator samples the IDs it issues so that Report can show,
+// for example in a security review, that the generator's entropy is not
+// degenerate. It keeps the most recent DefaultAuditSampleSize samples. All
+// other methods are served directly by the underlying generator. It is safe
+// for concurrent use.
+type AuditingGenerator struct {
+	*generator
+	every int
+
+	mu      sync.Mutex
+	issued  int
+	samples []string
+	next    int
+}
+
+// NewAuditingGenerator creates an auditing generator sampling one in every
+// IDs it issues; every below 1 samples all of them
+func NewAuditingGenerator(every int) *AuditingGenerator {
+	return NewAuditingGeneratorFrom(NewGenerator(), every)
+}
+
+// NewAuditingGeneratorFrom samples IDs produced by an existing generator
+func NewAuditingGeneratorFrom(base *generator, every int) *AuditingGenerator {
+	return &AuditingGenerator{
+		generator: base,
+		every:     max(every, 1),
+		samples:   make([]string, 0, DefaultAuditSampleSize),
+	}
+}
+
+// Generate provides a new ULID, sampling it for the audit
+func (a *AuditingGenerator) Generate() string {
+	return a.sample(a.generator.Generate())
+}
+
+// GenerateWithTime generates a ULID with a specific timestamp, sampling it
+// for the audit
+func (a *AuditingGenerator) GenerateWithTime(t time.Time) string {
+	return a.sample(a.generator.GenerateWithTime(t))
+}
+
+// GenerateBatch creates multiple ULIDs, sampling them for the audit
+func (a *AuditingGenerator) GenerateBatch(count int) []string {
+	return a.sampleAll(a.generator.GenerateBatch(count))
+}
+
+// GenerateRange creates ULIDs within a time range, sampling them for the audit
+func (a *AuditingGenerator) GenerateRange(start, end time.Time, count int) []string {
+	return a.sampleAll(a.generator.GenerateRange(start, end, count))
+}
+
+// Report audits the sampled IDs
+func (a *AuditingGenerator) Report() EntropyReport {
+	a.mu.Lock()
+	samples := slices.Clone(a.samples)
+	a.mu.Unlock()
+	return Audit(samples)
+}
+
+// Sampled returns how many IDs the report currently covers
+func (a *AuditingGenerator) Sampled() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return len(a.samples)
+}
+
+// sample records id if it falls on the sampling interval and returns it
+func (a *AuditingGenerator) sample(id string) string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.record(id)
+	return id
+}
+
+// sampleAll samples every ID in ids and returns them
+func (a *AuditingGenerator) sampleAll(ids []string) []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, id := range ids {
+		a.record(id)
+	}
+	return ids
+}
+
+// record keeps one in every a.every IDs in a ring; callers must hold a.mu
+func (a *AuditingGenerator) record(id string) {
+	a.issued++
+	if a.issued%a.every != 0 {
+		return
+	}
+	if len(a.samples) < cap(a.samples) {
+		a.samples = append(a.samples, id)
+		return
+	}
+	a.samples[a.next] = id
+	a.next = (a.next + 1) % len(a.samples)
+}
@@ -0,0 +1,111 @@
+package id_test
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+)
+
+// counterReader returns successive big-endian counter values, posing as entropy
+type counterReader struct {
+	n uint64
+}
+
+func (r *counterReader) Read(p []byte) (int, error) {
+	clear(p)
+	r.n++
+	binary.BigEndian.PutUint64(p[len(p)-8:], r.n)
+	return len(p), nil
+}
+
+func auditSpread(gen interface {
+	GenerateRange(start, end time.Time, count int) []string
+}, count int) []string {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	return gen.GenerateRange(start, start.Add(time.Hour), count)
+}
+
+func Test_Audit_Healthy(t *testing.T) {
+	ids := auditSpread(id.NewGenerator(), 5000)
+
+	// Act
+	report := id.Audit(append(ids, "nope"))
+
+	// Assert
+	assert.True(t, report.OK(), "%+v", report.Anomalies)
+	assert.Equal(t, 5000, report.Samples)
+	assert.Equal(t, 1, report.Invalid)
+	assert.Equal(t, 5000, report.FreshBlocks)
+	assert.Equal(t, 256, report.DistinctBytes)
+	assert.Less(t, report.ChiSquare, 400.0)
+}
+
+func Test_Audit_MonotonicBurst(t *testing.T) {
+	clock := id.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	ids := id.NewGenerator(id.WithClock(clock)).GenerateBatch(500)
+
+	// Act
+	report := id.Audit(ids)
+
+	// Assert
+	assert.True(t, report.OK(), "%+v", report.Anomalies)
+	assert.Equal(t, 1, report.FreshBlocks)
+	assert.Equal(t, 499, report.MonotonicSteps)
+	assert.Equal(t, 500, report.LongestMonotonicRun)
+}
+
+func Test_Audit_ConstantEntropy(t *testing.T) {
+	ids := auditSpread(id.NewGeneratorWithEntropy(constReader(7)), 2000)
+
+	// Act
+	report := id.Audit(ids)
+
+	// Assert
+	assert.False(t, report.OK())
+	assert.Equal(t, 1999, report.RepeatedBlocks)
+	assert.True(t, report.Has(id.AnomalyRepeatedEntropy))
+	assert.True(t, report.Has(id.AnomalyByteBias))
+	assert.True(t, report.Has(id.AnomalyStuckByte))
+}
+
+func Test_Audit_CounterEntropy(t *testing.T) {
+	ids := auditSpread(id.NewGeneratorWithEntropy(&counterReader{}), 100)
+
+	// Act
+	report := id.Audit(ids)
+
+	// Assert
+	assert.Equal(t, 99, report.CounterCarryovers)
+	assert.True(t, report.Has(id.AnomalyCounterEntropy))
+	assert.Zero(t, report.RepeatedBlocks)
+}
+
+func Test_AuditingGenerator(t *testing.T) {
+	gen := id.NewAuditingGenerator(2)
+
+	// Act
+	for range 50 {
+		gen.Generate()
+	}
+	gen.GenerateBatch(50)
+	report := gen.Report()
+
+	// Assert
+	assert.Equal(t, 50, gen.Sampled())
+	assert.Equal(t, 50, report.Samples)
+	assert.True(t, report.OK(), "%+v", report.Anomalies)
+}
+
+func Test_AuditingGenerator_KeepsRecentSamples(t *testing.T) {
+	gen := id.NewAuditingGeneratorFrom(id.NewGeneratorWithEntropy(constReader(1)), 0)
+
+	// Act
+	auditSpread(gen, id.DefaultAuditSampleSize+100)
+
+	// Assert
+	assert.Equal(t, id.DefaultAuditSampleSize, gen.Sampled())
+	assert.True(t, gen.Report().Has(id.AnomalyRepeatedEntropy))
+}
@@ -0,0 +1,116 @@
+package id
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// parallelChunk is the fewest IDs worth handing to a worker goroutine
+const parallelChunk = 1024
+
+// batchConfig holds the settings applied by BatchOptions
+type batchConfig struct {
+	workers int
+}
+
+// BatchOption configures ParseBatch and ValidateBatch
+type BatchOption func(*batchConfig)
+
+// WithWorkers fans a batch out over n goroutines, or GOMAXPROCS when n is
+// not positive. Inputs too small to benefit are still processed on the
+// calling goroutine.
+func WithWorkers(n int) BatchOption {
+	return func(c *batchConfig) {
+		if n <= 0 {
+			n = runtime.GOMAXPROCS(0)
+		}
+		c.workers = n
+	}
+}
+
+// BatchResult reports the outcome of validating a batch of IDs
+type BatchResult struct {
+	// IDs holds the parsed IDs, aligned with the input; invalid entries are zero
+	IDs []ID
+	// Errors is aligned with the input; valid entries are nil
+	Errors []error
+	// Valid and Invalid count the entries of each kind
+	Valid   int
+	Invalid int
+}
+
+// OK reports whether every ID in the batch was valid
+func (r BatchResult) OK() bool {
+	return r.Invalid == 0
+}
+
+// InvalidIndexes returns the input positions of invalid IDs in ascending order
+func (r BatchResult) InvalidIndexes() []int {
+	indexes := make([]int, 0, r.Invalid)
+	for i, err := range r.Errors {
+		if err != nil {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// ParseBatch parses every ID once. Both results are aligned with ids:
+// invalid IDs get a zero ID and a typed error as returned by Validate,
+// valid IDs a nil error.
+func ParseBatch(ids []string, opts ...BatchOption) ([]ID, []error) {
+	var cfg batchConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	parsed := make([]ID, len(ids))
+	errs := make([]error, len(ids))
+	parallelize(len(ids), cfg.workers, func(start, end int) {
+		for i := start; i < end; i++ {
+			u, err := parseCanonical(ids[i])
+			if err != nil {
+				errs[i] = fmt.Errorf("invalid ULID: %w", err)
+				continue
+			}
+			parsed[i] = ID(u)
+		}
+	})
+	return parsed, errs
+}
+
+// ValidateBatch parses every ID once and reports per-index errors and totals
+func ValidateBatch(ids []string, opts ...BatchOption) BatchResult {
+	parsed, errs := ParseBatch(ids, opts...)
+	result := BatchResult{IDs: parsed, Errors: errs}
+	for _, err := range errs {
+		if err != nil {
+			result.Invalid++
+		}
+	}
+	result.Valid = len(ids) - result.Invalid
+	return result
+}
+
+// parallelize calls fn over contiguous ranges covering [0, n), splitting the
+// work across up to workers goroutines of at least parallelChunk items each
+func parallelize(n, workers int, fn func(start, end int)) {
+	workers = min(workers, (n+parallelChunk-1)/parallelChunk)
+	if workers <= 1 {
+		fn(0, n)
+		return
+	}
+
+	chunk := (n + workers - 1) / workers
+	var wg sync.WaitGroup
+	for start := 0; start < n; start += chunk {
+		end := min(start+chunk, n)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fn(start, end)
+		}()
+	}
+	wg.Wait()
+}
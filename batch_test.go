@@ -0,0 +1,63 @@
+package id_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseBatch(t *testing.T) {
+	gen := id.NewGenerator()
+	ids := []string{gen.Generate(), "", gen.Generate(), "!!!!!!!!!!!!!!!!!!!!!!!!!!"}
+
+	// Act
+	parsed, errs := id.ParseBatch(ids)
+
+	// Assert
+	require.Len(t, parsed, 4)
+	require.Len(t, errs, 4)
+	assert.NoError(t, errs[0])
+	assert.Equal(t, ids[0], parsed[0].String())
+	assert.ErrorIs(t, errs[1], id.ErrEmpty)
+	assert.True(t, parsed[1].IsZero())
+	assert.Equal(t, ids[2], parsed[2].String())
+	assert.ErrorIs(t, errs[3], id.ErrInvalidCharacter{})
+}
+
+func Test_ParseBatch_WithWorkers(t *testing.T) {
+	gen := id.NewGenerator()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ids := gen.GenerateRange(start, start.Add(time.Hour), 5000)
+	ids[1234] = "bogus"
+
+	for _, workers := range []int{0, 1, 2, 7} {
+		// Act
+		parsed, errs := id.ParseBatch(ids, id.WithWorkers(workers))
+		wantParsed, wantErrs := id.ParseBatch(ids)
+
+		// Assert
+		assert.Equal(t, wantParsed, parsed, "workers=%d", workers)
+		assert.Equal(t, wantErrs, errs, "workers=%d", workers)
+	}
+}
+
+func Test_ValidateBatch(t *testing.T) {
+	gen := id.NewGenerator()
+	ids := []string{gen.Generate(), "bad", gen.Generate(), "", gen.Generate()}
+
+	// Act
+	result := id.ValidateBatch(ids, id.WithWorkers(4))
+
+	// Assert
+	assert.Equal(t, 3, result.Valid)
+	assert.Equal(t, 2, result.Invalid)
+	assert.False(t, result.OK())
+	assert.Equal(t, []int{1, 3}, result.InvalidIndexes())
+	assert.ErrorIs(t, result.Errors[1], id.ErrWrongLength)
+	assert.Equal(t, ids[4], result.IDs[4].String())
+
+	assert.True(t, id.ValidateBatch(nil).OK())
+}
@@ -0,0 +1,43 @@
+package id
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"github.com/oklog/ulid"
+)
+
+// GenerateBatchTo streams count new IDs to w, each followed by sep, without
+// holding them in memory, in the generator's scheme. Output is buffered; the
+// first write error stops generation and is returned.
+func (g *generator) GenerateBatchTo(w io.Writer, count int, sep byte) error {
+	bw := bufio.NewWriter(w)
+	var text [ulid.EncodedSize + 1]byte
+	text[ulid.EncodedSize] = sep
+
+	for i := 0; i < count; i++ {
+		var err error
+		switch {
+		case g.scheme != nil:
+			if _, err = bw.WriteString(g.schemeNew(g.now())); err == nil {
+				err = bw.WriteByte(sep)
+			}
+		case g.format.Canonical():
+			_ = g.newULID(g.now()).MarshalTextTo(text[:ulid.EncodedSize])
+			_, err = bw.Write(text[:])
+		default:
+			if _, err = bw.WriteString(g.encode(g.newULID(g.now()))); err == nil {
+				err = bw.WriteByte(sep)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("writing ID %d: %w", i, err)
+		}
+	}
+
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("flushing IDs: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package id_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GenerateBatchTo(t *testing.T) {
+	gen := id.NewGenerator()
+	var buf bytes.Buffer
+
+	// Act
+	err := gen.GenerateBatchTo(&buf, 1000, '\n')
+
+	// Assert
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 1000)
+	seen := make(map[string]bool)
+	for _, line := range lines {
+		assert.True(t, gen.IsIdValid(line))
+		assert.False(t, seen[line])
+		seen[line] = true
+	}
+}
+
+func Test_GenerateBatchTo_FormatProfile(t *testing.T) {
+	gen := id.NewLowercaseGenerator()
+	var buf bytes.Buffer
+
+	// Act
+	err := gen.GenerateBatchTo(&buf, 3, ',')
+
+	// Assert
+	require.NoError(t, err)
+	parts := strings.Split(buf.String(), ",")
+	require.Len(t, parts, 4)
+	assert.Empty(t, parts[3])
+	for _, p := range parts[:3] {
+		assert.Equal(t, strings.ToLower(p), p)
+		assert.True(t, gen.IsIdValid(p))
+	}
+}
+
+func Test_GenerateBatchTo_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, id.NewGenerator().GenerateBatchTo(&buf, 0, '\n'))
+	assert.Zero(t, buf.Len())
+}
+
+type brokenWriter struct{}
+
+func (brokenWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func Test_GenerateBatchTo_WriteError(t *testing.T) {
+	err := id.NewGenerator().GenerateBatchTo(brokenWriter{}, 10000, '\n')
+	assert.ErrorContains(t, err, "disk full")
+}
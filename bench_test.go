@@ -1,6 +1,12 @@
 package id_test
 
 import (
+	"context"
+	"io"
+	"math/rand"
+	"slices"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -119,6 +125,54 @@ func BenchmarkSortChronologically(b *testing.B) {
 	}
 }
 
+// shuffledIDs returns n IDs spread over a day in random order
+func shuffledIDs(n int) []string {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ids := id.NewGenerator().GenerateRange(start, start.Add(24*time.Hour), n)
+	rand.Shuffle(len(ids), func(i, j int) { ids[i], ids[j] = ids[j], ids[i] })
+	return ids
+}
+
+func BenchmarkSortChronologically100k(b *testing.B) {
+	ids := shuffledIDs(100_000)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = id.SortChronologically(ids)
+	}
+}
+
+func BenchmarkSortChronologically100kMixedCase(b *testing.B) {
+	ids := shuffledIDs(100_000)
+	for i := 0; i < len(ids); i += 2 {
+		ids[i] = strings.ToLower(ids[i])
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = id.SortChronologically(ids)
+	}
+}
+
+// BenchmarkSortChronologically100kParsePerCompare is the previous design,
+// which parsed both IDs inside every comparison, kept as a baseline
+func BenchmarkSortChronologically100kParsePerCompare(b *testing.B) {
+	gen := id.NewGenerator()
+	ids := shuffledIDs(100_000)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		sorted := slices.Clone(ids)
+		sort.Slice(sorted, func(i, j int) bool {
+			cmp, err := gen.Compare(sorted[i], sorted[j])
+			return err == nil && cmp < 0
+		})
+	}
+}
+
 func BenchmarkAnalyzeIDs(b *testing.B) {
 	gen := id.NewGenerator()
 	ulids := make([]string, 100)
@@ -149,3 +203,246 @@ func BenchmarkFilterByTimeRange(b *testing.B) {
 		_ = id.FilterByTimeRange(ulids, filterStart, filterEnd)
 	}
 }
+
+func BenchmarkGenerateID(b *testing.B) {
+	gen := id.NewGenerator()
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = gen.GenerateID()
+	}
+}
+
+func BenchmarkParseID(b *testing.B) {
+	gen := id.NewGenerator()
+	ulid := gen.Generate()
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = gen.ParseID(ulid)
+	}
+}
+
+func BenchmarkIDCompare(b *testing.B) {
+	gen := id.NewGenerator()
+	id1 := gen.GenerateID()
+	id2 := gen.GenerateID()
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = id1.Compare(id2)
+	}
+}
+
+func BenchmarkExtractTimestamps(b *testing.B) {
+	gen := id.NewGenerator()
+	ids := gen.GenerateBatch(10000)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = id.ExtractTimestamps(ids)
+	}
+}
+
+func BenchmarkExtractTimestampsParallel(b *testing.B) {
+	gen := id.NewGenerator()
+	ids := gen.GenerateBatch(10000)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = id.ExtractTimestampsParallel(ids, 0)
+	}
+}
+
+func BenchmarkGenerateParallel(b *testing.B) {
+	gen := id.NewGenerator()
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = gen.Generate()
+		}
+	})
+}
+
+func BenchmarkGenerateParallelPerGoroutine(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		gen := id.NewGenerator()
+		for pb.Next() {
+			_ = gen.Generate()
+		}
+	})
+}
+
+func BenchmarkGenerateSecureParallel(b *testing.B) {
+	gen := id.NewSecureGenerator()
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = gen.Generate()
+		}
+	})
+}
+
+func BenchmarkParseBatch(b *testing.B) {
+	gen := id.NewGenerator()
+	ids := gen.GenerateBatch(10000)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = id.ParseBatch(ids)
+	}
+}
+
+func BenchmarkGenerateBatchTo(b *testing.B) {
+	gen := id.NewGenerator()
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = gen.GenerateBatchTo(io.Discard, 100, '\n')
+	}
+}
+
+func BenchmarkStream(b *testing.B) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := id.NewGenerator().Stream(ctx)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = <-stream
+	}
+}
+
+func BenchmarkStreamIDs(b *testing.B) {
+	b.ReportAllocs()
+	i := 0
+	for range id.NewGenerator().StreamIDs(context.Background()) {
+		if i++; i >= b.N {
+			break
+		}
+	}
+}
+
+func BenchmarkSnapshotFilterByTimeRange(b *testing.B) {
+	ids := shuffledIDs(100_000)
+	snap := id.NewSnapshot(ids)
+	start := time.Date(2024, 1, 1, 6, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = snap.FilterByTimeRange(start, end)
+	}
+}
+
+func BenchmarkSnapshotMatchPrefix(b *testing.B) {
+	ids := shuffledIDs(100_000)
+	snap := id.NewSnapshot(ids)
+	prefix := ids[0][:8]
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = snap.MatchPrefix(prefix)
+	}
+}
+
+func BenchmarkBufferedGenerate(b *testing.B) {
+	// The last ID of each chunk takes the buffer below three quarters full,
+	// which signals a refill
+	const size, chunk = 8192, 2049
+	gen := id.NewBufferedGenerator(size)
+	if err := gen.Start(context.Background()); err != nil {
+		b.Fatal(err)
+	}
+	defer gen.Close()
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		// Measure the hot path only: let the buffer refill between chunks
+		if i%chunk == 0 {
+			b.StopTimer()
+			for gen.Buffered() < size {
+				time.Sleep(100 * time.Microsecond)
+			}
+			b.StartTimer()
+		}
+		_ = gen.Generate()
+	}
+}
+
+func BenchmarkPoolGet(b *testing.B) {
+	const size, chunk = 8192, 4096
+	pool := id.NewPool(id.PoolOptions{Size: size, LowWatermark: chunk})
+	if err := pool.Start(context.Background()); err != nil {
+		b.Fatal(err)
+	}
+	defer pool.Close()
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		// Measure the hot path only: let the pool refill between chunks
+		if i%chunk == 0 {
+			b.StopTimer()
+			for pool.Stats().Depth < chunk {
+				time.Sleep(100 * time.Microsecond)
+			}
+			b.StartTimer()
+		}
+		_ = pool.Get()
+	}
+}
+
+func BenchmarkAppendBinary(b *testing.B) {
+	ulid := id.NewGenerator().Generate()
+	buf := make([]byte, 0, id.BinarySize)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		buf, _ = id.AppendBinary(buf[:0], ulid)
+	}
+}
+
+func BenchmarkParseBinary(b *testing.B) {
+	value := id.NewGenerator().GenerateID()
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = id.ParseBinary(value[:])
+	}
+}
+
+func BenchmarkIDMarshalBinary(b *testing.B) {
+	value := id.NewGenerator().GenerateID()
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = value.MarshalBinary()
+	}
+}
+
+func BenchmarkIDUnmarshalBinary(b *testing.B) {
+	value := id.NewGenerator().GenerateID()
+	data := value[:]
+	var decoded id.ID
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = decoded.UnmarshalBinary(data)
+	}
+}
@@ -0,0 +1,53 @@
+package id
+
+import (
+	"fmt"
+
+	"github.com/oklog/ulid"
+)
+
+// BinarySize is the length of the compact binary form of an ID, for
+// protobuf bytes fields and other wire formats
+const BinarySize = ulidSize
+
+// MarshalBinary implements encoding.BinaryMarshaler as the 16 raw bytes.
+// Unlike GobEncode and Value it ignores the CurrentStorageProfile, so the
+// wire form never changes with storage settings.
+func (id ID) MarshalBinary() ([]byte, error) {
+	return id.Bytes(), nil
+}
+
+// AppendBinary implements encoding.BinaryAppender, appending the 16 raw bytes to b
+func (id ID) AppendBinary(b []byte) ([]byte, error) {
+	return append(b, id[:]...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, accepting exactly
+// BinarySize bytes
+func (id *ID) UnmarshalBinary(data []byte) error {
+	if len(data) != BinarySize {
+		return fmt.Errorf("%w: got %d bytes, want %d", ErrInvalidEncoding, len(data), BinarySize)
+	}
+	copy(id[:], data)
+	return nil
+}
+
+// AppendBinary appends the 16-byte binary form of a ULID in either case to
+// dst, skipping the intermediate ID and text buffers, and returns the
+// extended slice. dst is returned unchanged on error.
+func AppendBinary(dst []byte, id string) ([]byte, error) {
+	parsed, err := parseCanonical(id)
+	if err != nil {
+		return dst, fmt.Errorf("invalid ULID: %w", err)
+	}
+	return append(dst, parsed[:]...), nil
+}
+
+// ParseBinary decodes the 16-byte binary form of an ID into its canonical
+// ULID string. It does not retain src.
+func ParseBinary(src []byte) (string, error) {
+	if len(src) != BinarySize {
+		return "", fmt.Errorf("%w: got %d bytes, want %d", ErrInvalidEncoding, len(src), BinarySize)
+	}
+	return ulid.ULID(src).String(), nil
+}
@@ -0,0 +1,114 @@
+package id_test
+
+import (
+	"encoding"
+	"strings"
+	"testing"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	_ encoding.BinaryMarshaler   = id.ID{}
+	_ encoding.BinaryAppender    = id.ID{}
+	_ encoding.BinaryUnmarshaler = (*id.ID)(nil)
+)
+
+func Test_ID_Binary(t *testing.T) {
+	value := id.NewGenerator().GenerateID()
+
+	for _, p := range []id.StorageProfile{id.StorageText, id.StorageUUID, id.StorageOrderedBinary} {
+		t.Run(p.String(), func(t *testing.T) {
+			withStorageProfile(t, p)
+
+			// Act
+			b, err := value.MarshalBinary()
+			require.NoError(t, err)
+			var decoded id.ID
+			err = decoded.UnmarshalBinary(b)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, value[:], b, "the wire form ignores the storage profile")
+			assert.Equal(t, value, decoded)
+		})
+	}
+}
+
+func Test_ID_AppendBinary(t *testing.T) {
+	value := id.NewGenerator().GenerateID()
+
+	// Act
+	b, err := value.AppendBinary([]byte{0xAA})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, append([]byte{0xAA}, value[:]...), b)
+}
+
+func Test_ID_UnmarshalBinary_WrongLength(t *testing.T) {
+	var decoded id.ID
+
+	// Act
+	err := decoded.UnmarshalBinary(make([]byte, 15))
+
+	// Assert
+	assert.ErrorIs(t, err, id.ErrInvalidEncoding)
+	assert.True(t, decoded.IsZero())
+}
+
+func Test_AppendBinary_ParseBinary_RoundTrip(t *testing.T) {
+	gen := id.NewGenerator()
+	ulid := gen.Generate()
+	prefix := []byte("msg:")
+
+	// Act
+	b, err := id.AppendBinary(prefix, strings.ToLower(ulid))
+	require.NoError(t, err)
+	parsed, err := id.ParseBinary(b[len(prefix):])
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, b, len(prefix)+id.BinarySize)
+	raw, err := gen.ToBytes(ulid)
+	require.NoError(t, err)
+	assert.Equal(t, raw[:], b[len(prefix):])
+	assert.Equal(t, ulid, parsed)
+}
+
+func Test_AppendBinary_InvalidID(t *testing.T) {
+	dst := []byte("msg:")
+
+	// Act
+	b, err := id.AppendBinary(dst, "not-a-ulid")
+
+	// Assert
+	assert.ErrorIs(t, err, id.ErrWrongLength)
+	assert.Equal(t, dst, b)
+}
+
+func Test_ParseBinary_WrongLength(t *testing.T) {
+	// Act
+	_, err := id.ParseBinary(make([]byte, id.BinarySize+1))
+
+	// Assert
+	assert.ErrorIs(t, err, id.ErrInvalidEncoding)
+}
+
+func Test_ParseBinary_ReferenceVectors(t *testing.T) {
+	for _, v := range id.ReferenceVectors() {
+		t.Run(v.Name, func(t *testing.T) {
+			var value id.ID
+			require.NoError(t, value.UnmarshalText([]byte(v.ULID)))
+
+			// Act
+			parsed, err := id.ParseBinary(value[:])
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, v.ULID, parsed)
+		})
+	}
+}
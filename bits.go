@@ -0,0 +1,114 @@
+package id
+
+import (
+	"slices"
+
+	"github.com/oklog/ulid"
+)
+
+// entropyStamp is a fixed value a generator writes into the entropy of
+// every ULID it issues
+type entropyStamp struct {
+	offset int
+	width  int
+	value  uint64
+}
+
+// withStamp returns an Option adding s to the generator's stamps, replacing
+// any stamp already at the same offset. The stamps slice is copied so that
+// generators cloned from one another never share it, and the new stamp set
+// starts its own record of the last stamped ULID.
+func withStamp(s entropyStamp) Option {
+	return func(g *generator) {
+		stamps := slices.DeleteFunc(slices.Clone(g.stamps), func(other entropyStamp) bool {
+			return other.offset == s.offset
+		})
+		g.stamps = append(stamps, s)
+		g.lastStamped = new(ulid.ULID)
+	}
+}
+
+// stamp writes the generator's stamps into u; callers must hold the entropy
+// lock. Monotonic entropy may carry into a stamped bit, which the stamp then
+// clears, so when a stamped ULID would not follow the last one in its
+// millisecond, the unstamped bits of the last one are incremented instead.
+// Only if those are exhausted does it return ulid.ErrMonotonicOverflow.
+func (g *generator) stamp(u *ulid.ULID) error {
+	if len(g.stamps) == 0 {
+		return nil
+	}
+	for _, s := range g.stamps {
+		setEntropyBits(u, s.offset, s.width, s.value)
+	}
+	if _, ok := g.entropySource.(monotonicReader); !ok {
+		return nil
+	}
+
+	last := g.lastStamped
+	if u.Time() == last.Time() && u.Compare(*last) <= 0 {
+		next, ok := g.incrementUnstamped(*last)
+		if !ok {
+			return ulid.ErrMonotonicOverflow
+		}
+		*u = next
+	}
+	*last = *u
+	return nil
+}
+
+// incrementUnstamped adds one to the entropy bits of u that no stamp
+// covers, read as a single number, reporting false if they overflow
+func (g *generator) incrementUnstamped(u ulid.ULID) (ulid.ULID, bool) {
+	for offset := 79; offset >= 0; offset-- {
+		if g.stamped(offset) {
+			continue
+		}
+		bit := 48 + offset
+		mask := byte(0x80) >> (bit % 8)
+		u[bit/8] ^= mask
+		if u[bit/8]&mask != 0 {
+			return u, true
+		}
+	}
+	return u, false
+}
+
+// stamped reports whether any of the generator's stamps covers the entropy
+// bit at offset
+func (g *generator) stamped(offset int) bool {
+	for _, s := range g.stamps {
+		if offset >= s.offset && offset < s.offset+s.width {
+			return true
+		}
+	}
+	return false
+}
+
+// setEntropyBits overwrites width bits of a ULID's entropy starting offset
+// bits after the timestamp, most significant bit first. Reserving entropy
+// bits keeps IDs from one generator ordered within a millisecond, since
+// stamp increments around them.
+func setEntropyBits(u *ulid.ULID, offset, width int, value uint64) {
+	for i := 0; i < width; i++ {
+		bit := 48 + offset + i
+		mask := byte(0x80) >> (bit % 8)
+		if value>>(width-1-i)&1 == 1 {
+			u[bit/8] |= mask
+		} else {
+			u[bit/8] &^= mask
+		}
+	}
+}
+
+// entropyBits reads width bits of a ULID's entropy starting offset bits after the timestamp
+func entropyBits(u ulid.ULID, offset, width int) uint64 {
+	var value uint64
+	for i := 0; i < width; i++ {
+		bit := 48 + offset + i
+		value <<= 1
+		if u[bit/8]&(byte(0x80)>>(bit%8)) != 0 {
+			value |= 1
+		}
+	}
+	return value
+}
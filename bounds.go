@@ -0,0 +1,71 @@
+package id
+
+import (
+	"time"
+
+	"github.com/oklog/ulid"
+)
+
+// IDRange is a half-open time window [Start, End) with the ULID bounds that
+// select it: id >= Lo AND id < Hi
+type IDRange struct {
+	Start time.Time
+	End   time.Time
+	Lo    string
+	Hi    string
+}
+
+// MinIDForTime returns the smallest ULID with t's millisecond timestamp: its
+// entropy is all zeros. Times outside the ULID range are clamped.
+func MinIDForTime(t time.Time) string {
+	return boundID(t, 0x00)
+}
+
+// MaxIDForTime returns the largest ULID with t's millisecond timestamp: its
+// entropy is all ones. Times outside the ULID range are clamped.
+func MaxIDForTime(t time.Time) string {
+	return boundID(t, 0xFF)
+}
+
+// RangeBounds returns bounds selecting the ULIDs timestamped in [start, end)
+// at millisecond precision, for queries of the form
+// WHERE id >= lo AND id < hi. Canonical ULIDs compare the same as text and
+// as bytes, so the bounds work on either storage.
+func RangeBounds(start, end time.Time) (lo, hi string) {
+	return MinIDForTime(start), MinIDForTime(end)
+}
+
+// BucketBounds splits [start, end) into consecutive buckets of width bucket,
+// aligned to start, with the ULID bounds of each. The last bucket ends at
+// end. It returns nothing for a non-positive bucket or an empty window.
+func BucketBounds(start, end time.Time, bucket time.Duration) []IDRange {
+	if bucket <= 0 || !end.After(start) {
+		return []IDRange{}
+	}
+
+	result := make([]IDRange, 0, int(end.Sub(start)/bucket)+1)
+	for lo := start; lo.Before(end); lo = lo.Add(bucket) {
+		hi := lo.Add(bucket)
+		if hi.After(end) {
+			hi = end
+		}
+		loID, hiID := RangeBounds(lo, hi)
+		result = append(result, IDRange{Start: lo, End: hi, Lo: loID, Hi: hiID})
+	}
+	return result
+}
+
+// boundID builds the canonical ULID for t with every entropy byte set to fill
+func boundID(t time.Time, fill byte) string {
+	return boundULID(t, fill).String()
+}
+
+// boundULID is boundID in binary form
+func boundULID(t time.Time, fill byte) ulid.ULID {
+	var u ulid.ULID
+	_ = u.SetTime(clampTimestamp(t))
+	for i := 6; i < len(u); i++ {
+		u[i] = fill
+	}
+	return u
+}
@@ -0,0 +1,73 @@
+package id_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_MinMaxIDForTime(t *testing.T) {
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	gen := id.NewGenerator()
+	inside := gen.GenerateWithTime(at)
+
+	// Act
+	lo := id.MinIDForTime(at)
+	hi := id.MaxIDForTime(at)
+
+	// Assert
+	assert.Equal(t, "01HK153X000000000000000000", lo)
+	assert.Equal(t, "01HK153X00ZZZZZZZZZZZZZZZZ", hi)
+	assert.LessOrEqual(t, lo, inside)
+	assert.GreaterOrEqual(t, hi, inside)
+	assert.Less(t, hi, id.MinIDForTime(at.Add(time.Millisecond)))
+
+	assert.Equal(t, "00000000000000000000000000", id.MinIDForTime(time.Unix(-1, 0)))
+	assert.Equal(t, "7ZZZZZZZZZZZZZZZZZZZZZZZZZ", id.MaxIDForTime(time.Date(20000, 1, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func Test_RangeBounds(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	gen := id.NewGenerator()
+
+	// Act
+	lo, hi := id.RangeBounds(start, end)
+
+	// Assert
+	for _, tc := range []struct {
+		at   time.Time
+		want bool
+	}{
+		{start, true},
+		{end.Add(-time.Millisecond), true},
+		{end, false},
+		{start.Add(-time.Millisecond), false},
+	} {
+		s := gen.GenerateWithTime(tc.at)
+		assert.Equal(t, tc.want, s >= lo && s < hi, tc.at)
+	}
+}
+
+func Test_BucketBounds(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	buckets := id.BucketBounds(start, start.Add(150*time.Minute), time.Hour)
+
+	// Assert
+	require.Len(t, buckets, 3)
+	assert.True(t, buckets[2].Start.Equal(start.Add(2*time.Hour)))
+	assert.True(t, buckets[2].End.Equal(start.Add(150*time.Minute)))
+	for i := 1; i < len(buckets); i++ {
+		assert.Equal(t, buckets[i-1].Hi, buckets[i].Lo, "buckets are contiguous")
+	}
+	lo, _ := id.RangeBounds(start, start)
+	assert.Equal(t, lo, buckets[0].Lo)
+
+	assert.Empty(t, id.BucketBounds(start, start, time.Hour))
+	assert.Empty(t, id.BucketBounds(start, start.Add(time.Hour), 0))
+}
@@ -0,0 +1,373 @@
+package id
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultBufferSize is the capacity NewBufferedGenerator uses for non-positive sizes
+const DefaultBufferSize = 1024
+
+// BufferedGenerator pre-generates IDs in a background goroutine so Generate
+// on the hot path only claims the next slot of a ready batch with a single
+// atomic add, without taking a lock. It refills in batches whenever fewer
+// than three quarters of its size remain. Buffered IDs carry the time they
+// were generated, not the time they are handed out, so timestamps may lag
+// by however long an ID waits in the buffer. Before Start, once the buffer
+// is empty, or after Close, Generate falls back to generating inline. If
+// the entropy source fails the background goroutine stops, Err reports
+// why, and Generate keeps falling back. All other methods are served
+// directly by the underlying generator.
+type BufferedGenerator struct {
+	*generator
+	size   int
+	low    int
+	maxAge time.Duration
+	wake   chan struct{}
+	wg     sync.WaitGroup
+
+	// current is the batch Generate claims from; queued counts the IDs in
+	// the batches waiting behind it so the depth can be read without b.mu
+	current atomic.Pointer[idBatch]
+	queued  atomic.Int64
+	misses  atomic.Uint64
+
+	mu       sync.Mutex
+	queue    []*idBatch
+	served   uint64
+	refills  uint64
+	refilled uint64
+	stale    uint64
+	started  bool
+	closed   bool
+	cancel   context.CancelFunc
+	err      error
+}
+
+// idBatch is a run of IDs generated together, handed out in order by
+// atomically advancing next. Claims past the end overshoot next and fail.
+type idBatch struct {
+	ids  []string
+	at   time.Time
+	next atomic.Int64
+}
+
+// claimed returns how many of the batch's IDs have been handed out
+func (bt *idBatch) claimed() int {
+	return min(int(bt.next.Load()), len(bt.ids))
+}
+
+// NewBufferedGenerator creates a generator that keeps up to size IDs ready
+// once started
+func NewBufferedGenerator(size int) *BufferedGenerator {
+	return NewBufferedGeneratorFrom(NewGenerator(), size)
+}
+
+// NewBufferedGeneratorFrom buffers IDs produced by an existing generator
+func NewBufferedGeneratorFrom(base *generator, size int) *BufferedGenerator {
+	if size <= 0 {
+		size = DefaultBufferSize
+	}
+	return newBufferedGenerator(base, size, max(size-size/4, 1), 0)
+}
+
+// newBufferedGenerator creates a buffer of size IDs that refills whenever
+// it falls below low and discards IDs older than a positive maxAge
+func newBufferedGenerator(base *generator, size, low int, maxAge time.Duration) *BufferedGenerator {
+	return &BufferedGenerator{
+		generator: base,
+		size:      size,
+		low:       low,
+		maxAge:    max(maxAge, 0),
+		wake:      make(chan struct{}, 1),
+	}
+}
+
+// Start fills the buffer and launches the background goroutine that keeps
+// it filled until ctx is canceled or Close is called
+func (b *BufferedGenerator) Start(ctx context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return ErrClosed
+	}
+	if b.started {
+		return ErrAlreadyStarted
+	}
+
+	ctx, b.cancel = context.WithCancel(ctx)
+	b.started = true
+	b.signal()
+	b.wg.Add(1)
+	go b.fill(ctx)
+	return nil
+}
+
+// Generate returns a pre-generated ID, or a freshly generated one if the buffer is empty
+func (b *BufferedGenerator) Generate() string {
+	id, left, ok := b.next()
+	if left < b.low {
+		b.signal()
+	}
+	if ok {
+		return id
+	}
+	b.misses.Add(1)
+	return b.generator.Generate()
+}
+
+// Buffered returns the number of IDs currently waiting in the buffer
+func (b *BufferedGenerator) Buffered() int {
+	return b.depth()
+}
+
+// Close stops the background goroutine and waits for it to exit, returning
+// the error that stopped it early, if any. IDs still in the buffer continue
+// to be handed out until drained.
+func (b *BufferedGenerator) Close() error {
+	b.mu.Lock()
+	b.closed = true
+	cancel := b.cancel
+	b.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+	b.wg.Wait()
+	return b.Err()
+}
+
+// Err returns the entropy failure that stopped the background goroutine,
+// or nil while it runs normally
+func (b *BufferedGenerator) Err() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.err
+}
+
+// Drain removes and returns every ID waiting in the buffer, so a shutting
+// down service can hand them off instead of discarding them
+func (b *BufferedGenerator) Drain() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	result := make([]string, 0, b.depth())
+	if current := b.current.Load(); current != nil {
+		result = append(result, b.retire(current)...)
+		b.current.Store(nil)
+	}
+	for _, bt := range b.queue {
+		result = append(result, bt.ids...)
+	}
+	b.queue = nil
+	b.queued.Store(0)
+	return result
+}
+
+// take claims up to n buffered IDs, counting the shortfall as misses, and
+// returns them with the shortfall
+func (b *BufferedGenerator) take(n int) ([]string, int) {
+	result := make([]string, 0, n)
+	left := 0
+	for len(result) < n {
+		id, remaining, ok := b.next()
+		left = remaining
+		if !ok {
+			break
+		}
+		result = append(result, id)
+	}
+	missing := n - len(result)
+	b.misses.Add(uint64(missing)) //nolint:gosec // G115: non-negative
+	if left < b.low {
+		b.signal()
+	}
+	return result, missing
+}
+
+// next claims the oldest buffered ID and reports how many are left behind
+// it. The common case, an unexpired current batch with IDs to spare, costs
+// a single atomic add; everything else is left to nextSlow.
+func (b *BufferedGenerator) next() (string, int, bool) {
+	if bt := b.current.Load(); bt != nil && b.maxAge == 0 {
+		if i := bt.next.Add(1); i <= int64(len(bt.ids)) {
+			return bt.ids[i-1], len(bt.ids) - int(i) + int(b.queued.Load()), true
+		}
+	}
+	return b.nextSlow()
+}
+
+// nextSlow claims the oldest buffered ID, moving on to the next batch once
+// the current one is used up or past maxAge
+func (b *BufferedGenerator) nextSlow() (string, int, bool) {
+	for {
+		bt := b.current.Load()
+		if bt != nil && !b.expired(bt.at) {
+			if i := int(bt.next.Add(1) - 1); i < len(bt.ids) {
+				return bt.ids[i], len(bt.ids) - i - 1 + int(b.queued.Load()), true
+			}
+		}
+		if !b.advance(bt) {
+			return "", 0, false
+		}
+	}
+}
+
+// advance replaces seen as the current batch with the oldest fresh queued
+// batch, reporting false if there is none. If another caller has already
+// moved past seen it leaves the new current batch alone.
+func (b *BufferedGenerator) advance(seen *idBatch) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.current.Load() != seen {
+		return true
+	}
+	if seen != nil {
+		b.stale += uint64(len(b.retire(seen)))
+		b.current.Store(nil)
+	}
+	if b.maxAge > 0 {
+		b.purgeBefore(b.now().Add(-b.maxAge))
+	}
+	if len(b.queue) == 0 {
+		return false
+	}
+	bt := b.queue[0]
+	b.queue[0] = nil
+	b.queue = b.queue[1:]
+	b.queued.Add(-int64(len(bt.ids)))
+	b.current.Store(bt)
+	return true
+}
+
+// retire claims whatever is left of bt, credits the IDs already handed out
+// from it as served, and returns the rest; callers must hold b.mu and then
+// replace bt as the current batch
+func (b *BufferedGenerator) retire(bt *idBatch) []string {
+	n := min(int(bt.next.Swap(int64(len(bt.ids)))), len(bt.ids))
+	b.served += uint64(n) //nolint:gosec // G115: non-negative
+	return bt.ids[n:]
+}
+
+// depth returns the number of IDs waiting in the current and queued batches
+func (b *BufferedGenerator) depth() int {
+	depth := int(b.queued.Load())
+	if bt := b.current.Load(); bt != nil {
+		depth += len(bt.ids) - bt.claimed()
+	}
+	return depth
+}
+
+// snapshot returns the buffer's depth and counters
+func (b *BufferedGenerator) snapshot() PoolStats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	served := b.served
+	if bt := b.current.Load(); bt != nil {
+		served += uint64(bt.claimed()) //nolint:gosec // G115: non-negative
+	}
+	return PoolStats{
+		Depth:    b.depth(),
+		Capacity: b.size,
+		Served:   served,
+		Misses:   b.misses.Load(),
+		Refills:  b.refills,
+		Refilled: b.refilled,
+		Stale:    b.stale,
+	}
+}
+
+// signal wakes the background goroutine without blocking
+func (b *BufferedGenerator) signal() {
+	if len(b.wake) > 0 {
+		return // a wakeup is already pending
+	}
+	select {
+	case b.wake <- struct{}{}:
+	default:
+	}
+}
+
+// expired reports whether a batch generated at at is older than a positive maxAge
+func (b *BufferedGenerator) expired(at time.Time) bool {
+	return b.maxAge > 0 && at.Before(b.now().Add(-b.maxAge))
+}
+
+// purgeBefore discards buffered IDs generated before cutoff; callers must
+// hold b.mu. Batches are in generation order, so the stale ones are first.
+func (b *BufferedGenerator) purgeBefore(cutoff time.Time) {
+	if bt := b.current.Load(); bt != nil && bt.at.Before(cutoff) {
+		b.stale += uint64(len(b.retire(bt)))
+		b.current.Store(nil)
+	}
+	for len(b.queue) > 0 && b.queue[0].at.Before(cutoff) {
+		bt := b.queue[0]
+		b.queue[0] = nil
+		b.queue = b.queue[1:]
+		b.queued.Add(-int64(len(bt.ids)))
+		b.stale += uint64(len(bt.ids))
+	}
+}
+
+// fill tops the buffer up to size whenever it is signaled below the low
+// watermark, until ctx is done or generation fails. With a maxAge it also
+// wakes every maxAge/2 to replace IDs past half their tolerance, so a
+// buffer that is rarely drawn on stays fresh.
+func (b *BufferedGenerator) fill(ctx context.Context) {
+	defer b.wg.Done()
+
+	var tick <-chan time.Time
+	if b.maxAge > 0 {
+		ticker := time.NewTicker(max(b.maxAge/2, time.Millisecond))
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		refresh := false
+		select {
+		case <-b.wake:
+		case <-tick:
+			refresh = true
+		case <-ctx.Done():
+			return
+		}
+
+		b.mu.Lock()
+		if refresh {
+			b.purgeBefore(b.now().Add(-b.maxAge / 2))
+		} else if b.maxAge > 0 {
+			b.purgeBefore(b.now().Add(-b.maxAge))
+		}
+		b.mu.Unlock()
+		missing := 0
+		if depth := b.depth(); refresh || depth < b.low {
+			missing = b.size - depth
+		}
+		if missing <= 0 {
+			continue
+		}
+
+		at := b.now()
+		ids, err := b.generator.TryGenerateBatch(missing)
+
+		b.mu.Lock()
+		if err != nil {
+			b.err = err
+			b.mu.Unlock()
+			return
+		}
+		bt := &idBatch{ids: ids, at: at}
+		if b.current.Load() == nil && len(b.queue) == 0 {
+			b.current.Store(bt)
+		} else {
+			b.queue = append(b.queue, bt)
+			b.queued.Add(int64(len(ids)))
+		}
+		b.refills++
+		b.refilled += uint64(len(ids)) //nolint:gosec // G115: non-negative
+		b.mu.Unlock()
+	}
+}
@@ -0,0 +1,144 @@
+package id_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_BufferedGenerator(t *testing.T) {
+	gen := id.NewBufferedGenerator(64)
+	require.NoError(t, gen.Start(context.Background()))
+	defer gen.Close()
+
+	require.Eventually(t, func() bool { return gen.Buffered() == 64 }, time.Second, time.Millisecond)
+
+	// Act
+	seen := make(map[string]bool)
+	for i := 0; i < 200; i++ {
+		ulid := gen.Generate()
+
+		// Assert
+		require.True(t, gen.IsIdValid(ulid))
+		require.False(t, seen[ulid])
+		seen[ulid] = true
+	}
+}
+
+func Test_BufferedGenerator_Concurrent(t *testing.T) {
+	gen := id.NewBufferedGenerator(0)
+	require.NoError(t, gen.Start(context.Background()))
+	defer gen.Close()
+
+	var mu sync.Mutex
+	seen := make(map[string]bool)
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				ulid := gen.Generate()
+				mu.Lock()
+				seen[ulid] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	assert.Len(t, seen, 4000)
+}
+
+func Test_BufferedGenerator_CountsEveryIDAcrossBatches(t *testing.T) {
+	pool := id.NewPool(id.PoolOptions{Size: 16, LowWatermark: 4})
+	require.NoError(t, pool.Start(context.Background()))
+	defer pool.Close()
+
+	var wg sync.WaitGroup
+	perWorker := make([][]string, 4)
+	for w := range perWorker {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 300; i++ {
+				perWorker[w] = append(perWorker[w], pool.Get())
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Assert: every ID is unique and counted as either served or a miss
+	seen := make(map[string]bool)
+	for _, ids := range perWorker {
+		for _, s := range ids {
+			seen[s] = true
+		}
+	}
+	assert.Len(t, seen, 1200)
+	stats := pool.Stats()
+	assert.Equal(t, uint64(1200), stats.Served+stats.Misses)
+}
+
+func Test_BufferedGenerator_Lifecycle(t *testing.T) {
+	gen := id.NewBufferedGenerator(4)
+
+	// Before Start, generation happens inline
+	assert.True(t, gen.IsIdValid(gen.Generate()))
+	assert.Zero(t, gen.Buffered())
+
+	require.NoError(t, gen.Start(context.Background()))
+	assert.ErrorIs(t, gen.Start(context.Background()), id.ErrAlreadyStarted)
+	require.Eventually(t, func() bool { return gen.Buffered() == 4 }, time.Second, time.Millisecond)
+
+	// Act
+	require.NoError(t, gen.Close())
+	require.NoError(t, gen.Close())
+	pending := gen.Drain()
+
+	// Assert
+	assert.Len(t, pending, 4)
+	assert.Zero(t, gen.Buffered())
+	assert.True(t, gen.IsIdValid(gen.Generate()))
+	assert.ErrorIs(t, gen.Start(context.Background()), id.ErrClosed)
+}
+
+func Test_BufferedGenerator_ContextCancel(t *testing.T) {
+	gen := id.NewBufferedGenerator(4)
+	ctx, cancel := context.WithCancel(context.Background())
+	require.NoError(t, gen.Start(ctx))
+
+	// Act
+	cancel()
+
+	// Assert: Close returns promptly once the goroutine has observed cancellation
+	done := make(chan struct{})
+	go func() {
+		_ = gen.Close()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Close did not return after context cancellation")
+	}
+}
+
+func Test_BufferedGenerator_StopsOnEntropyFailure(t *testing.T) {
+	gen := id.NewBufferedGeneratorFrom(id.NewGeneratorWithEntropy(failingReader{}), 4)
+
+	// Act
+	require.NoError(t, gen.Start(context.Background()))
+
+	// Assert
+	require.Eventually(t, func() bool { return gen.Err() != nil }, time.Second, time.Millisecond)
+	assert.ErrorContains(t, gen.Err(), "hardware RNG exhausted")
+	assert.Zero(t, gen.Buffered())
+	assert.ErrorContains(t, gen.Close(), "hardware RNG exhausted")
+}
@@ -0,0 +1,132 @@
+package id
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/oklog/ulid"
+)
+
+// DefaultValidationCacheSize is the capacity NewCachedValidator uses for non-positive sizes
+const DefaultValidationCacheSize = 1024
+
+// CacheStats reports the effectiveness of a CachedValidator
+type CacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Size      int
+	Capacity  int
+}
+
+// HitRate returns the fraction of lookups served from the cache
+func (s CacheStats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// validationResult is a memoized ValidateAndNormalize outcome
+type validationResult struct {
+	input      string
+	normalized string
+	err        error
+}
+
+// CachedValidator memoizes validation of recently seen ID strings in a
+// fixed-size LRU, for workloads such as gateways that validate the same hot
+// IDs over and over. Only strings of ULID length are cached, so arbitrary
+// input cannot pin large strings in memory; others go straight to the
+// underlying validator and count as misses. It is safe for concurrent use.
+type CachedValidator struct {
+	validator Validator
+	capacity  int
+
+	mu    sync.Mutex
+	order *list.List
+	items map[string]*list.Element
+	stats CacheStats
+}
+
+// NewCachedValidator creates a validator that caches up to size results
+func NewCachedValidator(size int) *CachedValidator {
+	return NewCachedValidatorFor(NewGenerator(), size)
+}
+
+// NewCachedValidatorFor caches the results of an existing Validator
+func NewCachedValidatorFor(v Validator, size int) *CachedValidator {
+	if size <= 0 {
+		size = DefaultValidationCacheSize
+	}
+	return &CachedValidator{
+		validator: v,
+		capacity:  size,
+		order:     list.New(),
+		items:     make(map[string]*list.Element, size),
+	}
+}
+
+// IsIdValid validates an ID, consulting the cache first
+func (c *CachedValidator) IsIdValid(s string) bool {
+	_, err := c.ValidateAndNormalize(s)
+	return err == nil
+}
+
+// ValidateAndNormalize checks and normalizes an ID, consulting the cache first
+func (c *CachedValidator) ValidateAndNormalize(id string) (string, error) {
+	if len(id) != ulid.EncodedSize {
+		c.mu.Lock()
+		c.stats.Misses++
+		c.mu.Unlock()
+		return c.validator.ValidateAndNormalize(id)
+	}
+
+	c.mu.Lock()
+	if el, ok := c.items[id]; ok {
+		c.order.MoveToFront(el)
+		c.stats.Hits++
+		result := el.Value.(*validationResult) //nolint:errcheck // only *validationResult is stored
+		c.mu.Unlock()
+		return result.normalized, result.err
+	}
+	c.stats.Misses++
+	c.mu.Unlock()
+
+	normalized, err := c.validator.ValidateAndNormalize(id)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.items[id]; !ok {
+		c.items[id] = c.order.PushFront(&validationResult{input: id, normalized: normalized, err: err})
+		if c.order.Len() > c.capacity {
+			oldest := c.order.Back()
+			c.order.Remove(oldest)
+			delete(c.items, oldest.Value.(*validationResult).input) //nolint:errcheck // only *validationResult is stored
+			c.stats.Evictions++
+		}
+	}
+	return normalized, err
+}
+
+// Stats returns a snapshot of the cache counters
+func (c *CachedValidator) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Size = c.order.Len()
+	stats.Capacity = c.capacity
+	return stats
+}
+
+// Reset empties the cache and zeroes its counters
+func (c *CachedValidator) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.order.Init()
+	c.items = make(map[string]*list.Element, c.capacity)
+	c.stats = CacheStats{}
+}
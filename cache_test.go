@@ -0,0 +1,98 @@
+package id_test
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CachedValidator(t *testing.T) {
+	gen := id.NewGenerator()
+	v := id.NewCachedValidator(2)
+	a, b, c := gen.Generate(), gen.Generate(), gen.Generate()
+
+	// Act
+	assert.True(t, v.IsIdValid(a))
+	assert.True(t, v.IsIdValid(a))
+	assert.False(t, v.IsIdValid("01ARZ3NDEKTSV4RRFFQ69G5F!!"))
+	assert.False(t, v.IsIdValid("01ARZ3NDEKTSV4RRFFQ69G5F!!"))
+	assert.True(t, v.IsIdValid(b)) // Evicts a
+	assert.True(t, v.IsIdValid(c)) // Evicts the invalid ID
+	assert.True(t, v.IsIdValid(a))
+
+	// Assert
+	stats := v.Stats()
+	assert.Equal(t, uint64(2), stats.Hits)
+	assert.Equal(t, uint64(5), stats.Misses)
+	assert.Equal(t, uint64(3), stats.Evictions)
+	assert.Equal(t, 2, stats.Size)
+	assert.InDelta(t, 2.0/7.0, stats.HitRate(), 0.001)
+
+	v.Reset()
+	assert.Equal(t, id.CacheStats{Capacity: 2}, v.Stats())
+}
+
+func Test_CachedValidator_SkipsWrongLengths(t *testing.T) {
+	v := id.NewCachedValidator(2)
+	long := strings.Repeat("0", 1<<20)
+
+	// Act
+	valid := v.IsIdValid(long)
+	again := v.IsIdValid(long)
+	_, err := v.ValidateAndNormalize("invalid")
+
+	// Assert
+	assert.False(t, valid)
+	assert.False(t, again)
+	assert.ErrorIs(t, err, id.ErrWrongLength)
+	stats := v.Stats()
+	assert.Zero(t, stats.Size, "only ULID-length strings are cached")
+	assert.Zero(t, stats.Hits)
+	assert.Equal(t, uint64(3), stats.Misses)
+}
+
+func Test_CachedValidator_Normalize(t *testing.T) {
+	gen := id.NewGenerator()
+	v := id.NewCachedValidator(0)
+	ulid := gen.Generate()
+
+	// Act
+	normalized, err := v.ValidateAndNormalize(strings.ToLower(ulid))
+	require.NoError(t, err)
+	cached, err := v.ValidateAndNormalize(strings.ToLower(ulid))
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, ulid, normalized)
+	assert.Equal(t, ulid, cached)
+	_, err = v.ValidateAndNormalize("")
+	assert.Error(t, err)
+	assert.Equal(t, 0.0, id.CacheStats{}.HitRate())
+}
+
+func Test_CachedValidator_Concurrent(t *testing.T) {
+	gen := id.NewGenerator()
+	v := id.NewCachedValidator(8)
+	ids := gen.GenerateBatch(16)
+
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				assert.True(t, v.IsIdValid(ids[i%len(ids)]))
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	stats := v.Stats()
+	assert.Equal(t, uint64(1600), stats.Hits+stats.Misses)
+	assert.LessOrEqual(t, stats.Size, 8)
+}
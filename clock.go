@@ -0,0 +1,148 @@
+package id
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock supplies the current time to a generator
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is the Clock backed by time.Now
+var SystemClock Clock = systemClock{}
+
+// systemClock reads the wall clock
+type systemClock struct{}
+
+// Now returns time.Now()
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// clockOrSystem returns c, or SystemClock if c is nil
+func clockOrSystem(c Clock) Clock {
+	if c == nil {
+		return SystemClock
+	}
+	return c
+}
+
+// FakeClock is a Clock that only moves when told to, for deterministic
+// tests. It is safe for concurrent use.
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFakeClock creates a FakeClock frozen at t
+func NewFakeClock(t time.Time) *FakeClock {
+	return &FakeClock{now: t}
+}
+
+// Now returns the clock's current time
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Set moves the clock to t
+func (c *FakeClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = t
+}
+
+// Advance moves the clock forward by d and returns the new time
+func (c *FakeClock) Advance(d time.Duration) time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	return c.now
+}
+
+// Sleep advances the clock by d instead of blocking, so code that waits on
+// the clock, such as RateLimitedClock, runs instantly in tests
+func (c *FakeClock) Sleep(d time.Duration) {
+	c.Advance(max(d, 0))
+}
+
+// sleep waits d on clock: clocks with a Sleep method, such as FakeClock,
+// handle the wait themselves and any other clock waits in real time
+func sleep(clock Clock, d time.Duration) {
+	if s, ok := clock.(interface{ Sleep(time.Duration) }); ok {
+		s.Sleep(d)
+		return
+	}
+	time.Sleep(d)
+}
+
+// SteppedClock is a Clock that moves by a fixed step on every reading, for
+// tests that need time to pass between IDs without sleeping. A negative step
+// simulates a clock running backwards. It is safe for concurrent use.
+type SteppedClock struct {
+	mu   sync.Mutex
+	next time.Time
+	step time.Duration
+}
+
+// NewSteppedClock creates a SteppedClock whose first reading is start
+func NewSteppedClock(start time.Time, step time.Duration) *SteppedClock {
+	return &SteppedClock{next: start, step: step}
+}
+
+// Now returns the current reading and moves the clock by its step
+func (c *SteppedClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.next
+	c.next = c.next.Add(c.step)
+	return now
+}
+
+// LeapSecondClock simulates how a host clock reports a positive leap second
+// inserted at leap. Its base clock is taken to count true elapsed time, which
+// the clock converts to the UTC a host would show.
+//
+// Without a smear the clock steps back one second at leap and replays that
+// second, as the kernel does. With a smear window the extra second is spread
+// linearly across a window centred on leap, as public NTP pools do, so the
+// clock runs slightly slow but never backwards. Either way readings after the
+// event are one second behind the base.
+type LeapSecondClock struct {
+	base  Clock
+	leap  time.Time
+	smear time.Duration
+}
+
+// NewLeapSecondClock creates a LeapSecondClock over base, or SystemClock if
+// base is nil. A smear of zero or less steps the clock instead of smearing.
+func NewLeapSecondClock(base Clock, leap time.Time, smear time.Duration) *LeapSecondClock {
+	if base == nil {
+		base = SystemClock
+	}
+	return &LeapSecondClock{base: base, leap: leap, smear: max(smear, 0)}
+}
+
+// Now returns the base time adjusted for the leap second
+func (c *LeapSecondClock) Now() time.Time {
+	now := c.base.Now()
+	if c.smear == 0 {
+		if now.Before(c.leap) {
+			return now
+		}
+		return now.Add(-time.Second)
+	}
+
+	start := c.leap.Add(-c.smear / 2)
+	switch elapsed := now.Sub(start); {
+	case elapsed <= 0:
+		return now
+	case elapsed >= c.smear:
+		return now.Add(-time.Second)
+	default:
+		return now.Add(-time.Duration(float64(time.Second) * float64(elapsed) / float64(c.smear)))
+	}
+}
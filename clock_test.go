@@ -0,0 +1,133 @@
+package id_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_FakeClock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := id.NewFakeClock(start)
+
+	// Act
+	advanced := clock.Advance(time.Hour)
+
+	// Assert
+	assert.Equal(t, start.Add(time.Hour), advanced)
+	assert.Equal(t, advanced, clock.Now())
+	clock.Set(start)
+	assert.Equal(t, start, clock.Now())
+}
+
+func Test_SystemClock(t *testing.T) {
+	assert.WithinDuration(t, time.Now(), id.SystemClock.Now(), time.Second)
+}
+
+func Test_NewGeneratorWithOptions_Clock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := id.NewFakeClock(start)
+	gen := id.NewGeneratorWithOptions(id.WithClock(clock))
+
+	// Act
+	generated := gen.Generate()
+	batch := gen.GenerateBatch(3)
+	clock.Advance(90 * time.Minute)
+
+	// Assert
+	ts, err := gen.ExtractTimestamp(generated)
+	require.NoError(t, err)
+	assert.True(t, ts.Equal(start))
+	for _, b := range batch {
+		bts, err := gen.ExtractTimestamp(b)
+		require.NoError(t, err)
+		assert.True(t, bts.Equal(start))
+	}
+
+	age, err := gen.Age(generated)
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Minute, age)
+
+	expired, err := gen.IsExpired(generated, time.Hour)
+	require.NoError(t, err)
+	assert.True(t, expired)
+	expired, err = gen.IsExpired(generated, 2*time.Hour)
+	require.NoError(t, err)
+	assert.False(t, expired)
+
+	assert.True(t, gen.GenerateID().Timestamp().Equal(start.Add(90*time.Minute)))
+}
+
+func Test_NewGeneratorWithOptions_Entropy(t *testing.T) {
+	clock := id.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	// Act
+	a := id.NewGeneratorWithOptions(id.WithClock(clock), id.WithEntropy(zeroReader{})).Generate()
+	b := id.NewGeneratorWithOptions(id.WithClock(clock), id.WithEntropy(zeroReader{})).Generate()
+
+	// Assert
+	assert.Equal(t, a, b)
+	assert.Equal(t, "0000000000000000", a[10:])
+}
+
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
+
+func Test_SteppedClock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := id.NewSteppedClock(start, -time.Millisecond)
+
+	// Act & Assert
+	assert.Equal(t, start, clock.Now())
+	assert.Equal(t, start.Add(-time.Millisecond), clock.Now())
+	assert.Equal(t, start.Add(-2*time.Millisecond), clock.Now())
+}
+
+func Test_LeapSecondClock_Step(t *testing.T) {
+	leap := time.Date(2016, 12, 31, 23, 59, 60, 0, time.UTC)
+	base := id.NewFakeClock(leap.Add(-time.Millisecond))
+	clock := id.NewLeapSecondClock(base, leap, 0)
+
+	// Act
+	before := clock.Now()
+	base.Advance(time.Millisecond)
+	after := clock.Now()
+
+	// Assert
+	assert.Equal(t, leap.Add(-time.Millisecond), before)
+	assert.Equal(t, leap.Add(-time.Second), after, "the last second is replayed")
+}
+
+func Test_LeapSecondClock_Smear(t *testing.T) {
+	leap := time.Date(2016, 12, 31, 23, 59, 60, 0, time.UTC)
+	smear := 24 * time.Hour
+	base := id.NewFakeClock(leap.Add(-smear))
+	clock := id.NewLeapSecondClock(base, leap, smear)
+
+	// Act
+	early := clock.Now()
+	base.Set(leap)
+	midpoint := clock.Now()
+	base.Set(leap.Add(smear))
+	late := clock.Now()
+
+	// Assert
+	assert.Equal(t, leap.Add(-smear), early)
+	assert.Equal(t, leap.Add(-500*time.Millisecond), midpoint)
+	assert.Equal(t, leap.Add(smear-time.Second), late)
+
+	var previous time.Time
+	for offset := -smear; offset <= smear; offset += time.Minute {
+		base.Set(leap.Add(offset))
+		now := clock.Now()
+		require.False(t, now.Before(previous), "smeared clock ran backwards at %v", offset)
+		previous = now
+	}
+}
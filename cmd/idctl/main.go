@@ -0,0 +1,339 @@
+// Command idctl generates, inspects, converts, sorts, and analyzes ULIDs.
+//
+// Usage:
+//
+//	idctl gen [-n count] [--secure]
+//	idctl inspect [--json] [id ...]
+//	idctl convert --to uuid|base58|hex|base64url|lower|ulid [--from ...] [id ...]
+//	idctl sort < ids.txt
+//	idctl analyze < ids.txt
+//
+// Commands taking IDs read them one per line from standard input when none
+// are given as arguments.
+package main
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/bold-minds/id"
+)
+
+// Exit codes
+const (
+	exitOK      = 0
+	exitInvalid = 1
+	exitUsage   = 2
+)
+
+const usage = `usage: idctl <command> [flags] [id ...]
+
+commands:
+  gen       generate IDs
+  inspect   show an ID's timestamp, age, entropy, and UUID form
+  convert   re-encode IDs in another format
+  sort      sort IDs from standard input chronologically
+  analyze   summarize IDs from standard input as JSON
+
+Run "idctl <command> -h" for a command's flags.
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes the command in args and returns the exit code
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return exitUsage
+	}
+
+	commands := map[string]func([]string, io.Reader, io.Writer, io.Writer) int{
+		"gen":     runGen,
+		"inspect": runInspect,
+		"convert": runConvert,
+		"sort":    runSort,
+		"analyze": runAnalyze,
+	}
+	command, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "idctl: unknown command %q\n\n%s", args[0], usage)
+		return exitUsage
+	}
+	return command(args[1:], stdin, stdout, stderr)
+}
+
+// newFlagSet creates a flag set that reports errors to stderr
+func newFlagSet(name string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet("idctl "+name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	return fs
+}
+
+// parseFlags parses args into fs, returning the exit code for a failure
+func parseFlags(fs *flag.FlagSet, args []string) (int, bool) {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK, false
+		}
+		return exitUsage, false
+	}
+	return exitOK, true
+}
+
+// runGen prints newly generated IDs
+func runGen(args []string, _ io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("gen", stderr)
+	count := fs.Int("n", 1, "number of IDs to generate")
+	secure := fs.Bool("secure", false, "draw entropy from crypto/rand")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if *count < 0 {
+		fmt.Fprintf(stderr, "idctl gen: -n must not be negative, got %d\n", *count)
+		return exitUsage
+	}
+
+	gen := id.NewGenerator()
+	if *secure {
+		gen = id.NewSecureGenerator()
+	}
+	w := bufio.NewWriter(stdout)
+	for _, generated := range gen.GenerateBatch(*count) {
+		fmt.Fprintln(w, generated)
+	}
+	return flushed(w, stderr)
+}
+
+// inspectOutput is the JSON form of an inspected ID
+type inspectOutput struct {
+	ID        string    `json:"id"`
+	Timestamp time.Time `json:"timestamp"`
+	Age       string    `json:"age"`
+	Entropy   string    `json:"entropy"`
+	UUID      string    `json:"uuid"`
+	Hex       string    `json:"hex"`
+	Notes     []string  `json:"notes,omitempty"`
+}
+
+// runInspect prints the decomposition of each ID
+func runInspect(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("inspect", stderr)
+	asJSON := fs.Bool("json", false, "print one JSON object per ID")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	w := bufio.NewWriter(stdout)
+	code := forEachID(fs.Args(), stdin, stderr, func(input string) error {
+		info, err := id.Inspect(input)
+		if err != nil {
+			return fmt.Errorf("%s (%s)", err, strings.Join(info.Notes, "; "))
+		}
+
+		out := inspectOutput{
+			ID:        info.ID,
+			Timestamp: info.Timestamp,
+			Age:       info.Age.Round(time.Millisecond).String(),
+			Entropy:   hex.EncodeToString(info.Entropy[:]),
+			UUID:      info.UUID,
+			Hex:       info.Hex,
+			Notes:     info.Notes,
+		}
+		if *asJSON {
+			return json.NewEncoder(w).Encode(out)
+		}
+		fmt.Fprintf(w, "ID:        %s\nTimestamp: %s\nAge:       %s\nEntropy:   %s\nUUID:      %s\nHex:       %s\n",
+			out.ID, out.Timestamp.Format(time.RFC3339Nano), out.Age, out.Entropy, out.UUID, out.Hex)
+		for _, note := range out.Notes {
+			fmt.Fprintf(w, "Note:      %s\n", note)
+		}
+		fmt.Fprintln(w)
+		return nil
+	})
+	return max(code, flushed(w, stderr))
+}
+
+// formats maps convert's format names to encoders; "uuid" is handled apart
+var formats = map[string]id.Encoder{
+	"ulid":      ulidEncoder{},
+	"lower":     id.CrockfordLower,
+	"base58":    id.Base58,
+	"hex":       id.Hex,
+	"base64url": id.Base64URL,
+	"uuid":      uuidEncoder{},
+}
+
+// runConvert re-encodes each ID from one format to another
+func runConvert(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("convert", stderr)
+	to := fs.String("to", "", "output format: uuid, base58, hex, base64url, lower, or ulid")
+	from := fs.String("from", "ulid", "input format, from the same list")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	decoder, ok := formats[*from]
+	if !ok {
+		fmt.Fprintf(stderr, "idctl convert: unknown -from format %q\n", *from)
+		return exitUsage
+	}
+	encoder, ok := formats[*to]
+	if !ok {
+		fmt.Fprintf(stderr, "idctl convert: unknown -to format %q\n", *to)
+		return exitUsage
+	}
+
+	w := bufio.NewWriter(stdout)
+	code := forEachID(fs.Args(), stdin, stderr, func(input string) error {
+		parsed, err := decoder.Decode(input)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, encoder.Encode(parsed))
+		return nil
+	})
+	return max(code, flushed(w, stderr))
+}
+
+// runSort prints the IDs from stdin chronologically, invalid ones last
+func runSort(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("sort", stderr)
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	ids, err := readLines(stdin)
+	if err != nil {
+		fmt.Fprintf(stderr, "idctl sort: %v\n", err)
+		return exitInvalid
+	}
+	w := bufio.NewWriter(stdout)
+	for _, sorted := range id.SortChronologically(ids) {
+		fmt.Fprintln(w, sorted)
+	}
+	return flushed(w, stderr)
+}
+
+// analyzeOutput is the JSON summary printed by analyze
+type analyzeOutput struct {
+	Count     int       `json:"count"`
+	Invalid   int       `json:"invalid"`
+	FirstID   string    `json:"first_id,omitempty"`
+	LastID    string    `json:"last_id,omitempty"`
+	FirstTime time.Time `json:"first_time"`
+	LastTime  time.Time `json:"last_time"`
+	TimeSpan  string    `json:"time_span"`
+	Rate      float64   `json:"rate_per_second"`
+}
+
+// runAnalyze prints the Stats of the IDs from stdin as JSON
+func runAnalyze(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("analyze", stderr)
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	ids, err := readLines(stdin)
+	if err != nil {
+		fmt.Fprintf(stderr, "idctl analyze: %v\n", err)
+		return exitInvalid
+	}
+	// AnalyzeIDs errors only when no ID is valid, which the counts report
+	stats, _ := id.AnalyzeIDs(ids)
+
+	enc := json.NewEncoder(stdout)
+	enc.SetIndent("", "  ")
+	err = enc.Encode(analyzeOutput{
+		Count:     stats.Count,
+		Invalid:   len(ids) - stats.Count,
+		FirstID:   stats.FirstID,
+		LastID:    stats.LastID,
+		FirstTime: stats.FirstTime.UTC(),
+		LastTime:  stats.LastTime.UTC(),
+		TimeSpan:  stats.TimeSpan.String(),
+		Rate:      stats.Rate(),
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "idctl analyze: %v\n", err)
+		return exitInvalid
+	}
+	return exitOK
+}
+
+// forEachID calls fn for each ID in args, or each line of stdin if there are
+// none, reporting failures to stderr and carrying on
+func forEachID(args []string, stdin io.Reader, stderr io.Writer, fn func(string) error) int {
+	if len(args) == 0 {
+		lines, err := readLines(stdin)
+		if err != nil {
+			fmt.Fprintf(stderr, "idctl: %v\n", err)
+			return exitInvalid
+		}
+		args = lines
+	}
+
+	code := exitOK
+	for _, input := range args {
+		if err := fn(input); err != nil {
+			fmt.Fprintf(stderr, "idctl: %q: %v\n", input, err)
+			code = exitInvalid
+		}
+	}
+	return code
+}
+
+// readLines returns the non-blank lines of r, trimmed
+func readLines(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, scanner.Err()
+}
+
+// flushed flushes w and returns the exit code for the result
+func flushed(w *bufio.Writer, stderr io.Writer) int {
+	if err := w.Flush(); err != nil {
+		fmt.Fprintf(stderr, "idctl: %v\n", err)
+		return exitInvalid
+	}
+	return exitOK
+}
+
+// ulidEncoder converts to and from canonical ULIDs, accepting either case
+type ulidEncoder struct{}
+
+func (ulidEncoder) Encode(parsed id.ID) string {
+	return parsed.String()
+}
+
+func (ulidEncoder) Decode(s string) (id.ID, error) {
+	return id.CrockfordLower.Decode(s)
+}
+
+// uuidEncoder converts to and from RFC 4122 UUIDs with the same 16 bytes
+type uuidEncoder struct{}
+
+func (uuidEncoder) Encode(parsed id.ID) string {
+	return fmt.Sprintf("%x-%x-%x-%x-%x", parsed[0:4], parsed[4:6], parsed[6:8], parsed[8:10], parsed[10:16])
+}
+
+func (uuidEncoder) Decode(s string) (id.ID, error) {
+	ulid, err := id.NewGenerator().FromUUID(s)
+	if err != nil {
+		return id.ID{}, err
+	}
+	return id.CrockfordLower.Decode(ulid)
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runCase runs idctl with args and stdin, returning the exit code and output
+func runCase(stdin string, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(args, strings.NewReader(stdin), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func Test_Gen(t *testing.T) {
+	// Act
+	code, out, _ := runCase("", "gen", "-n", "3", "--secure")
+
+	// Assert
+	assert.Equal(t, exitOK, code)
+	ids := strings.Fields(out)
+	require.Len(t, ids, 3)
+	for _, generated := range ids {
+		assert.NoError(t, id.Validate(generated))
+	}
+}
+
+func Test_Inspect(t *testing.T) {
+	// Act
+	code, out, _ := runCase("", "inspect", "--json", "01ARZ3NDEKTSV4RRFFQ69G5FAV")
+
+	// Assert
+	assert.Equal(t, exitOK, code)
+	var got inspectOutput
+	require.NoError(t, json.Unmarshal([]byte(out), &got))
+	assert.Equal(t, "01ARZ3NDEKTSV4RRFFQ69G5FAV", got.ID)
+	assert.Equal(t, time.UnixMilli(1469922850259).UTC(), got.Timestamp)
+	assert.Equal(t, "d6764c61efb99302bd5b", got.Entropy)
+	assert.Equal(t, "01563e3a"+"b5d3"+got.Entropy[:4], strings.ReplaceAll(got.UUID, "-", "")[:16])
+	assert.Equal(t, strings.ReplaceAll(got.UUID, "-", ""), got.Hex)
+}
+
+func Test_Inspect_Text(t *testing.T) {
+	// Act
+	code, out, errOut := runCase("01arz3ndektsv4rrffq69g5fav\nnot-an-id\n", "inspect")
+
+	// Assert
+	assert.Equal(t, exitInvalid, code)
+	assert.Contains(t, out, "ID:        01ARZ3NDEKTSV4RRFFQ69G5FAV")
+	assert.Contains(t, out, "Note:")
+	assert.Contains(t, errOut, "not-an-id")
+}
+
+func Test_Convert(t *testing.T) {
+	ulid := "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+
+	for format, want := range map[string]string{
+		"base58": mustEncode(t, id.EncodeBase58, ulid),
+		"hex":    mustEncode(t, id.EncodeHex, ulid),
+		"lower":  strings.ToLower(ulid),
+	} {
+		// Act
+		code, out, _ := runCase("", "convert", "--to", format, ulid)
+
+		// Assert
+		assert.Equal(t, exitOK, code, format)
+		assert.Equal(t, want+"\n", out, format)
+
+		code, back, _ := runCase(out, "convert", "--from", format, "--to", "ulid")
+		assert.Equal(t, exitOK, code, format)
+		assert.Equal(t, ulid+"\n", back, format)
+	}
+}
+
+func Test_Convert_UUID(t *testing.T) {
+	ulid := "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+	uuid, err := id.NewGenerator().ToUUID(ulid)
+	require.NoError(t, err)
+
+	// Act
+	code, out, _ := runCase("", "convert", "--to", "uuid", ulid)
+	_, back, _ := runCase(out, "convert", "--from", "uuid", "--to", "ulid")
+
+	// Assert
+	assert.Equal(t, exitOK, code)
+	assert.Equal(t, uuid+"\n", out)
+	assert.Equal(t, ulid+"\n", back)
+}
+
+func Test_Sort(t *testing.T) {
+	ids := id.NewGenerator().GenerateBatch(3)
+	input := strings.Join([]string{ids[2], "bad", ids[0], "", ids[1]}, "\n")
+
+	// Act
+	code, out, _ := runCase(input, "sort")
+
+	// Assert
+	assert.Equal(t, exitOK, code)
+	assert.Equal(t, strings.Join(append(ids, "bad"), "\n")+"\n", out)
+}
+
+func Test_Analyze(t *testing.T) {
+	gen := id.NewGenerator()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	first, last := gen.GenerateWithTime(start), gen.GenerateWithTime(start.Add(time.Minute))
+
+	// Act
+	code, out, _ := runCase(last+"\nbad\n"+first+"\n", "analyze")
+
+	// Assert
+	assert.Equal(t, exitOK, code)
+	var got analyzeOutput
+	require.NoError(t, json.Unmarshal([]byte(out), &got))
+	assert.Equal(t, analyzeOutput{
+		Count:     2,
+		Invalid:   1,
+		FirstID:   first,
+		LastID:    last,
+		FirstTime: start,
+		LastTime:  start.Add(time.Minute),
+		TimeSpan:  "1m0s",
+		Rate:      2.0 / 60,
+	}, got)
+}
+
+func Test_Usage(t *testing.T) {
+	for _, args := range [][]string{
+		nil,
+		{"frobnicate"},
+		{"gen", "-n", "-1"},
+		{"gen", "--bogus"},
+		{"convert", "--to", "rot13", "01ARZ3NDEKTSV4RRFFQ69G5FAV"},
+	} {
+		code, _, errOut := runCase("", args...)
+		assert.Equal(t, exitUsage, code, args)
+		assert.NotEmpty(t, errOut, args)
+	}
+	code, _, _ := runCase("", "gen", "-h")
+	assert.Equal(t, exitOK, code)
+}
+
+// mustEncode re-encodes a ULID with one of the id.Encode functions
+func mustEncode(t *testing.T, encode func(string) (string, error), ulid string) string {
+	t.Helper()
+	encoded, err := encode(ulid)
+	require.NoError(t, err)
+	return encoded
+}
@@ -0,0 +1,216 @@
+package id
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/oklog/ulid"
+)
+
+// Format selects the wire encoding used by Writer and Reader
+type Format int
+
+const (
+	// FormatText writes one canonical ULID per line
+	FormatText Format = iota
+	// FormatBinary writes each ULID as a uvarint length prefix followed by its raw bytes
+	FormatBinary
+	// FormatDelta writes each ULID as a zigzag varint timestamp delta from the
+	// previous ULID followed by its entropy bytes. Sorted input compresses best.
+	FormatDelta
+)
+
+// String returns the name of the format
+func (f Format) String() string {
+	switch f {
+	case FormatText:
+		return "text"
+	case FormatBinary:
+		return "binary"
+	case FormatDelta:
+		return "delta"
+	default:
+		return fmt.Sprintf("Format(%d)", int(f))
+	}
+}
+
+const (
+	// ulidSize is the length of a binary ULID
+	ulidSize = 16
+	// entropySize is the length of the random component of a binary ULID
+	entropySize = 10
+)
+
+// Writer streams ULIDs to an underlying io.Writer in a chosen Format
+type Writer struct {
+	w      *bufio.Writer
+	format Format
+	prevMs uint64
+	buf    [binary.MaxVarintLen64 + ulidSize]byte
+}
+
+// NewWriter creates a Writer that encodes ULIDs to w using format.
+// Callers must call Flush once all ULIDs have been written.
+func NewWriter(w io.Writer, format Format) *Writer {
+	return &Writer{
+		w:      bufio.NewWriter(w),
+		format: format,
+	}
+}
+
+// Write encodes a single ULID
+func (w *Writer) Write(id string) error {
+	parsed, err := parseCanonical(id)
+	if err != nil {
+		return fmt.Errorf("invalid ULID: %w", err)
+	}
+
+	switch w.format {
+	case FormatText:
+		if _, err := w.w.WriteString(parsed.String()); err != nil {
+			return err
+		}
+		return w.w.WriteByte('\n')
+	case FormatBinary:
+		n := binary.PutUvarint(w.buf[:], ulidSize)
+		n += copy(w.buf[n:], parsed[:])
+		_, err := w.w.Write(w.buf[:n])
+		return err
+	case FormatDelta:
+		ms := parsed.Time()
+		n := binary.PutVarint(w.buf[:], int64(ms)-int64(w.prevMs)) //nolint:gosec // G115: ULID timestamps are 48 bits
+		n += copy(w.buf[n:], parsed[6:])
+		w.prevMs = ms
+		_, err := w.w.Write(w.buf[:n])
+		return err
+	default:
+		return fmt.Errorf("unknown format: %s", w.format)
+	}
+}
+
+// WriteAll encodes every ULID in ids and flushes the output
+func (w *Writer) WriteAll(ids []string) error {
+	for _, id := range ids {
+		if err := w.Write(id); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+// Flush writes any buffered data to the underlying io.Writer
+func (w *Writer) Flush() error {
+	return w.w.Flush()
+}
+
+// Reader streams ULIDs from an underlying io.Reader in a chosen Format
+type Reader struct {
+	r      *bufio.Reader
+	format Format
+	prevMs uint64
+}
+
+// NewReader creates a Reader that decodes ULIDs from r using format
+func NewReader(r io.Reader, format Format) *Reader {
+	return &Reader{
+		r:      bufio.NewReader(r),
+		format: format,
+	}
+}
+
+// Read decodes the next ULID. It returns io.EOF when the stream ends cleanly
+// and io.ErrUnexpectedEOF when it ends partway through a record.
+func (r *Reader) Read() (string, error) {
+	switch r.format {
+	case FormatText:
+		return r.readText()
+	case FormatBinary:
+		return r.readBinary()
+	case FormatDelta:
+		return r.readDelta()
+	default:
+		return "", fmt.Errorf("unknown format: %s", r.format)
+	}
+}
+
+// ReadAll decodes ULIDs until the end of the stream
+func (r *Reader) ReadAll() ([]string, error) {
+	var ids []string
+	for {
+		id, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return ids, nil
+		}
+		if err != nil {
+			return ids, err
+		}
+		ids = append(ids, id)
+	}
+}
+
+func (r *Reader) readText() (string, error) {
+	for {
+		line, err := r.r.ReadString('\n')
+		trimmed := strings.TrimSpace(line)
+		if trimmed != "" {
+			parsed, perr := parseCanonical(trimmed)
+			if perr != nil {
+				return "", fmt.Errorf("invalid ULID: %w", perr)
+			}
+			return parsed.String(), nil
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+}
+
+func (r *Reader) readBinary() (string, error) {
+	size, err := binary.ReadUvarint(r.r)
+	if err != nil {
+		return "", err
+	}
+	if size != ulidSize {
+		return "", fmt.Errorf("invalid record length: %d", size)
+	}
+
+	var u ulid.ULID
+	if _, err := io.ReadFull(r.r, u[:]); err != nil {
+		return "", unexpectedEOF(err)
+	}
+	return u.String(), nil
+}
+
+func (r *Reader) readDelta() (string, error) {
+	delta, err := binary.ReadVarint(r.r)
+	if err != nil {
+		return "", err
+	}
+
+	ms := int64(r.prevMs) + delta //nolint:gosec // G115: ULID timestamps are 48 bits
+	if ms < 0 {
+		return "", fmt.Errorf("invalid timestamp delta: %d", delta)
+	}
+
+	var u ulid.ULID
+	if err := u.SetTime(uint64(ms)); err != nil {
+		return "", err
+	}
+	if _, err := io.ReadFull(r.r, u[6:]); err != nil {
+		return "", unexpectedEOF(err)
+	}
+	r.prevMs = uint64(ms)
+	return u.String(), nil
+}
+
+// unexpectedEOF reports a stream that ended partway through a record
+func unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
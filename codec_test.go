@@ -0,0 +1,75 @@
+package id_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WriterReader_RoundTrip(t *testing.T) {
+	gen := id.NewGenerator()
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	ids := gen.GenerateRange(start, start.Add(time.Hour), 50)
+
+	for _, format := range []id.Format{id.FormatText, id.FormatBinary, id.FormatDelta} {
+		t.Run(format.String(), func(t *testing.T) {
+			var buf bytes.Buffer
+
+			// Act
+			require.NoError(t, id.NewWriter(&buf, format).WriteAll(ids))
+			decoded, err := id.NewReader(&buf, format).ReadAll()
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, ids, decoded)
+		})
+	}
+}
+
+func Test_Writer_DeltaIsCompact(t *testing.T) {
+	gen := id.NewGenerator()
+	ids := gen.GenerateBatch(100)
+
+	var text, delta bytes.Buffer
+	require.NoError(t, id.NewWriter(&text, id.FormatText).WriteAll(ids))
+	require.NoError(t, id.NewWriter(&delta, id.FormatDelta).WriteAll(ids))
+
+	// Assert
+	assert.Less(t, delta.Len(), text.Len()/2)
+}
+
+func Test_Writer_Errors(t *testing.T) {
+	var buf bytes.Buffer
+
+	// Act & Assert
+	assert.Error(t, id.NewWriter(&buf, id.FormatText).Write("invalid"))
+	assert.Error(t, id.NewWriter(&buf, id.FormatBinary).Write("01ARZ3NDEKTSV4RRFFQ69G5F!!"))
+	assert.Error(t, id.NewWriter(&buf, id.Format(99)).Write(id.NewGenerator().Generate()))
+}
+
+func Test_Reader_Errors(t *testing.T) {
+	gen := id.NewGenerator()
+	var buf bytes.Buffer
+	require.NoError(t, id.NewWriter(&buf, id.FormatBinary).WriteAll([]string{gen.Generate()}))
+
+	// Act
+	_, err := id.NewReader(bytes.NewReader(buf.Bytes()[:10]), id.FormatBinary).Read()
+
+	// Assert
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+	_, err = id.NewReader(bytes.NewReader(nil), id.FormatDelta).Read()
+	assert.ErrorIs(t, err, io.EOF)
+
+	_, err = id.NewReader(bytes.NewBufferString("invalid\n"), id.FormatText).Read()
+	assert.Error(t, err)
+
+	_, err = id.NewReader(bytes.NewBufferString("01ARZ3NDEKTSV4RRFFQ69G5F!!\n"), id.FormatText).Read()
+	var charErr id.ErrInvalidCharacter
+	assert.ErrorAs(t, err, &charErr)
+}
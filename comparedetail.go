@@ -0,0 +1,63 @@
+package id
+
+import (
+	"fmt"
+	"time"
+)
+
+// Basis identifies which component of two ULIDs decided their ordering
+type Basis string
+
+const (
+	// BasisTimestamp means the timestamps differ
+	BasisTimestamp Basis = "timestamp"
+	// BasisEntropy means the timestamps match and the entropy decided the order
+	BasisEntropy Basis = "entropy"
+	// BasisIdentical means the ULIDs are equal
+	BasisIdentical Basis = "identical"
+)
+
+// Detail explains how two ULIDs compare
+type Detail struct {
+	// Result is -1, 0, or 1, as returned by Compare
+	Result int
+	// DecidedBy reports which component decided Result
+	DecidedBy Basis
+	// TimeDelta is the second ULID's timestamp minus the first's
+	TimeDelta time.Duration
+	// FirstDifference is the index of the first differing byte of the binary
+	// ULIDs (0-5 timestamp, 6-15 entropy), or -1 when they are identical
+	FirstDifference int
+}
+
+// CompareDetailed compares two ULIDs like Compare and also reports whether the
+// ordering was decided by timestamp or entropy, and the time between them
+func (g *generator) CompareDetailed(id1, id2 string) (Detail, error) {
+	ulid1, err := g.parse(id1)
+	if err != nil {
+		return Detail{}, fmt.Errorf("invalid first ULID: %w", err)
+	}
+
+	ulid2, err := g.parse(id2)
+	if err != nil {
+		return Detail{}, fmt.Errorf("invalid second ULID: %w", err)
+	}
+
+	detail := Detail{
+		Result:          ulid1.Compare(ulid2),
+		DecidedBy:       BasisIdentical,
+		TimeDelta:       time.Duration(int64(ulid2.Time())-int64(ulid1.Time())) * time.Millisecond, //nolint:gosec // G115: ULID timestamps are 48 bits
+		FirstDifference: -1,
+	}
+	for i := range ulid1 {
+		if ulid1[i] != ulid2[i] {
+			detail.FirstDifference = i
+			detail.DecidedBy = BasisEntropy
+			if i < 6 {
+				detail.DecidedBy = BasisTimestamp
+			}
+			break
+		}
+	}
+	return detail, nil
+}
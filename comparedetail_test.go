@@ -0,0 +1,55 @@
+package id_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CompareDetailed(t *testing.T) {
+	gen := id.NewGenerator()
+	t1 := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	earlier := gen.GenerateWithTime(t1)
+	later := gen.GenerateWithTime(t1.Add(1500 * time.Millisecond))
+
+	// Act
+	detail, err := gen.CompareDetailed(earlier, later)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, -1, detail.Result)
+	assert.Equal(t, id.BasisTimestamp, detail.DecidedBy)
+	assert.Equal(t, 1500*time.Millisecond, detail.TimeDelta)
+	assert.Less(t, detail.FirstDifference, 6)
+}
+
+func Test_CompareDetailed_SameMillisecond(t *testing.T) {
+	gen := id.NewGenerator()
+	now := time.Now()
+	a := gen.GenerateWithTime(now)
+	b := gen.GenerateWithTime(now)
+
+	// Act
+	detail, err := gen.CompareDetailed(b, a)
+
+	// Assert
+	require.NoError(t, err)
+	cmp, err := gen.Compare(b, a)
+	require.NoError(t, err)
+	assert.Equal(t, cmp, detail.Result)
+	assert.Equal(t, id.BasisEntropy, detail.DecidedBy)
+	assert.Zero(t, detail.TimeDelta)
+	assert.GreaterOrEqual(t, detail.FirstDifference, 6)
+
+	same, err := gen.CompareDetailed(a, a)
+	require.NoError(t, err)
+	assert.Equal(t, id.Detail{DecidedBy: id.BasisIdentical, FirstDifference: -1}, same)
+
+	_, err = gen.CompareDetailed("invalid", a)
+	assert.Error(t, err)
+	_, err = gen.CompareDetailed(a, "invalid")
+	assert.Error(t, err)
+}
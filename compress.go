@@ -0,0 +1,33 @@
+package id
+
+import (
+	"bytes"
+)
+
+// Compress packs a sorted slice of ULIDs using the FormatDelta encoding.
+// Sorted input keeps every timestamp delta small, so each ULID costs its 10
+// entropy bytes plus one or two bytes of varint delta instead of 26 text
+// characters. Unsorted input still round-trips, just less compactly.
+// Invalid ULIDs are skipped.
+func Compress(sorted []string) []byte {
+	var buf bytes.Buffer
+	buf.Grow(len(sorted) * (entropySize + 2))
+
+	w := NewWriter(&buf, FormatDelta)
+	for _, id := range sorted {
+		_ = w.Write(id) // Skip invalid ULIDs; writes to a bytes.Buffer cannot fail
+	}
+	_ = w.Flush()
+
+	return buf.Bytes()
+}
+
+// Decompress restores the ULIDs packed by Compress, in their original order.
+// It returns nil if data is truncated or corrupt.
+func Decompress(data []byte) []string {
+	ids, err := NewReader(bytes.NewReader(data), FormatDelta).ReadAll()
+	if err != nil {
+		return nil
+	}
+	return ids
+}
@@ -0,0 +1,41 @@
+package id_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Compress_Decompress(t *testing.T) {
+	gen := id.NewGenerator()
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	ids := gen.GenerateRange(start, start.Add(time.Minute), 1000)
+
+	// Act
+	data := id.Compress(ids)
+	restored := id.Decompress(data)
+
+	// Assert
+	assert.Equal(t, ids, restored)
+	assert.Less(t, len(data), len(ids)*13) // ~10 entropy bytes + small varint delta
+}
+
+func Test_Compress_EdgeCases(t *testing.T) {
+	gen := id.NewGenerator()
+	valid := gen.Generate()
+
+	// Act & Assert
+	assert.Empty(t, id.Compress(nil))
+	assert.Empty(t, id.Decompress(nil))
+	assert.Equal(t, []string{valid}, id.Decompress(id.Compress([]string{"invalid", valid})))
+
+	// Unsorted input still round-trips
+	unsorted := []string{gen.GenerateWithTime(time.Now()), gen.GenerateWithTime(time.Now().Add(-time.Hour))}
+	assert.Equal(t, unsorted, id.Decompress(id.Compress(unsorted)))
+
+	// Truncated input is rejected
+	data := id.Compress([]string{valid})
+	assert.Nil(t, id.Decompress(data[:len(data)-1]))
+}
@@ -0,0 +1,18 @@
+package id
+
+import "context"
+
+// contextKey is the unexported type of the key NewContext stores IDs under,
+// so no other package can collide with it
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying id, typically a request ID
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the ID stored in ctx by NewContext, if any
+func FromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(contextKey{}).(string)
+	return id, ok
+}
@@ -0,0 +1,29 @@
+package id_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FromContext(t *testing.T) {
+	ulid := id.NewGenerator().Generate()
+
+	// Act
+	got, ok := id.FromContext(id.NewContext(context.Background(), ulid))
+
+	// Assert
+	assert.True(t, ok)
+	assert.Equal(t, ulid, got)
+}
+
+func Test_FromContext_Missing(t *testing.T) {
+	// Act
+	got, ok := id.FromContext(context.Background())
+
+	// Assert
+	assert.False(t, ok)
+	assert.Empty(t, got)
+}
@@ -0,0 +1,73 @@
+package id
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/oklog/ulid"
+)
+
+const (
+	// windowCountBits is how many low bits of issueCounters.window count IDs
+	windowCountBits = 16
+	windowCountMask = 1<<windowCountBits - 1
+)
+
+// issueCounters tracks what a generator has issued. Clones made by With*
+// methods share their parent's counters, as they share its entropy.
+type issueCounters struct {
+	issued atomic.Uint64
+	// window packs the timestamp of the latest ID, in milliseconds, above the
+	// number of consecutive IDs issued with that timestamp
+	window atomic.Uint64
+	peak   atomic.Uint64
+	// labeled counts GenerateLabeled calls per label set
+	labeled labeledCounters
+}
+
+// record counts one ID issued with timestamp ms
+func (c *issueCounters) record(ms uint64) {
+	c.issued.Add(1)
+
+	for {
+		old := c.window.Load()
+		next := ms<<windowCountBits | 1
+		if old>>windowCountBits == ms {
+			next = old
+			if old&windowCountMask < windowCountMask {
+				next++
+			}
+		}
+		if !c.window.CompareAndSwap(old, next) {
+			continue
+		}
+
+		count := next & windowCountMask
+		for {
+			peak := c.peak.Load()
+			if count <= peak || c.peak.CompareAndSwap(peak, count) {
+				return
+			}
+		}
+	}
+}
+
+// Issued returns how many IDs the generator has issued
+func (g *generator) Issued() uint64 {
+	return g.counters.issued.Load()
+}
+
+// LastIssuedAt returns the timestamp of the most recently issued ID, which
+// for Generate is when it was issued, or the zero time if none has been
+func (g *generator) LastIssuedAt() time.Time {
+	if g.Issued() == 0 {
+		return time.Time{}
+	}
+	return ulid.Time(g.counters.window.Load() >> windowCountBits)
+}
+
+// PeakPerMillisecond returns the most IDs issued in a row sharing one
+// millisecond timestamp, saturating at 65535
+func (g *generator) PeakPerMillisecond() uint64 {
+	return g.counters.peak.Load()
+}
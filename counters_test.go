@@ -0,0 +1,63 @@
+package id_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Generator_Counters(t *testing.T) {
+	clock := id.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	gen := id.NewGenerator(id.WithClock(clock))
+	assert.Zero(t, gen.Issued())
+	assert.True(t, gen.LastIssuedAt().IsZero())
+
+	// Act
+	gen.GenerateBatch(3)
+	clock.Advance(time.Millisecond)
+	gen.Generate()
+	_ = gen.GenerateID()
+
+	// Assert
+	assert.Equal(t, uint64(5), gen.Issued())
+	assert.Equal(t, uint64(3), gen.PeakPerMillisecond())
+	assert.True(t, gen.LastIssuedAt().Equal(clock.Now()))
+}
+
+func Test_Generator_Counters_SharedByClones(t *testing.T) {
+	gen := id.NewGenerator()
+	lower := gen.WithFormatProfile(id.LowercaseProfile)
+
+	// Act
+	gen.Generate()
+	lower.Generate()
+
+	// Assert
+	assert.Equal(t, uint64(2), gen.Issued())
+	assert.Equal(t, uint64(2), lower.Issued())
+}
+
+func Test_Generator_Counters_Concurrent(t *testing.T) {
+	gen := id.NewSecureGenerator()
+	var wg sync.WaitGroup
+
+	// Act
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				gen.Generate()
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	assert.Equal(t, uint64(4000), gen.Issued())
+	assert.GreaterOrEqual(t, gen.PeakPerMillisecond(), uint64(1))
+	assert.WithinDuration(t, time.Now(), gen.LastIssuedAt(), time.Minute)
+}
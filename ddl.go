@@ -0,0 +1,196 @@
+package id
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Dialect names a SQL database flavor
+type Dialect string
+
+const (
+	DialectPostgres  Dialect = "postgres"
+	DialectMySQL     Dialect = "mysql"
+	DialectSQLite    Dialect = "sqlite"
+	DialectSQLServer Dialect = "sqlserver"
+)
+
+const (
+	// ulidPattern matches a canonical ULID as a POSIX regular expression
+	ulidPattern = "^[0-7][0-9A-HJKMNP-TV-Z]{25}$"
+	// base64Pattern matches an unpadded base64url ID as a POSIX regular expression
+	base64Pattern = "^[0-9A-Za-z_-]{21}[AQgw]$"
+
+	base64OrderNote = "base64url does not sort in ULID time order; order by the decoded ID instead"
+)
+
+// ColumnDDL is the schema for storing IDs in one column of one dialect
+type ColumnDDL struct {
+	// Type is the column type, including any collation
+	Type string
+	// Check is a CHECK constraint expression, or empty if the type suffices
+	Check string
+	// Default is a default-value expression, or empty when IDs must be
+	// generated by the application
+	Default string
+	// Notes explain dialect-specific caveats
+	Notes []string
+}
+
+// ColumnSQL returns the column type, collation, check constraint, and default
+// expression for storing IDs in column under the given dialect and storage
+// profile, keeping application code and schema consistent
+func ColumnSQL(dialect Dialect, storage StorageProfile, column string) (ColumnDDL, error) {
+	col, err := quoteIdent(dialect, column)
+	if err != nil {
+		return ColumnDDL{}, err
+	}
+
+	switch dialect {
+	case DialectPostgres:
+		switch storage {
+		case StorageText:
+			return ColumnDDL{
+				Type:  `CHAR(26) COLLATE "C"`,
+				Check: fmt.Sprintf("%s ~ '%s'", col, ulidPattern),
+				Notes: []string{`COLLATE "C" keeps index order identical to ULID order`},
+			}, nil
+		case StorageBinary:
+			return ColumnDDL{Type: "BYTEA", Check: fmt.Sprintf("octet_length(%s) = 16", col)}, nil
+		case StorageUUID:
+			return ColumnDDL{
+				Type:    "UUID",
+				Default: "uuidv7()",
+				Notes:   []string{"uuidv7() requires PostgreSQL 18 or later; drop the default on older servers"},
+			}, nil
+		case StorageBase64:
+			return ColumnDDL{
+				Type:  `CHAR(22) COLLATE "C"`,
+				Check: fmt.Sprintf("%s ~ '%s'", col, base64Pattern),
+				Notes: []string{base64OrderNote},
+			}, nil
+		}
+	case DialectMySQL:
+		switch storage {
+		case StorageText:
+			return ColumnDDL{
+				Type:  "CHAR(26) CHARACTER SET ascii COLLATE ascii_bin",
+				Check: fmt.Sprintf("%s REGEXP '%s'", col, ulidPattern),
+				Notes: []string{"CHECK constraints are enforced from MySQL 8.0.16"},
+			}, nil
+		case StorageBinary:
+			return ColumnDDL{Type: "BINARY(16)"}, nil
+		case StorageUUID:
+			return ColumnDDL{
+				Type:  "CHAR(36) CHARACTER SET ascii COLLATE ascii_bin",
+				Check: fmt.Sprintf("CHAR_LENGTH(%s) = 36", col),
+			}, nil
+		case StorageBase64:
+			return ColumnDDL{
+				Type:  "CHAR(22) CHARACTER SET ascii COLLATE ascii_bin",
+				Check: fmt.Sprintf("%s REGEXP '%s'", col, base64Pattern),
+				Notes: []string{base64OrderNote},
+			}, nil
+		}
+	case DialectSQLite:
+		switch storage {
+		case StorageText:
+			return ColumnDDL{
+				Type:  "TEXT COLLATE BINARY",
+				Check: fmt.Sprintf("length(%s) = 26 AND %s NOT GLOB '*[^0-9A-HJKMNP-TV-Z]*'", col, col),
+			}, nil
+		case StorageBinary:
+			return ColumnDDL{Type: "BLOB", Check: fmt.Sprintf("typeof(%s) = 'blob' AND length(%s) = 16", col, col)}, nil
+		case StorageUUID:
+			return ColumnDDL{Type: "TEXT COLLATE BINARY", Check: fmt.Sprintf("length(%s) = 36", col)}, nil
+		case StorageBase64:
+			return ColumnDDL{
+				Type:  "TEXT COLLATE BINARY",
+				Check: fmt.Sprintf("length(%s) = 22 AND %s NOT GLOB '*[^0-9A-Za-z_-]*'", col, col),
+				Notes: []string{base64OrderNote},
+			}, nil
+		}
+	case DialectSQLServer:
+		switch storage {
+		case StorageText:
+			return ColumnDDL{
+				Type:  "CHAR(26) COLLATE Latin1_General_BIN2",
+				Check: fmt.Sprintf("LEN(%s) = 26 AND %s NOT LIKE '%%[^0-9A-HJKMNP-TV-Z]%%'", col, col),
+			}, nil
+		case StorageBinary:
+			return ColumnDDL{Type: "BINARY(16)"}, nil
+		case StorageUUID:
+			return ColumnDDL{
+				Type:  "UNIQUEIDENTIFIER",
+				Notes: []string{"UNIQUEIDENTIFIER sorts by its last 6 bytes first, so index order will not follow ULID time order; prefer BINARY(16) or StorageOrderedBinary"},
+			}, nil
+		case StorageBase64:
+			return ColumnDDL{
+				Type:  "CHAR(22) COLLATE Latin1_General_BIN2",
+				Check: fmt.Sprintf("LEN(%s) = 22 AND %s NOT LIKE '%%[^0-9A-Za-z_-]%%'", col, col),
+				Notes: []string{base64OrderNote},
+			}, nil
+		case StorageOrderedBinary:
+			return ColumnDDL{
+				Type:  "UNIQUEIDENTIFIER",
+				Notes: []string{"bytes are rearranged so UNIQUEIDENTIFIER index order follows ULID time order"},
+			}, nil
+		}
+	default:
+		return ColumnDDL{}, fmt.Errorf("unsupported dialect: %q", dialect)
+	}
+	if storage == StorageOrderedBinary {
+		return ColumnDDL{}, fmt.Errorf("%s storage only applies to %s; use %s", storage, DialectSQLServer, StorageBinary)
+	}
+	return ColumnDDL{}, fmt.Errorf("unsupported storage profile: %s", storage)
+}
+
+// Definition renders the full column definition for use in CREATE or ALTER TABLE
+func (c ColumnDDL) Definition(dialect Dialect, column string) (string, error) {
+	col, err := quoteIdent(dialect, column)
+	if err != nil {
+		return "", err
+	}
+
+	parts := []string{col, c.Type, "NOT NULL"}
+	if c.Default != "" {
+		parts = append(parts, "DEFAULT "+c.Default)
+	}
+	if c.Check != "" {
+		parts = append(parts, "CHECK ("+c.Check+")")
+	}
+	return strings.Join(parts, " "), nil
+}
+
+// CreateTableSQL renders a CREATE TABLE statement with column as an ID primary key
+func CreateTableSQL(dialect Dialect, storage StorageProfile, table, column string) (string, error) {
+	ddl, err := ColumnSQL(dialect, storage, column)
+	if err != nil {
+		return "", err
+	}
+	def, err := ddl.Definition(dialect, column)
+	if err != nil {
+		return "", err
+	}
+	tbl, err := quoteIdent(dialect, table)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("CREATE TABLE %s (\n    %s PRIMARY KEY\n);", tbl, def), nil
+}
+
+// quoteIdent quotes an identifier for a dialect, rejecting names that would need escaping
+func quoteIdent(dialect Dialect, name string) (string, error) {
+	if name == "" || strings.ContainsAny(name, "\"`[]'\x00") {
+		return "", fmt.Errorf("invalid identifier: %q", name)
+	}
+
+	switch dialect {
+	case DialectMySQL:
+		return "`" + name + "`", nil
+	case DialectSQLServer:
+		return "[" + name + "]", nil
+	default:
+		return `"` + name + `"`, nil
+	}
+}
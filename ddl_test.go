@@ -0,0 +1,66 @@
+package id_test
+
+import (
+	"testing"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ColumnSQL_AllCombinations(t *testing.T) {
+	dialects := []id.Dialect{id.DialectPostgres, id.DialectMySQL, id.DialectSQLite, id.DialectSQLServer}
+	storages := []id.StorageProfile{id.StorageText, id.StorageBinary, id.StorageUUID, id.StorageBase64}
+
+	for _, d := range dialects {
+		for _, s := range storages {
+			// Act
+			ddl, err := id.ColumnSQL(d, s, "id")
+
+			// Assert
+			require.NoError(t, err, "%s/%s", d, s)
+			assert.NotEmpty(t, ddl.Type, "%s/%s", d, s)
+		}
+	}
+}
+
+func Test_ColumnSQL_Postgres(t *testing.T) {
+	// Act
+	ddl, err := id.ColumnSQL(id.DialectPostgres, id.StorageText, "id")
+	require.NoError(t, err)
+	def, err := ddl.Definition(id.DialectPostgres, "id")
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, `"id" CHAR(26) COLLATE "C" NOT NULL CHECK ("id" ~ '^[0-7][0-9A-HJKMNP-TV-Z]{25}$')`, def)
+}
+
+func Test_CreateTableSQL(t *testing.T) {
+	// Act
+	stmt, err := id.CreateTableSQL(id.DialectMySQL, id.StorageBinary, "orders", "id")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "CREATE TABLE `orders` (\n    `id` BINARY(16) NOT NULL PRIMARY KEY\n);", stmt)
+
+	stmt, err = id.CreateTableSQL(id.DialectSQLServer, id.StorageText, "orders", "id")
+	require.NoError(t, err)
+	assert.Contains(t, stmt, "NOT LIKE '%[^0-9A-HJKMNP-TV-Z]%'")
+
+	stmt, err = id.CreateTableSQL(id.DialectSQLServer, id.StorageOrderedBinary, "orders", "id")
+	require.NoError(t, err)
+	assert.Contains(t, stmt, "UNIQUEIDENTIFIER NOT NULL")
+}
+
+func Test_ColumnSQL_Errors(t *testing.T) {
+	_, err := id.ColumnSQL("oracle", id.StorageText, "id")
+	assert.Error(t, err)
+	_, err = id.ColumnSQL(id.DialectPostgres, id.StorageProfile(99), "id")
+	assert.Error(t, err)
+	_, err = id.ColumnSQL(id.DialectPostgres, id.StorageOrderedBinary, "id")
+	assert.Error(t, err)
+	_, err = id.ColumnSQL(id.DialectPostgres, id.StorageText, `id"; DROP TABLE x; --`)
+	assert.Error(t, err)
+	_, err = id.CreateTableSQL(id.DialectSQLite, id.StorageText, "", "id")
+	assert.Error(t, err)
+}
@@ -0,0 +1,581 @@
+package id
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// probeBytes is the example ID from the ULID specification. Wrappers that
+// depend on how the wrapped Provider converts its IDs pass it through
+// FromBytes at assembly, so an incompatible Provider is rejected when the
+// chain is built instead of when it first generates.
+var probeBytes = [16]byte{
+	0x01, 0x56, 0x3e, 0x3a, 0xb5, 0xd3, 0xd6, 0x76,
+	0x4c, 0x61, 0xef, 0xb9, 0x93, 0x02, 0xbd, 0x5b,
+}
+
+// Chain wraps base in each wrapper and returns the result. The first wrapper
+// is outermost and sees every call first, so
+//
+//	Chain(gen, Instrumented(&m), Validated(nil))
+//
+// counts calls before validating them. Wrappers run once, at assembly.
+func Chain(base Provider, wrappers ...func(Provider) Provider) Provider {
+	p := base
+	for i := len(wrappers) - 1; i >= 0; i-- {
+		p = wrappers[i](p)
+	}
+	return p
+}
+
+// ProviderStats is a snapshot of the counters kept by ProviderMetrics
+type ProviderStats struct {
+	// Generated counts IDs issued by every Generate method
+	Generated uint64
+	// Validations counts IsIdValid and ValidateAndNormalize calls
+	Validations uint64
+	// Invalid counts validations that rejected their input
+	Invalid uint64
+	// Errors counts errors returned by the other methods
+	Errors uint64
+}
+
+// ProviderMetrics accumulates counters for a Provider wrapped by
+// Instrumented. The zero value is ready to use and safe for concurrent use.
+type ProviderMetrics struct {
+	generated   atomic.Uint64
+	validations atomic.Uint64
+	invalid     atomic.Uint64
+	errors      atomic.Uint64
+}
+
+// Stats returns a snapshot of the counters
+func (m *ProviderMetrics) Stats() ProviderStats {
+	return ProviderStats{
+		Generated:   m.generated.Load(),
+		Validations: m.validations.Load(),
+		Invalid:     m.invalid.Load(),
+		Errors:      m.errors.Load(),
+	}
+}
+
+// countErr counts err if it is non-nil and returns it
+func (m *ProviderMetrics) countErr(err error) error {
+	if err != nil {
+		m.errors.Add(1)
+	}
+	return err
+}
+
+// Instrumented counts generated IDs, validations, and errors into m
+func Instrumented(m *ProviderMetrics) func(Provider) Provider {
+	return func(p Provider) Provider {
+		return &instrumented{Provider: p, m: m}
+	}
+}
+
+type instrumented struct {
+	Provider
+	m *ProviderMetrics
+}
+
+func (p *instrumented) Generate() string {
+	p.m.generated.Add(1)
+	return p.Provider.Generate()
+}
+
+func (p *instrumented) GenerateWithTime(t time.Time) string {
+	p.m.generated.Add(1)
+	return p.Provider.GenerateWithTime(t)
+}
+
+func (p *instrumented) GenerateBatch(count int) []string {
+	ids := p.Provider.GenerateBatch(count)
+	p.m.generated.Add(uint64(len(ids)))
+	return ids
+}
+
+func (p *instrumented) GenerateRange(start, end time.Time, count int) []string {
+	ids := p.Provider.GenerateRange(start, end, count)
+	p.m.generated.Add(uint64(len(ids)))
+	return ids
+}
+
+func (p *instrumented) IsIdValid(id string) bool {
+	p.m.validations.Add(1)
+	valid := p.Provider.IsIdValid(id)
+	if !valid {
+		p.m.invalid.Add(1)
+	}
+	return valid
+}
+
+func (p *instrumented) ValidateAndNormalize(id string) (string, error) {
+	p.m.validations.Add(1)
+	normalized, err := p.Provider.ValidateAndNormalize(id)
+	if err != nil {
+		p.m.invalid.Add(1)
+	}
+	return normalized, err
+}
+
+func (p *instrumented) ExtractTimestamp(id string) (time.Time, error) {
+	t, err := p.Provider.ExtractTimestamp(id)
+	return t, p.m.countErr(err)
+}
+
+func (p *instrumented) Age(id string) (time.Duration, error) {
+	d, err := p.Provider.Age(id)
+	return d, p.m.countErr(err)
+}
+
+func (p *instrumented) IsExpired(id string, maxAge time.Duration) (bool, error) {
+	expired, err := p.Provider.IsExpired(id, maxAge)
+	return expired, p.m.countErr(err)
+}
+
+func (p *instrumented) Compare(id1, id2 string) (int, error) {
+	c, err := p.Provider.Compare(id1, id2)
+	return c, p.m.countErr(err)
+}
+
+func (p *instrumented) IsBefore(id1, id2 string) (bool, error) {
+	before, err := p.Provider.IsBefore(id1, id2)
+	return before, p.m.countErr(err)
+}
+
+func (p *instrumented) IsAfter(id1, id2 string) (bool, error) {
+	after, err := p.Provider.IsAfter(id1, id2)
+	return after, p.m.countErr(err)
+}
+
+func (p *instrumented) ToBytes(id string) ([16]byte, error) {
+	b, err := p.Provider.ToBytes(id)
+	return b, p.m.countErr(err)
+}
+
+func (p *instrumented) ToUUID(id string) (string, error) {
+	uuid, err := p.Provider.ToUUID(id)
+	return uuid, p.m.countErr(err)
+}
+
+// RateLimited blocks generation so that, averaged over time, at most
+// perSecond IDs are issued, allowing bursts of up to burst. A batch waits
+// for its whole size, so batches larger than burst are allowed but delay the
+// calls after them. A non-positive rate disables the limit.
+func RateLimited(perSecond float64, burst int) func(Provider) Provider {
+	return RateLimitedClock(SystemClock, perSecond, burst)
+}
+
+// RateLimitedClock is RateLimited with the bucket refilled and waited on by
+// clock, or SystemClock if clock is nil. With a FakeClock waits advance the
+// clock instead of blocking.
+func RateLimitedClock(clock Clock, perSecond float64, burst int) func(Provider) Provider {
+	if clock == nil {
+		clock = SystemClock
+	}
+	return func(p Provider) Provider {
+		if perSecond <= 0 {
+			return p
+		}
+		size := float64(max(burst, 1))
+		return &rateLimited{
+			Provider: p,
+			clock:    clock,
+			rate:     perSecond,
+			burst:    size,
+			tokens:   size,
+			last:     clock.Now(),
+		}
+	}
+}
+
+type rateLimited struct {
+	Provider
+	clock Clock
+	rate  float64
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// wait reserves n tokens, sleeping until the bucket has refilled enough to
+// cover them. Reservations may drive the bucket negative, which queues later
+// callers behind earlier ones.
+func (p *rateLimited) wait(n int) {
+	p.mu.Lock()
+	now := p.clock.Now()
+	p.tokens = min(p.burst, p.tokens+now.Sub(p.last).Seconds()*p.rate)
+	p.last = now
+	p.tokens -= float64(n)
+	deficit := -p.tokens
+	p.mu.Unlock()
+
+	if deficit > 0 {
+		sleep(p.clock, time.Duration(deficit/p.rate*float64(time.Second)))
+	}
+}
+
+func (p *rateLimited) Generate() string {
+	p.wait(1)
+	return p.Provider.Generate()
+}
+
+func (p *rateLimited) GenerateWithTime(t time.Time) string {
+	p.wait(1)
+	return p.Provider.GenerateWithTime(t)
+}
+
+func (p *rateLimited) GenerateBatch(count int) []string {
+	p.wait(max(count, 0))
+	return p.Provider.GenerateBatch(count)
+}
+
+func (p *rateLimited) GenerateRange(start, end time.Time, count int) []string {
+	p.wait(max(count, 0))
+	return p.Provider.GenerateRange(start, end, count)
+}
+
+// Signed issues tokens in the SignedGenerator format, "{id}.{signature}",
+// and makes every other method verify its input's signature before handing
+// the inner ID to the wrapped Provider. key signs new tokens; previousKeys
+// are still accepted when verifying. The wrapped Provider must convert its
+// IDs with ToBytes, which ULID, Snowflake, and prefixed generators do; the
+// wrapper panics at assembly for one that cannot, such as a KSUID generator.
+func Signed(key []byte, previousKeys ...[]byte) (func(Provider) Provider, error) {
+	keys, err := signingKeys(key, previousKeys)
+	if err != nil {
+		return nil, err
+	}
+	return func(p Provider) Provider {
+		if _, err := p.ToBytes(p.FromBytes(probeBytes)); err != nil {
+			panic(fmt.Sprintf("id: Signed cannot wrap a Provider whose IDs do not convert to bytes: %v", err))
+		}
+		return &signed{Provider: p, keys: keys}
+	}, nil
+}
+
+type signed struct {
+	Provider
+	keys [][]byte
+}
+
+// sign appends the signature of id under the current key. Signed checked at
+// assembly that the wrapped Provider converts its IDs, so a failure here is
+// a Provider that rejects its own output, and sign panics as generation does
+// when entropy fails.
+func (p *signed) sign(id string) string {
+	b, err := p.Provider.ToBytes(id)
+	if err != nil {
+		panic(fmt.Sprintf("id: cannot sign %q: %v", id, err))
+	}
+	return signToken(p.keys, id, b)
+}
+
+// verify checks token's signature against every key and returns the inner ID
+func (p *signed) verify(token string) (string, error) {
+	inner, _, err := verifyToken(p.keys, token, p.Provider.ToBytes)
+	return inner, err
+}
+
+// verifyPair verifies two tokens
+func (p *signed) verifyPair(token1, token2 string) (string, string, error) {
+	id1, err := p.verify(token1)
+	if err != nil {
+		return "", "", err
+	}
+	id2, err := p.verify(token2)
+	if err != nil {
+		return "", "", err
+	}
+	return id1, id2, nil
+}
+
+func (p *signed) Generate() string {
+	return p.sign(p.Provider.Generate())
+}
+
+func (p *signed) GenerateWithTime(t time.Time) string {
+	return p.sign(p.Provider.GenerateWithTime(t))
+}
+
+func (p *signed) GenerateBatch(count int) []string {
+	ids := p.Provider.GenerateBatch(count)
+	for i, id := range ids {
+		ids[i] = p.sign(id)
+	}
+	return ids
+}
+
+func (p *signed) GenerateRange(start, end time.Time, count int) []string {
+	ids := p.Provider.GenerateRange(start, end, count)
+	for i, id := range ids {
+		ids[i] = p.sign(id)
+	}
+	return ids
+}
+
+func (p *signed) IsIdValid(token string) bool {
+	_, err := p.verify(token)
+	return err == nil
+}
+
+// ValidateAndNormalize verifies token and returns it re-signed over the
+// normalized inner ID
+func (p *signed) ValidateAndNormalize(token string) (string, error) {
+	inner, err := p.verify(token)
+	if err != nil {
+		return "", err
+	}
+	normalized, err := p.Provider.ValidateAndNormalize(inner)
+	if err != nil {
+		return "", err
+	}
+	return p.sign(normalized), nil
+}
+
+func (p *signed) ExtractTimestamp(token string) (time.Time, error) {
+	inner, err := p.verify(token)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return p.Provider.ExtractTimestamp(inner)
+}
+
+func (p *signed) Age(token string) (time.Duration, error) {
+	inner, err := p.verify(token)
+	if err != nil {
+		return 0, err
+	}
+	return p.Provider.Age(inner)
+}
+
+func (p *signed) IsExpired(token string, maxAge time.Duration) (bool, error) {
+	inner, err := p.verify(token)
+	if err != nil {
+		return false, err
+	}
+	return p.Provider.IsExpired(inner, maxAge)
+}
+
+func (p *signed) Compare(token1, token2 string) (int, error) {
+	id1, id2, err := p.verifyPair(token1, token2)
+	if err != nil {
+		return 0, err
+	}
+	return p.Provider.Compare(id1, id2)
+}
+
+func (p *signed) IsBefore(token1, token2 string) (bool, error) {
+	id1, id2, err := p.verifyPair(token1, token2)
+	if err != nil {
+		return false, err
+	}
+	return p.Provider.IsBefore(id1, id2)
+}
+
+func (p *signed) IsAfter(token1, token2 string) (bool, error) {
+	id1, id2, err := p.verifyPair(token1, token2)
+	if err != nil {
+		return false, err
+	}
+	return p.Provider.IsAfter(id1, id2)
+}
+
+func (p *signed) ToBytes(token string) ([16]byte, error) {
+	inner, err := p.verify(token)
+	if err != nil {
+		return [16]byte{}, err
+	}
+	return p.Provider.ToBytes(inner)
+}
+
+func (p *signed) ToUUID(token string) (string, error) {
+	inner, err := p.verify(token)
+	if err != nil {
+		return "", err
+	}
+	return p.Provider.ToUUID(inner)
+}
+
+// FromBytes returns the signed token for data
+func (p *signed) FromBytes(data [16]byte) string {
+	return p.sign(p.Provider.FromBytes(data))
+}
+
+// Recorded calls record with every ID the wrapped Provider issues, in
+// order, before returning it. record runs on the calling goroutine and must
+// be safe for concurrent use if the Provider is shared.
+func Recorded(record func(id string)) func(Provider) Provider {
+	return func(p Provider) Provider {
+		return &recorded{Provider: p, record: record}
+	}
+}
+
+type recorded struct {
+	Provider
+	record func(id string)
+}
+
+func (p *recorded) Generate() string {
+	id := p.Provider.Generate()
+	p.record(id)
+	return id
+}
+
+func (p *recorded) GenerateWithTime(t time.Time) string {
+	id := p.Provider.GenerateWithTime(t)
+	p.record(id)
+	return id
+}
+
+func (p *recorded) GenerateBatch(count int) []string {
+	ids := p.Provider.GenerateBatch(count)
+	for _, id := range ids {
+		p.record(id)
+	}
+	return ids
+}
+
+func (p *recorded) GenerateRange(start, end time.Time, count int) []string {
+	ids := p.Provider.GenerateRange(start, end, count)
+	for _, id := range ids {
+		p.record(id)
+	}
+	return ids
+}
+
+// Validated applies check, in addition to the wrapped Provider's own
+// validation, to every ID passed in: IsIdValid and ValidateAndNormalize
+// reject it, and the other methods return its error. A nil check uses
+// ValidateFull, which also catches truncated IDs, so it only suits Providers
+// issuing plain ULIDs. The wrapper panics at assembly if check rejects the
+// wrapped Provider's IDs, as it would for a KSUID or prefixed generator with
+// a nil check; a generated ID that fails check later still panics.
+func Validated(check func(id string) error) func(Provider) Provider {
+	if check == nil {
+		check = ValidateFull
+	}
+	return func(p Provider) Provider {
+		if err := check(p.FromBytes(probeBytes)); err != nil {
+			panic(fmt.Sprintf("id: Validated check rejects the wrapped Provider's IDs: %v", err))
+		}
+		return &validated{Provider: p, check: check}
+	}
+}
+
+type validated struct {
+	Provider
+	check func(id string) error
+}
+
+// issued panics if a generated ID fails the check
+func (p *validated) issued(id string) string {
+	if err := p.check(id); err != nil {
+		panic(fmt.Sprintf("id: generated %q fails validation: %v", id, err))
+	}
+	return id
+}
+
+// checkPair checks two IDs
+func (p *validated) checkPair(id1, id2 string) error {
+	if err := p.check(id1); err != nil {
+		return err
+	}
+	return p.check(id2)
+}
+
+func (p *validated) Generate() string {
+	return p.issued(p.Provider.Generate())
+}
+
+func (p *validated) GenerateWithTime(t time.Time) string {
+	return p.issued(p.Provider.GenerateWithTime(t))
+}
+
+func (p *validated) GenerateBatch(count int) []string {
+	ids := p.Provider.GenerateBatch(count)
+	for _, id := range ids {
+		p.issued(id)
+	}
+	return ids
+}
+
+func (p *validated) GenerateRange(start, end time.Time, count int) []string {
+	ids := p.Provider.GenerateRange(start, end, count)
+	for _, id := range ids {
+		p.issued(id)
+	}
+	return ids
+}
+
+func (p *validated) IsIdValid(id string) bool {
+	return p.check(id) == nil && p.Provider.IsIdValid(id)
+}
+
+func (p *validated) ValidateAndNormalize(id string) (string, error) {
+	if err := p.check(id); err != nil {
+		return "", err
+	}
+	return p.Provider.ValidateAndNormalize(id)
+}
+
+func (p *validated) ExtractTimestamp(id string) (time.Time, error) {
+	if err := p.check(id); err != nil {
+		return time.Time{}, err
+	}
+	return p.Provider.ExtractTimestamp(id)
+}
+
+func (p *validated) Age(id string) (time.Duration, error) {
+	if err := p.check(id); err != nil {
+		return 0, err
+	}
+	return p.Provider.Age(id)
+}
+
+func (p *validated) IsExpired(id string, maxAge time.Duration) (bool, error) {
+	if err := p.check(id); err != nil {
+		return false, err
+	}
+	return p.Provider.IsExpired(id, maxAge)
+}
+
+func (p *validated) Compare(id1, id2 string) (int, error) {
+	if err := p.checkPair(id1, id2); err != nil {
+		return 0, err
+	}
+	return p.Provider.Compare(id1, id2)
+}
+
+func (p *validated) IsBefore(id1, id2 string) (bool, error) {
+	if err := p.checkPair(id1, id2); err != nil {
+		return false, err
+	}
+	return p.Provider.IsBefore(id1, id2)
+}
+
+func (p *validated) IsAfter(id1, id2 string) (bool, error) {
+	if err := p.checkPair(id1, id2); err != nil {
+		return false, err
+	}
+	return p.Provider.IsAfter(id1, id2)
+}
+
+func (p *validated) ToBytes(id string) ([16]byte, error) {
+	if err := p.check(id); err != nil {
+		return [16]byte{}, err
+	}
+	return p.Provider.ToBytes(id)
+}
+
+func (p *validated) ToUUID(id string) (string, error) {
+	if err := p.check(id); err != nil {
+		return "", err
+	}
+	return p.Provider.ToUUID(id)
+}
@@ -0,0 +1,206 @@
+package id_test
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/bold-minds/id/idtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Chain_Order(t *testing.T) {
+	var calls []string
+	tag := func(name string) func(id.Provider) id.Provider {
+		return id.Recorded(func(string) { calls = append(calls, name) })
+	}
+
+	// Act
+	p := id.Chain(id.NewGenerator(), tag("outer"), tag("inner"))
+	p.Generate()
+
+	// Assert
+	assert.Equal(t, []string{"inner", "outer"}, calls, "inner wrappers see results first")
+	assert.IsType(t, id.NewGenerator(), id.Chain(id.NewGenerator()))
+}
+
+func Test_Chain_Conformance(t *testing.T) {
+	var m id.ProviderMetrics
+	var mu sync.Mutex
+	recorded := 0
+	p := id.Chain(id.NewGenerator(),
+		id.Instrumented(&m),
+		id.RateLimited(1e6, 1e4),
+		id.Recorded(func(string) { mu.Lock(); recorded++; mu.Unlock() }),
+		id.Validated(nil),
+	)
+
+	// Act & Assert
+	idtest.TestProvider(t, p)
+	assert.Equal(t, uint64(recorded), m.Stats().Generated) //nolint:gosec // G115: count is small
+}
+
+func Test_Instrumented(t *testing.T) {
+	var m id.ProviderMetrics
+	p := id.Chain(id.NewGenerator(), id.Instrumented(&m))
+
+	// Act
+	generated := p.Generate()
+	p.GenerateBatch(4)
+	p.IsIdValid(generated)
+	p.IsIdValid("nope")
+	_, _ = p.ValidateAndNormalize("nope")
+	_, _ = p.ExtractTimestamp("nope")
+	_, _ = p.Compare(generated, generated)
+
+	// Assert
+	assert.Equal(t, id.ProviderStats{Generated: 5, Validations: 3, Invalid: 2, Errors: 1}, m.Stats())
+}
+
+func Test_RateLimited(t *testing.T) {
+	p := id.Chain(id.NewGenerator(), id.RateLimited(200, 1))
+
+	// Act
+	start := time.Now()
+	for range 5 {
+		p.Generate()
+	}
+	p.GenerateBatch(5)
+	elapsed := time.Since(start)
+
+	// Assert
+	assert.GreaterOrEqual(t, elapsed, 40*time.Millisecond, "10 IDs at 200/s after a burst of 1")
+	assert.Same(t, p, id.Chain(p, id.RateLimited(0, 1)))
+}
+
+func Test_RateLimitedClock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := id.NewFakeClock(start)
+	p := id.Chain(id.NewGenerator(), id.RateLimitedClock(clock, 10, 2))
+
+	// Act
+	p.GenerateBatch(2)
+	afterBurst := clock.Now()
+	p.GenerateBatch(10)
+
+	// Assert
+	assert.Equal(t, start, afterBurst, "the burst is free")
+	assert.Equal(t, start.Add(time.Second), clock.Now(), "10 more IDs at 10/s wait a second on the clock")
+}
+
+func Test_Signed(t *testing.T) {
+	sign, err := id.Signed(signingKey, rotatedKey)
+	require.NoError(t, err)
+	p := id.Chain(id.NewGenerator(), sign)
+	verifier, err := id.NewSignedGenerator(signingKey)
+	require.NoError(t, err)
+	rotated, err := id.NewSignedGenerator(rotatedKey)
+	require.NoError(t, err)
+
+	// Act
+	token := p.Generate()
+	inner, err := verifier.VerifySigned(token)
+
+	// Assert
+	require.NoError(t, err, "tokens match the SignedGenerator format")
+	assert.True(t, p.IsIdValid(token))
+	assert.True(t, p.IsIdValid(rotated.Generate()), "previous keys still verify")
+	assert.True(t, p.IsIdValid(strings.ToLower(token)))
+	ts, err := p.ExtractTimestamp(token)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), ts, time.Second)
+	normalized, err := p.ValidateAndNormalize(strings.ToLower(token))
+	require.NoError(t, err)
+	assert.Equal(t, token, normalized)
+	b, err := p.ToBytes(token)
+	require.NoError(t, err)
+	assert.Equal(t, inner, id.NewGenerator().FromBytes(b))
+
+	tampered := token[:25] + "Z" + token[26:]
+	if tampered == token {
+		tampered = token[:25] + "Y" + token[26:]
+	}
+	assert.False(t, p.IsIdValid(tampered))
+	assert.False(t, p.IsIdValid(inner))
+	_, err = p.Age(inner)
+	assert.ErrorIs(t, err, id.ErrInvalidSignature)
+	_, err = p.Compare(token, tampered)
+	assert.ErrorIs(t, err, id.ErrInvalidSignature)
+
+	_, err = id.Signed([]byte("short"))
+	assert.Error(t, err)
+}
+
+func Test_Signed_RejectsIncompatibleProvider(t *testing.T) {
+	sign, err := id.Signed(signingKey)
+	require.NoError(t, err)
+	prefixed, err := id.NewPrefixedGenerator("cus")
+	require.NoError(t, err)
+
+	// Act
+	p := id.Chain(prefixed, sign)
+	token := p.Generate()
+
+	// Assert
+	assert.Panics(t, func() { id.Chain(id.NewGenerator(id.WithScheme(id.SchemeKSUID)), sign) },
+		"KSUIDs do not fit in 16 bytes")
+	assert.True(t, strings.HasPrefix(token, "cus_"))
+	assert.True(t, p.IsIdValid(token))
+}
+
+func Test_Recorded(t *testing.T) {
+	var got []string
+	p := id.Chain(id.NewGenerator(), id.Recorded(func(s string) { got = append(got, s) }))
+
+	// Act
+	want := []string{p.Generate()}
+	want = append(want, p.GenerateBatch(3)...)
+	want = append(want, p.GenerateWithTime(time.Now()))
+
+	// Assert
+	assert.Equal(t, want, got)
+}
+
+func Test_Validated(t *testing.T) {
+	errTenant := errors.New("reserved ID")
+	reserved := id.NewGenerator().Generate()
+	p := id.Chain(id.NewGenerator(), id.Validated(func(s string) error {
+		if s == reserved {
+			return errTenant
+		}
+		return id.ValidateFull(s)
+	}))
+
+	// Act
+	_, reservedErr := p.ExtractTimestamp(reserved)
+	_, truncatedErr := p.ValidateAndNormalize(reserved[:20] + "...")
+
+	// Assert
+	assert.NoError(t, id.Validate(p.Generate()))
+	assert.False(t, p.IsIdValid(reserved))
+	assert.ErrorIs(t, reservedErr, errTenant)
+	assert.ErrorIs(t, truncatedErr, id.ErrTruncated)
+
+	assert.Panics(t, func() {
+		id.Chain(id.NewGenerator(), id.Validated(func(string) error { return errTenant }))
+	}, "a check that rejects every ID fails at assembly")
+}
+
+func Test_Validated_RejectsIncompatibleProvider(t *testing.T) {
+	prefixed, err := id.NewPrefixedGenerator("cus")
+	require.NoError(t, err)
+
+	// Act & Assert
+	assert.Panics(t, func() { id.Chain(prefixed, id.Validated(nil)) })
+	assert.Panics(t, func() { id.Chain(id.NewGenerator(id.WithScheme(id.SchemeKSUID)), id.Validated(nil)) })
+	assert.NotPanics(t, func() {
+		id.Chain(prefixed, id.Validated(func(s string) error {
+			_, err := prefixed.ValidateAndNormalize(s)
+			return err
+		}))
+	})
+}
@@ -0,0 +1,84 @@
+package id
+
+import (
+	"math"
+	"time"
+)
+
+// Rate returns the number of IDs per second across the collection's time span.
+// Spans shorter than one millisecond are treated as one millisecond, the
+// resolution of a ULID timestamp. Collections of fewer than two IDs have no rate.
+func (s Stats) Rate() float64 {
+	if s.Count < 2 {
+		return 0
+	}
+	span := max(s.TimeSpan, time.Millisecond)
+	return float64(s.Count) / span.Seconds()
+}
+
+// Delta describes how one ID collection differs from another
+type Delta struct {
+	Before Stats
+	After  Stats
+
+	// CountChange is After.Count minus Before.Count
+	CountChange int
+	// RateChange is After.Rate() minus Before.Rate(), in IDs per second
+	RateChange float64
+	// SpanChange is After.TimeSpan minus Before.TimeSpan
+	SpanChange time.Duration
+
+	// CountRelative, RateRelative, and SpanRelative are the changes as a
+	// fraction of the before value: 0.5 is a 50% increase and -1 means the
+	// value dropped to zero. A change from zero is +Inf.
+	CountRelative float64
+	RateRelative  float64
+	SpanRelative  float64
+}
+
+// Exceeds reports whether the count, rate, or span moved by more than
+// threshold as a relative change in either direction, for use in alerting
+func (d Delta) Exceeds(threshold float64) bool {
+	return math.Abs(d.CountRelative) > threshold ||
+		math.Abs(d.RateRelative) > threshold ||
+		math.Abs(d.SpanRelative) > threshold
+}
+
+// CompareStats summarizes ID collections a and b (e.g. yesterday and today)
+// and reports how count, rate, and time span changed from a to b.
+// Invalid IDs are ignored and input order does not matter.
+func CompareStats(a, b []string) Delta {
+	before := summarize(a)
+	after := summarize(b)
+
+	return Delta{
+		Before:        before,
+		After:         after,
+		CountChange:   after.Count - before.Count,
+		RateChange:    after.Rate() - before.Rate(),
+		SpanChange:    after.TimeSpan - before.TimeSpan,
+		CountRelative: relativeChange(float64(before.Count), float64(after.Count)),
+		RateRelative:  relativeChange(before.Rate(), after.Rate()),
+		SpanRelative:  relativeChange(float64(before.TimeSpan), float64(after.TimeSpan)),
+	}
+}
+
+// summarize computes Stats for ids, treating a collection with no valid IDs as empty
+func summarize(ids []string) Stats {
+	stats, err := AnalyzeIDs(ids)
+	if err != nil {
+		return Stats{}
+	}
+	return stats
+}
+
+// relativeChange returns (after-before)/before, treating any change from zero as +Inf
+func relativeChange(before, after float64) float64 {
+	if before == 0 {
+		if after == 0 {
+			return 0
+		}
+		return math.Inf(1)
+	}
+	return (after - before) / before
+}
@@ -0,0 +1,71 @@
+package id_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+)
+
+func idsAt(start time.Time, count int, step time.Duration) []string {
+	gen := id.NewGenerator()
+	ids := make([]string, count)
+	for i := range ids {
+		ids[i] = gen.GenerateWithTime(start.Add(time.Duration(i) * step))
+	}
+	return ids
+}
+
+func Test_CompareStats_Drift(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	yesterday := idsAt(start, 11, time.Second)               // 11 IDs over 10s
+	today := idsAt(start.Add(24*time.Hour), 21, time.Second) // 21 IDs over 20s
+
+	// Act
+	delta := id.CompareStats(yesterday, today)
+
+	// Assert
+	assert.Equal(t, 10, delta.CountChange)
+	assert.Equal(t, 10*time.Second, delta.SpanChange)
+	assert.InDelta(t, 1.1-1.05, -delta.RateChange, 1e-9)
+	assert.InDelta(t, 10.0/11.0, delta.CountRelative, 1e-9)
+	assert.InDelta(t, 1.0, delta.SpanRelative, 1e-9)
+	assert.True(t, delta.Exceeds(0.5))
+	assert.False(t, delta.Exceeds(1.0))
+}
+
+func Test_CompareStats_UnsortedAndInvalid(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ids := idsAt(start, 3, time.Minute)
+	shuffled := []string{ids[2], "bogus", ids[0], ids[1]}
+
+	// Act
+	delta := id.CompareStats(shuffled, ids)
+
+	// Assert
+	assert.Equal(t, 3, delta.Before.Count)
+	assert.Equal(t, ids[0], delta.Before.FirstID)
+	assert.Equal(t, ids[2], delta.Before.LastID)
+	assert.Equal(t, 2*time.Minute, delta.Before.TimeSpan)
+	assert.Zero(t, delta.CountChange)
+	assert.False(t, delta.Exceeds(0))
+}
+
+func Test_CompareStats_FromEmpty(t *testing.T) {
+	// Act
+	delta := id.CompareStats(nil, idsAt(time.Now(), 5, time.Millisecond))
+
+	// Assert
+	assert.Equal(t, 5, delta.CountChange)
+	assert.True(t, math.IsInf(delta.CountRelative, 1))
+	assert.True(t, delta.Exceeds(100))
+	assert.Zero(t, id.CompareStats(nil, nil).CountRelative)
+}
+
+func Test_Stats_Rate(t *testing.T) {
+	assert.Zero(t, id.Stats{Count: 1}.Rate())
+	assert.InDelta(t, 2.0, id.Stats{Count: 4, TimeSpan: 2 * time.Second}.Rate(), 1e-9)
+	assert.InDelta(t, 3000.0, id.Stats{Count: 3}.Rate(), 1e-9)
+}
@@ -0,0 +1,195 @@
+package id
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/bits"
+	"strings"
+)
+
+const (
+	// Base58Size is the length of a Base58-encoded ID
+	Base58Size = 22
+	// HexSize is the length of a hex-encoded ID
+	HexSize = 2 * ulidSize
+	// Base64URLSize is the length of a base64url-encoded ID
+	Base64URLSize = 22
+
+	// base58Alphabet is the Bitcoin alphabet, which is in ASCII order
+	base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+)
+
+// ErrInvalidEncoding is returned when a string is not a valid encoding of an ID
+var ErrInvalidEncoding = errors.New("invalid ID encoding")
+
+// Encoder renders the 16 bytes of an ID in an alternate text form. Every
+// encoder maps each ID to exactly one string and back.
+type Encoder interface {
+	// Encode renders id
+	Encode(id ID) string
+	// Decode parses the encoder's text form
+	Decode(s string) (ID, error)
+}
+
+var (
+	// CrockfordLower renders IDs as lowercase ULIDs, which sort like
+	// canonical ones. It decodes either case.
+	CrockfordLower Encoder = crockfordLower{}
+	// Base58 renders IDs in 22 characters of the Bitcoin alphabet, which
+	// avoids look-alike characters and punctuation for QR codes and URLs.
+	// The encoding is fixed-width and its alphabet is in ASCII order, so
+	// encoded IDs sort chronologically.
+	Base58 Encoder = base58Encoder{}
+	// Hex renders IDs as 32 lowercase hex digits, as log pipelines and
+	// databases often show them. It decodes either case.
+	Hex Encoder = hexEncoder{}
+	// Base64URL renders IDs in 22 unpadded URL-safe base64 characters, the
+	// most compact form. Encoded IDs do not sort chronologically.
+	Base64URL Encoder = base64URLEncoder{}
+)
+
+// EncodeBase58 re-encodes a ULID, given in either case, in Base58
+func EncodeBase58(id string) (string, error) {
+	return encodeWith(Base58, id)
+}
+
+// DecodeBase58 returns the canonical ULID for a Base58-encoded ID
+func DecodeBase58(s string) (string, error) {
+	return decodeWith(Base58, s)
+}
+
+// EncodeHex re-encodes a ULID, given in either case, in hex
+func EncodeHex(id string) (string, error) {
+	return encodeWith(Hex, id)
+}
+
+// DecodeHex returns the canonical ULID for a hex-encoded ID
+func DecodeHex(s string) (string, error) {
+	return decodeWith(Hex, s)
+}
+
+// EncodeBase64URL re-encodes a ULID, given in either case, in base64url
+func EncodeBase64URL(id string) (string, error) {
+	return encodeWith(Base64URL, id)
+}
+
+// DecodeBase64URL returns the canonical ULID for a base64url-encoded ID
+func DecodeBase64URL(s string) (string, error) {
+	return decodeWith(Base64URL, s)
+}
+
+// encodeWith parses a ULID and renders it with enc
+func encodeWith(enc Encoder, id string) (string, error) {
+	parsed, err := parseCanonical(id)
+	if err != nil {
+		return "", fmt.Errorf("invalid ULID: %w", err)
+	}
+	return enc.Encode(ID(parsed)), nil
+}
+
+// decodeWith parses s with enc and renders the canonical ULID
+func decodeWith(enc Encoder, s string) (string, error) {
+	parsed, err := enc.Decode(s)
+	if err != nil {
+		return "", err
+	}
+	return parsed.String(), nil
+}
+
+// crockfordLower implements CrockfordLower
+type crockfordLower struct{}
+
+func (crockfordLower) Encode(id ID) string {
+	return strings.ToLower(id.String())
+}
+
+func (crockfordLower) Decode(s string) (ID, error) {
+	parsed, err := parseCanonical(s)
+	if err != nil {
+		return ID{}, fmt.Errorf("%w: %w", ErrInvalidEncoding, err)
+	}
+	return ID(parsed), nil
+}
+
+// base58Encoder implements Base58 on the ID as a 128-bit big-endian number
+type base58Encoder struct{}
+
+func (base58Encoder) Encode(id ID) string {
+	hi, lo := binary.BigEndian.Uint64(id[:8]), binary.BigEndian.Uint64(id[8:])
+	var text [Base58Size]byte
+	for i := len(text) - 1; i >= 0; i-- {
+		var rem uint64
+		hi, rem = bits.Div64(0, hi, 58)
+		lo, rem = bits.Div64(rem, lo, 58)
+		text[i] = base58Alphabet[rem]
+	}
+	return string(text[:])
+}
+
+func (base58Encoder) Decode(s string) (ID, error) {
+	if len(s) != Base58Size {
+		return ID{}, fmt.Errorf("%w: got %d Base58 characters, want %d", ErrInvalidEncoding, len(s), Base58Size)
+	}
+
+	var hi, lo uint64
+	for i := 0; i < len(s); i++ {
+		digit := strings.IndexByte(base58Alphabet, s[i])
+		if digit < 0 {
+			return ID{}, fmt.Errorf("%w: invalid Base58 character %q at position %d", ErrInvalidEncoding, s[i], i)
+		}
+		// (hi, lo) = (hi, lo)*58 + digit, rejecting values past 128 bits
+		carry, product := bits.Mul64(lo, 58)
+		var c uint64
+		lo, c = bits.Add64(product, uint64(digit), 0)
+		overflow, high := bits.Mul64(hi, 58)
+		hi, c = bits.Add64(high, carry+c, 0)
+		if overflow != 0 || c != 0 {
+			return ID{}, fmt.Errorf("%w: Base58 value exceeds 128 bits", ErrInvalidEncoding)
+		}
+	}
+
+	var id ID
+	binary.BigEndian.PutUint64(id[:8], hi)
+	binary.BigEndian.PutUint64(id[8:], lo)
+	return id, nil
+}
+
+// hexEncoder implements Hex
+type hexEncoder struct{}
+
+func (hexEncoder) Encode(id ID) string {
+	return hex.EncodeToString(id[:])
+}
+
+func (hexEncoder) Decode(s string) (ID, error) {
+	var id ID
+	if len(s) != HexSize {
+		return id, fmt.Errorf("%w: got %d hex digits, want %d", ErrInvalidEncoding, len(s), HexSize)
+	}
+	if _, err := hex.Decode(id[:], []byte(s)); err != nil {
+		return ID{}, fmt.Errorf("%w: %w", ErrInvalidEncoding, err)
+	}
+	return id, nil
+}
+
+// base64URLEncoder implements Base64URL. Strict decoding rejects stray low
+// bits in the last character, so each ID has exactly one encoding.
+type base64URLEncoder struct{}
+
+func (base64URLEncoder) Encode(id ID) string {
+	return base64.RawURLEncoding.EncodeToString(id[:])
+}
+
+func (base64URLEncoder) Decode(s string) (ID, error) {
+	var id ID
+	if len(s) != Base64URLSize {
+		return id, fmt.Errorf("%w: got %d base64url characters, want %d", ErrInvalidEncoding, len(s), Base64URLSize)
+	}
+	if _, err := base64.RawURLEncoding.Strict().Decode(id[:], []byte(s)); err != nil {
+		return ID{}, fmt.Errorf("%w: %w", ErrInvalidEncoding, err)
+	}
+	return id, nil
+}
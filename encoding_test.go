@@ -0,0 +1,148 @@
+package id_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// encoders lists every built-in Encoder by name
+var encoders = map[string]id.Encoder{
+	"CrockfordLower": id.CrockfordLower,
+	"Base58":         id.Base58,
+	"Hex":            id.Hex,
+	"Base64URL":      id.Base64URL,
+}
+
+func Test_Encoders_Vectors(t *testing.T) {
+	ulid, err := id.DecodeHex("0157b0e1c2d3e4f5a6b7c8d9eafb0c1d")
+	require.NoError(t, err)
+	var ones id.ID
+	for i := range ones {
+		ones[i] = 0xff
+	}
+
+	// Act & Assert
+	assert.Equal(t, strings.ToLower(ulid), id.CrockfordLower.Encode(mustParseID(t, ulid)))
+	for encoded, want := range map[string]string{
+		mustEncode(t, id.EncodeBase58, ulid):    "1AchEZEYgj61Tz68Jf5kha",
+		mustEncode(t, id.EncodeHex, ulid):       "0157b0e1c2d3e4f5a6b7c8d9eafb0c1d",
+		mustEncode(t, id.EncodeBase64URL, ulid): "AVew4cLT5PWmt8jZ6vsMHQ",
+		id.Base58.Encode(id.ID{}):               "1111111111111111111111",
+		id.Base58.Encode(ones):                  "YcVfxkQb6JRzqk5kF2tNLv",
+	} {
+		assert.Equal(t, want, encoded)
+	}
+}
+
+func Test_Encoders_RoundTrip(t *testing.T) {
+	ids := id.NewGenerator().GenerateBatch(100)
+
+	for name, enc := range encoders {
+		t.Run(name, func(t *testing.T) {
+			for _, ulid := range ids {
+				parsed := mustParseID(t, ulid)
+
+				// Act
+				decoded, err := enc.Decode(enc.Encode(parsed))
+
+				// Assert
+				require.NoError(t, err)
+				assert.Equal(t, parsed, decoded)
+			}
+		})
+	}
+}
+
+func Test_Base58_Sorts(t *testing.T) {
+	ids := shuffledIDs(200)
+	encoded := make([]string, len(ids))
+	for i, ulid := range ids {
+		encoded[i] = mustEncode(t, id.EncodeBase58, ulid)
+	}
+
+	// Act
+	slices.Sort(ids)
+	slices.Sort(encoded)
+
+	// Assert
+	for i, ulid := range ids {
+		decoded, err := id.DecodeBase58(encoded[i])
+		require.NoError(t, err)
+		assert.Equal(t, ulid, decoded)
+	}
+}
+
+func Test_Encoders_Invalid(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"YcVfxkQb6JRzqk5kF2tNLw", // one past the largest 128-bit value
+		"0AchEZEYgj61Tz68Jf5kha", // 0 is not in the alphabet
+		"1AchEZEYgj61Tz68Jf5kh",
+	} {
+		_, err := id.DecodeBase58(s)
+		assert.ErrorIs(t, err, id.ErrInvalidEncoding, s)
+	}
+	_, err := id.DecodeHex("0157b0e1c2d3e4f5a6b7c8d9eafb0c1g")
+	require.ErrorIs(t, err, id.ErrInvalidEncoding)
+	_, err = id.DecodeBase64URL("AVew4cLT5PWmt8jZ6vsMHR") // stray low bits
+	require.ErrorIs(t, err, id.ErrInvalidEncoding)
+	_, err = id.CrockfordLower.Decode("invalid")
+	require.ErrorIs(t, err, id.ErrInvalidEncoding)
+	_, err = id.EncodeHex("invalid")
+	assert.Error(t, err)
+}
+
+func Fuzz_Encoders_RoundTrip(f *testing.F) {
+	f.Add(make([]byte, 16))
+	f.Add([]byte{0x01, 0x57, 0xb0, 0xe1, 0xc2, 0xd3, 0xe4, 0xf5, 0xa6, 0xb7, 0xc8, 0xd9, 0xea, 0xfb, 0x0c, 0x1d})
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		var parsed id.ID
+		copy(parsed[:], raw)
+		for name, enc := range encoders {
+			decoded, err := enc.Decode(enc.Encode(parsed))
+			if err != nil || decoded != parsed {
+				t.Fatalf("%s round trip of %x gave %x, %v", name, parsed, decoded, err)
+			}
+		}
+	})
+}
+
+func Fuzz_Encoders_Decode(f *testing.F) {
+	f.Add("1AchEZEYgj61Tz68Jf5kha")
+	f.Add("0157b0e1c2d3e4f5a6b7c8d9eafb0c1d")
+	f.Add("AVew4cLT5PWmt8jZ6vsMHQ")
+	f.Add("01arz3ndektsv4rrffq69g5fav")
+	f.Fuzz(func(t *testing.T, s string) {
+		for name, enc := range encoders {
+			decoded, err := enc.Decode(s)
+			if err != nil {
+				continue
+			}
+			// Each ID has one encoding, up to the case the decoder ignores
+			if encoded := enc.Encode(decoded); encoded != s && (name == "Base58" || name == "Base64URL" || !strings.EqualFold(encoded, s)) {
+				t.Fatalf("%s decoded %q but encodes it as %q", name, s, encoded)
+			}
+		}
+	})
+}
+
+// mustParseID parses a canonical ULID into an ID
+func mustParseID(t *testing.T, ulid string) id.ID {
+	t.Helper()
+	parsed, err := id.CrockfordLower.Decode(ulid)
+	require.NoError(t, err)
+	return parsed
+}
+
+// mustEncode re-encodes a ULID with one of the Encode functions
+func mustEncode(t *testing.T, encode func(string) (string, error), ulid string) string {
+	t.Helper()
+	encoded, err := encode(ulid)
+	require.NoError(t, err)
+	return encoded
+}
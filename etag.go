@@ -0,0 +1,51 @@
+package id
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+)
+
+// CacheKeySeparator joins the parts of a cache key
+const CacheKeySeparator = ':'
+
+// cacheKeyEscaper percent-encodes the separator and the escape character, so
+// distinct part lists never share a key
+var cacheKeyEscaper = strings.NewReplacer("%", "%25", string(CacheKeySeparator), "%3A")
+
+// ETag returns a strong, quoted entity tag for version of the resource keyed
+// by id, such as "01ARZ3NDEKTSV4RRFFQ69G5FAV-3". ULIDs are canonicalized, so
+// an ID given in either case yields the same tag. Other IDs are hashed, which
+// keeps the tag free of characters an ETag may not contain.
+func ETag(id string, version int) string {
+	return `"` + cacheKeyPart(id, true) + "-" + strconv.Itoa(version) + `"`
+}
+
+// CacheKey joins parts into a cache key such as "user:01ARZ3NDEKTSV4RRFFQ69G5FAV:profile".
+// ULID parts are canonicalized like ETag, and separators inside a part are
+// escaped, so equal inputs always give the same key and different inputs
+// never collide.
+func CacheKey(parts ...string) string {
+	var b strings.Builder
+	for i, part := range parts {
+		if i > 0 {
+			b.WriteByte(CacheKeySeparator)
+		}
+		b.WriteString(cacheKeyPart(part, false))
+	}
+	return b.String()
+}
+
+// cacheKeyPart renders a valid ULID in canonical form and anything else
+// either hashed or escaped
+func cacheKeyPart(part string, hash bool) string {
+	if parsed, err := parseCanonical(part); err == nil {
+		return parsed.String()
+	}
+	if hash {
+		sum := sha256.Sum256([]byte(part))
+		return hex.EncodeToString(sum[:16])
+	}
+	return cacheKeyEscaper.Replace(part)
+}
@@ -0,0 +1,39 @@
+package id_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ETag(t *testing.T) {
+	ulid := "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+
+	// Act & Assert
+	assert.Equal(t, `"01ARZ3NDEKTSV4RRFFQ69G5FAV-3"`, id.ETag(ulid, 3))
+	assert.Equal(t, id.ETag(ulid, 3), id.ETag(strings.ToLower(ulid), 3))
+	assert.NotEqual(t, id.ETag(ulid, 3), id.ETag(ulid, 4))
+}
+
+func Test_ETag_NonULID(t *testing.T) {
+	// Act
+	tag := id.ETag(`order "42"`, 1)
+
+	// Assert
+	assert.Equal(t, tag, id.ETag(`order "42"`, 1))
+	assert.NotContains(t, tag[1:len(tag)-1], `"`)
+	assert.True(t, strings.HasSuffix(tag, `-1"`))
+}
+
+func Test_CacheKey(t *testing.T) {
+	ulid := "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+
+	// Act & Assert
+	assert.Equal(t, "user:01ARZ3NDEKTSV4RRFFQ69G5FAV:profile", id.CacheKey("user", strings.ToLower(ulid), "profile"))
+	assert.Equal(t, "a%3Ab:c", id.CacheKey("a:b", "c"))
+	assert.NotEqual(t, id.CacheKey("a:b", "c"), id.CacheKey("a", "b:c"))
+	assert.NotEqual(t, id.CacheKey("a%3Ab"), id.CacheKey("a:b"))
+	assert.Empty(t, id.CacheKey())
+}
@@ -0,0 +1,96 @@
+package id
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// FallbackEvent records a read that the primary entropy source failed
+type FallbackEvent struct {
+	Time time.Time
+	Err  error
+}
+
+// FallbackReader is an entropy source that reads from a primary source and
+// transparently switches to a secondary source for any read the primary
+// fails, so generation never panics on an exhausted or broken primary.
+// FallbackReaders nest to form longer chains.
+type FallbackReader struct {
+	primary   io.Reader
+	secondary io.Reader
+
+	mu        sync.Mutex
+	fallbacks uint64
+	last      FallbackEvent
+	onEvent   func(FallbackEvent)
+}
+
+// NewFallbackReader creates an entropy source that falls back from primary
+// to secondary. Pass it to WithEntropy, keeping it to observe fallbacks.
+func NewFallbackReader(primary, secondary io.Reader) *FallbackReader {
+	return &FallbackReader{
+		primary:   primary,
+		secondary: secondary,
+	}
+}
+
+// WithEntropyFallback makes the generator draw randomness from primary,
+// falling back to secondary for any read primary fails. Use WithEntropy
+// with a NewFallbackReader to observe fallbacks.
+func WithEntropyFallback(primary, secondary io.Reader) Option {
+	return WithEntropy(NewFallbackReader(primary, secondary))
+}
+
+// OnFallback registers a callback invoked after every fallback, for logging or metrics.
+// The callback must not read from the FallbackReader.
+func (f *FallbackReader) OnFallback(fn func(FallbackEvent)) *FallbackReader {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.onEvent = fn
+	return f
+}
+
+// Read fills p from the primary source, or from the secondary source if the
+// primary returns an error or a short read
+func (f *FallbackReader) Read(p []byte) (int, error) {
+	n, err := io.ReadFull(f.primary, p)
+	if err == nil {
+		return n, nil
+	}
+
+	event := FallbackEvent{Time: time.Now(), Err: err}
+	f.mu.Lock()
+	f.fallbacks++
+	f.last = event
+	onEvent := f.onEvent
+	f.mu.Unlock()
+
+	if onEvent != nil {
+		onEvent(event)
+	}
+
+	n, serr := io.ReadFull(f.secondary, p)
+	if serr != nil {
+		return n, fmt.Errorf("entropy fallback failed: primary: %v, secondary: %w", err, serr)
+	}
+	return n, nil
+}
+
+// Fallbacks returns how many reads were served by the secondary source
+func (f *FallbackReader) Fallbacks() uint64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.fallbacks
+}
+
+// LastFallback returns the most recent fallback event, if any
+func (f *FallbackReader) LastFallback() (FallbackEvent, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.last, f.fallbacks > 0
+}
@@ -0,0 +1,83 @@
+package id_test
+
+import (
+	"crypto/rand"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) { return 0, errors.New("hardware RNG exhausted") }
+
+func Test_FallbackReader(t *testing.T) {
+	var events []id.FallbackEvent
+	entropy := id.NewFallbackReader(failingReader{}, rand.Reader).OnFallback(func(e id.FallbackEvent) {
+		events = append(events, e)
+	})
+	gen := id.NewGenerator(id.WithEntropy(entropy))
+
+	// Act
+	ulid := gen.Generate()
+
+	// Assert
+	assert.True(t, gen.IsIdValid(ulid))
+	assert.Equal(t, uint64(1), entropy.Fallbacks())
+	last, ok := entropy.LastFallback()
+	require.True(t, ok)
+	assert.EqualError(t, last.Err, "hardware RNG exhausted")
+	assert.Len(t, events, 1)
+}
+
+func Test_FallbackReader_PrimaryHealthy(t *testing.T) {
+	entropy := id.NewFallbackReader(rand.Reader, failingReader{})
+	buf := make([]byte, 10)
+
+	// Act
+	_, err := entropy.Read(buf)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Zero(t, entropy.Fallbacks())
+	_, ok := entropy.LastFallback()
+	assert.False(t, ok)
+}
+
+func Test_FallbackReader_ShortPrimary(t *testing.T) {
+	entropy := id.NewFallbackReader(strings.NewReader("abc"), rand.Reader)
+	buf := make([]byte, 10)
+
+	// Act
+	_, err := io.ReadFull(entropy, buf)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), entropy.Fallbacks())
+}
+
+func Test_FallbackReader_BothFail(t *testing.T) {
+	entropy := id.NewFallbackReader(failingReader{}, failingReader{})
+
+	// Act
+	_, err := entropy.Read(make([]byte, 10))
+
+	// Assert
+	assert.Error(t, err)
+}
+
+func Test_WithEntropyFallback(t *testing.T) {
+	gen := id.NewGenerator(id.WithEntropyFallback(failingReader{}, rand.Reader))
+
+	// Act
+	generated, err := gen.TryGenerate()
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, gen.IsIdValid(generated))
+}
@@ -0,0 +1,126 @@
+package id
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/oklog/ulid"
+)
+
+// ErrChecksumMismatch is returned when an ID's check symbol does not match its value
+var ErrChecksumMismatch = errors.New("check symbol does not match")
+
+// Case selects the letter case of formatted ULIDs
+type Case int
+
+const (
+	// CaseUpper emits canonical uppercase Crockford Base32
+	CaseUpper Case = iota
+	// CaseLower emits lowercase Crockford Base32
+	CaseLower
+)
+
+// checkSymbols is the Crockford Base32 check symbol alphabet (value mod 37)
+const checkSymbols = ulid.Encoding + "*~$=U"
+
+// FormatProfile describes how a generator renders the ULIDs it emits, so all
+// IDs match an organization's formatting policy. The zero value is the
+// canonical 26-character uppercase form.
+type FormatProfile struct {
+	// Case selects uppercase or lowercase letters
+	Case Case
+	// Checksum appends a Crockford mod-37 check symbol
+	Checksum bool
+	// GroupSize inserts a hyphen every GroupSize characters; 0 disables grouping
+	GroupSize int
+}
+
+// LowercaseProfile renders lowercase Crockford Base32 for backends that only
+// accept lowercase, such as DNS labels or S3 bucket names. Lowercase IDs sort
+// byte-wise in the same order as canonical ones.
+var LowercaseProfile = FormatProfile{Case: CaseLower}
+
+// NewLowercaseGenerator creates a generator with default entropy whose IDs
+// are emitted, normalized, and converted in LowercaseProfile form
+func NewLowercaseGenerator() *generator {
+	return NewGenerator().WithFormatProfile(LowercaseProfile)
+}
+
+// Canonical reports whether the profile produces the canonical ULID form
+func (p FormatProfile) Canonical() bool {
+	return p == FormatProfile{}
+}
+
+// format renders a binary ULID according to the profile
+func (p FormatProfile) format(u ulid.ULID) string {
+	s := u.String()
+	if p.Checksum {
+		s += string(checkSymbols[checksum(u)])
+	}
+	if p.Case == CaseLower {
+		s = strings.ToLower(s)
+	}
+	if p.GroupSize > 0 && p.GroupSize < len(s) {
+		var b strings.Builder
+		b.Grow(len(s) + len(s)/p.GroupSize)
+		for i := 0; i < len(s); i += p.GroupSize {
+			if i > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteString(s[i:min(i+p.GroupSize, len(s))])
+		}
+		s = b.String()
+	}
+	return s
+}
+
+// ParseFormatted parses a ULID rendered under any FormatProfile: either case,
+// hyphen grouping, and an optional trailing check symbol, which is verified
+func ParseFormatted(s string) (ulid.ULID, error) {
+	parsed, err := parseFormatted(s)
+	if err != nil {
+		return ulid.ULID{}, fmt.Errorf("invalid ULID: %w", err)
+	}
+	return parsed, nil
+}
+
+func parseFormatted(s string) (ulid.ULID, error) {
+	stripped := strings.ToUpper(strings.ReplaceAll(s, "-", ""))
+
+	if len(stripped) == ulid.EncodedSize+1 {
+		return parseChecked(stripped[:ulid.EncodedSize], stripped[ulid.EncodedSize])
+	}
+	return ulid.ParseStrict(stripped)
+}
+
+// parseChecked parses a canonical ULID and verifies its check symbol,
+// returning ErrChecksumMismatch if the symbol does not match
+func parseChecked(body string, check byte) (ulid.ULID, error) {
+	parsed, err := ulid.ParseStrict(body)
+	if err != nil {
+		return ulid.ULID{}, err
+	}
+	if checkSymbols[checksum(parsed)] != check {
+		return ulid.ULID{}, fmt.Errorf("%w: %q", ErrChecksumMismatch, check)
+	}
+	return parsed, nil
+}
+
+// Reformat parses a ULID in any supported form and renders it under profile
+func Reformat(id string, profile FormatProfile) (string, error) {
+	parsed, err := ParseFormatted(id)
+	if err != nil {
+		return "", err
+	}
+	return profile.format(parsed), nil
+}
+
+// checksum returns the 128-bit ULID value modulo 37
+func checksum(u ulid.ULID) int {
+	rem := 0
+	for _, b := range u {
+		rem = (rem*256 + int(b)) % 37
+	}
+	return rem
+}
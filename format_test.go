@@ -0,0 +1,115 @@
+package id_test
+
+import (
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WithFormatProfile(t *testing.T) {
+	profile := id.FormatProfile{Case: id.CaseLower, Checksum: true, GroupSize: 9}
+	gen := id.NewGenerator().WithFormatProfile(profile)
+
+	// Act
+	formatted := gen.Generate()
+
+	// Assert
+	assert.Len(t, formatted, 27+2) // 26 chars + check symbol + 2 hyphens
+	assert.Equal(t, strings.ToLower(formatted), formatted)
+	assert.Equal(t, 2, strings.Count(formatted, "-"))
+	assert.True(t, gen.IsIdValid(formatted))
+	_, err := gen.ExtractTimestamp(formatted)
+	assert.NoError(t, err)
+
+	canonical, err := id.Reformat(formatted, id.FormatProfile{})
+	require.NoError(t, err)
+	assert.True(t, id.NewGenerator().IsIdValid(canonical))
+
+	normalized, err := gen.ValidateAndNormalize(canonical)
+	require.NoError(t, err)
+	assert.Equal(t, formatted, normalized)
+
+	for _, batchID := range gen.GenerateBatch(3) {
+		assert.True(t, gen.IsIdValid(batchID))
+	}
+}
+
+func Test_Reformat(t *testing.T) {
+	const canonical = "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+
+	// Act
+	withCheck, err := id.Reformat(canonical, id.FormatProfile{Checksum: true})
+	require.NoError(t, err)
+	grouped, err := id.Reformat(strings.ToLower(canonical), id.FormatProfile{GroupSize: 13})
+	require.NoError(t, err)
+	back, err := id.Reformat(withCheck, id.FormatProfile{})
+	require.NoError(t, err)
+
+	// Assert
+	assert.Len(t, withCheck, 27)
+	assert.Equal(t, "01ARZ3NDEKTSV-4RRFFQ69G5FAV", grouped)
+	assert.Equal(t, canonical, back)
+}
+
+func Test_ParseFormatted_Errors(t *testing.T) {
+	withCheck, err := id.Reformat("01ARZ3NDEKTSV4RRFFQ69G5FAV", id.FormatProfile{Checksum: true})
+	require.NoError(t, err)
+	wrong := withCheck[:26] + map[bool]string{true: "1", false: "0"}[withCheck[26] == '0']
+
+	// Act & Assert
+	_, err = id.ParseFormatted(wrong)
+	assert.ErrorIs(t, err, id.ErrChecksumMismatch)
+	_, err = id.ParseFormatted("01ARZ3NDEKTSV4RRFFQ69G5FA!")
+	assert.Error(t, err)
+	_, err = id.ParseFormatted("short")
+	assert.Error(t, err)
+}
+
+func Test_NewLowercaseGenerator_RoundTrip(t *testing.T) {
+	gen := id.NewLowercaseGenerator()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	ids := gen.GenerateRange(start, start.Add(time.Hour), 50)
+	batch := gen.GenerateBatch(5)
+	normalized, err := gen.ValidateAndNormalize(strings.ToUpper(ids[0]))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, ids[0], normalized)
+	for _, s := range append(ids, batch...) {
+		assert.Equal(t, strings.ToLower(s), s)
+		assert.True(t, gen.IsIdValid(s))
+	}
+
+	raw, err := gen.ToBytes(ids[1])
+	require.NoError(t, err)
+	assert.Equal(t, ids[1], gen.FromBytes(raw))
+
+	before, err := gen.IsBefore(ids[0], ids[1])
+	require.NoError(t, err)
+	assert.True(t, before)
+}
+
+func Test_NewLowercaseGenerator_Sorting(t *testing.T) {
+	gen := id.NewLowercaseGenerator()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ids := gen.GenerateRange(start, start.Add(time.Minute), 20)
+	shuffled := append([]string(nil), ids...)
+	rand.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+
+	// Act
+	chronological := id.SortChronologically(shuffled)
+	lexical := append([]string(nil), shuffled...)
+	sort.Strings(lexical)
+
+	// Assert
+	assert.Equal(t, ids, chronological)
+	assert.Equal(t, ids, lexical)
+}
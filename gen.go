@@ -1,12 +1,10 @@
 package id
 
 import (
-	"crypto/rand"
 	"errors"
 	"fmt"
 	"io"
-	mathrand "math/rand"
-	"sort"
+	randv2 "math/rand/v2"
 	"strings"
 	"sync"
 	"time"
@@ -14,12 +12,6 @@ import (
 	"github.com/oklog/ulid"
 )
 
-var (
-	entropyMu sync.Mutex
-	// Default entropy uses math/rand for performance. Use NewSecureGenerator() for crypto-secure randomness.
-	entropy = ulid.Monotonic(mathrand.New(mathrand.NewSource(time.Now().UnixNano())), 0) //nolint:gosec // G404: Intentional use of math/rand for performance; crypto/rand available via NewSecureGenerator()
-)
-
 // Core id generation
 type Generator interface {
 	Generate() string
@@ -74,62 +66,210 @@ type Provider interface {
 // generator ensures valid ids for records
 type generator struct {
 	entropySource io.Reader
+	// unwrapped is the source under the monotonic layer WithMonotonic added
+	// to entropySource, or nil when the generator did not add one
+	unwrapped io.Reader
+	// mu serializes reads from entropySource, or is nil when the source is
+	// safe for concurrent use. Copies made by With* methods share both.
+	mu     *sync.Mutex
+	format FormatProfile
+	// clock supplies the current time; nil means the system clock
+	clock Clock
+	// normalize rewrites input before it is parsed
+	normalize Pipeline
+	// scheme replaces ULIDs in the Provider methods; nil means ULIDs
+	scheme IDScheme
+	// counters tracks issued IDs and is shared by copies
+	counters *issueCounters
+	// prefix is set by WithPrefix for New to apply; the generator ignores it
+	prefix string
+	// stamps are written into the entropy of every ULID the generator issues
+	stamps []entropyStamp
+	// lastStamped is the last ULID issued with stamps, shared by copies with
+	// the same stamps; it keeps stamped IDs ordered with monotonic entropy
+	lastStamped *ulid.ULID
+	// selfTest is set by WithSelfTest for NewGenerator to run once every
+	// option is applied
+	selfTest bool
 }
 
-// NewGenerator creates a new generator with default entropy
-func NewGenerator() *generator {
-	return &generator{
-		entropySource: entropy,
+// NewGenerator creates a new generator with default entropy and the system
+// clock, then applies opts in order. Each generator owns its entropy source,
+// so separate generators never contend with each other. It panics if
+// WithSelfTest is given and the self-test fails; TryNewGenerator returns the
+// error instead.
+func NewGenerator(opts ...Option) *generator {
+	g, err := TryNewGenerator(opts...)
+	if err != nil {
+		panic("id: " + err.Error())
 	}
+	return g
 }
 
-// NewGeneratorWithEntropy creates a generator with custom entropy source
-func NewGeneratorWithEntropy(entropySource io.Reader) *generator {
-	return &generator{
-		entropySource: entropySource,
+// TryNewGenerator is NewGenerator returning the WithSelfTest failure, which
+// wraps ErrSelfTest, instead of panicking
+func TryNewGenerator(opts ...Option) (*generator, error) {
+	g := &generator{
+		entropySource: NewSourceReader(newDefaultSource()),
+		counters:      new(issueCounters),
+	}
+	WithMonotonic(true)(g)
+	for _, opt := range opts {
+		opt(g)
+	}
+	if g.selfTest {
+		if err := g.SelfTest(); err != nil {
+			return nil, err
+		}
 	}
+	return g, nil
 }
 
-// NewSecureGenerator creates a generator using crypto/rand for high-security scenarios
+// NewGeneratorWithEntropy creates a generator with custom entropy source.
+// The generator serializes its own reads, so a source that is not safe for
+// concurrent use must not be shared with other generators.
+func NewGeneratorWithEntropy(entropySource io.Reader) *generator {
+	return NewGenerator(WithEntropy(entropySource))
+}
+
+// NewSecureGenerator creates a generator using crypto/rand for high-security
+// scenarios. crypto/rand is safe for concurrent use, so generation takes no lock.
 func NewSecureGenerator() *generator {
-	return &generator{
-		entropySource: rand.Reader,
+	return NewGenerator(WithSecureEntropy())
+}
+
+// newDefaultSource creates a PCG seeded from the runtime's random state.
+// Default entropy uses math/rand/v2 for performance. Use NewSecureGenerator() for crypto-secure randomness.
+func newDefaultSource() randv2.Source {
+	return randv2.NewPCG(randv2.Uint64(), randv2.Uint64()) //nolint:gosec // G404: Intentional use of math/rand for performance; crypto/rand available via NewSecureGenerator()
+}
+
+// now returns the current time from the generator's clock
+func (g *generator) now() time.Time {
+	if g.clock == nil {
+		return time.Now()
 	}
+	return g.clock.Now()
+}
+
+// lock acquires the generator's entropy lock, if it has one
+func (g *generator) lock() {
+	if g.mu != nil {
+		g.mu.Lock()
+	}
+}
+
+// unlock releases the generator's entropy lock, if it has one
+func (g *generator) unlock() {
+	if g.mu != nil {
+		g.mu.Unlock()
+	}
+}
+
+// WithFormatProfile returns a copy of the generator that emits IDs rendered
+// under profile and accepts them back in every parsing method
+func (g *generator) WithFormatProfile(profile FormatProfile) *generator {
+	clone := *g
+	clone.format = profile
+	return &clone
 }
 
 // Basic Generation Methods
 
-// Generate provides a new globally unique URL safe id for a record
+// Generate provides a new globally unique URL safe id for a record. It
+// panics if the entropy source fails; TryGenerate returns the error instead.
 func (g *generator) Generate() string {
-	return g.GenerateWithTime(time.Now())
+	return g.GenerateWithTime(g.now())
 }
 
-// GenerateWithTime generates a ULID with a specific timestamp
+// GenerateWithTime generates a ULID with a specific timestamp. It panics if
+// t is before 1970 or beyond the 48-bit timestamp range, or if the entropy
+// source fails; TryGenerateWithTime returns the error instead.
 func (g *generator) GenerateWithTime(t time.Time) string {
-	entropyMu.Lock()
-	defer entropyMu.Unlock()
-	id := ulid.MustNew(ulid.Timestamp(t), g.entropySource)
-	return id.String()
+	if g.scheme != nil {
+		return g.schemeNew(t)
+	}
+	return g.encode(g.newULID(t))
 }
 
-// GenerateBatch creates multiple ULIDs efficiently
+// newULID creates a binary ULID for t from the generator's entropy source,
+// panicking like ulid.MustNew if the source fails
+func (g *generator) newULID(t time.Time) ulid.ULID {
+	u, err := g.tryNewULID(t)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+// tryNewULID creates a binary ULID for t, returning time and entropy failures
+func (g *generator) tryNewULID(t time.Time) (ulid.ULID, error) {
+	if err := checkULIDTime(t); err != nil {
+		return ulid.ULID{}, err
+	}
+
+	g.lock()
+	defer g.unlock()
+	return g.nextULID(ulid.Timestamp(t))
+}
+
+// nextULID draws a ULID for ms, applies the generator's stamps, and counts
+// it. Callers must hold the entropy lock.
+func (g *generator) nextULID(ms uint64) (ulid.ULID, error) {
+	u, err := ulid.New(ms, g.entropySource)
+	if err != nil {
+		return ulid.ULID{}, fmt.Errorf("reading entropy: %w", err)
+	}
+	if err := g.stamp(&u); err != nil {
+		return ulid.ULID{}, fmt.Errorf("reading entropy: %w", err)
+	}
+	g.counters.record(ms)
+	return u, nil
+}
+
+// tryGenerateWithTime is GenerateWithTime returning time and entropy failures
+func (g *generator) tryGenerateWithTime(t time.Time) (string, error) {
+	if g.scheme != nil {
+		return g.schemeTryNew(t)
+	}
+	u, err := g.tryNewULID(t)
+	if err != nil {
+		return "", err
+	}
+	return g.encode(u), nil
+}
+
+// GenerateBatch creates multiple ULIDs efficiently. It panics if the entropy
+// source fails; TryGenerateBatch returns the error instead.
 func (g *generator) GenerateBatch(count int) []string {
 	if count <= 0 {
 		return []string{}
 	}
 
 	result := make([]string, count)
-	entropyMu.Lock()
-	defer entropyMu.Unlock()
+	if g.scheme != nil {
+		for i := range result {
+			result[i] = g.schemeNew(g.now())
+		}
+		return result
+	}
 
-	for i := 0; i < count; i++ {
-		id := ulid.MustNew(ulid.Timestamp(time.Now()), g.entropySource)
-		result[i] = id.String()
+	g.lock()
+	defer g.unlock()
+
+	for i := range result {
+		u, err := g.nextULID(ulid.Timestamp(g.now()))
+		if err != nil {
+			panic(err)
+		}
+		result[i] = g.encode(u)
 	}
 	return result
 }
 
-// GenerateRange creates ULIDs within a time range
+// GenerateRange creates ULIDs within a time range. It panics if the range
+// leaves the ULID timestamp range or the entropy source fails;
+// TryGenerateRange returns the error instead.
 func (g *generator) GenerateRange(start, end time.Time, count int) []string {
 	if count <= 0 || end.Before(start) {
 		return []string{}
@@ -137,15 +277,24 @@ func (g *generator) GenerateRange(start, end time.Time, count int) []string {
 
 	result := make([]string, count)
 	duration := end.Sub(start)
-	entropyMu.Lock()
-	defer entropyMu.Unlock()
+	if g.scheme != nil {
+		for i := range result {
+			result[i] = g.schemeNew(start.Add(time.Duration(int64(duration) * int64(i) / int64(count))))
+		}
+		return result
+	}
+
+	g.lock()
+	defer g.unlock()
 
 	for i := 0; i < count; i++ {
 		// Distribute timestamps evenly across the range
 		offset := time.Duration(int64(duration) * int64(i) / int64(count))
-		timestamp := start.Add(offset)
-		id := ulid.MustNew(ulid.Timestamp(timestamp), g.entropySource)
-		result[i] = id.String()
+		u, err := g.nextULID(ulid.Timestamp(start.Add(offset)))
+		if err != nil {
+			panic(err)
+		}
+		result[i] = g.encode(u)
 	}
 	return result
 }
@@ -154,33 +303,77 @@ func (g *generator) GenerateRange(start, end time.Time, count int) []string {
 
 // IsIdValid validates that the provided id is a valid ULID
 func (g *generator) IsIdValid(s string) bool {
-	_, err := ulid.Parse(s)
+	if g.scheme != nil {
+		_, err := g.schemeDecode(s)
+		return err == nil
+	}
+	_, err := g.parse(s)
 	return err == nil
 }
 
 // ValidateAndNormalize checks and normalizes a ULID string
 func (g *generator) ValidateAndNormalize(id string) (string, error) {
 	if id == "" {
-		return "", errors.New("empty ULID string")
+		return "", ErrEmpty
+	}
+	if g.scheme != nil {
+		raw, err := g.schemeDecode(id)
+		if err != nil {
+			return "", err
+		}
+		return g.scheme.Encode(raw)
 	}
 
 	// Normalize case (ULIDs should be uppercase)
 	normalized := strings.ToUpper(id)
 
 	// Validate the normalized ULID
-	parsed, err := ulid.Parse(normalized)
+	parsed, err := g.parse(normalized)
 	if err != nil {
 		return "", fmt.Errorf("invalid ULID: %w", err)
 	}
 
-	return parsed.String(), nil
+	return g.encode(parsed), nil
+}
+
+// parse decodes an ID in the generator's format after running its
+// normalization pipeline. Canonical generators report typed errors from
+// parseCanonical; others accept any FormatProfile rendering.
+func (g *generator) parse(id string) (ulid.ULID, error) {
+	if len(g.normalize) > 0 {
+		normalized, err := g.normalize.Normalize(id)
+		if err != nil {
+			return ulid.ULID{}, err
+		}
+		id = normalized
+	}
+	if g.format.Canonical() {
+		return parseCanonical(id)
+	}
+	return parseFormatted(id)
+}
+
+// encode renders a binary ULID in the generator's format
+func (g *generator) encode(u ulid.ULID) string {
+	if g.format.Canonical() {
+		return u.String()
+	}
+	return g.format.format(u)
 }
 
 // Timestamp Operations
 
 // ExtractTimestamp returns the timestamp component of a ULID
 func (g *generator) ExtractTimestamp(id string) (time.Time, error) {
-	parsed, err := ulid.Parse(id)
+	if g.scheme != nil {
+		raw, err := g.schemeDecode(id)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return g.scheme.Time(raw), nil
+	}
+
+	parsed, err := g.parse(id)
 	if err != nil {
 		return time.Time{}, fmt.Errorf("invalid ULID: %w", err)
 	}
@@ -205,7 +398,7 @@ func (g *generator) Age(id string) (time.Duration, error) {
 		return 0, err
 	}
 
-	return time.Since(timestamp), nil
+	return g.now().Sub(timestamp), nil
 }
 
 // IsExpired checks if ULID is older than maxAge
@@ -222,12 +415,16 @@ func (g *generator) IsExpired(id string, maxAge time.Duration) (bool, error) {
 
 // Compare returns -1, 0, or 1 for chronological ordering
 func (g *generator) Compare(id1, id2 string) (int, error) {
-	ulid1, err := ulid.Parse(id1)
+	if g.scheme != nil {
+		return g.schemeCompare(id1, id2)
+	}
+
+	ulid1, err := g.parse(id1)
 	if err != nil {
 		return 0, fmt.Errorf("invalid first ULID: %w", err)
 	}
 
-	ulid2, err := ulid.Parse(id2)
+	ulid2, err := g.parse(id2)
 	if err != nil {
 		return 0, fmt.Errorf("invalid second ULID: %w", err)
 	}
@@ -255,9 +452,15 @@ func (g *generator) IsAfter(id1, id2 string) (bool, error) {
 
 // Format Conversions
 
-// ToBytes returns the binary representation of a ULID
+// ToBytes returns the binary representation of a ULID. IDs in a scheme
+// wider than 16 bytes, such as KSUIDs, return ErrTooWide; AppendBytes
+// handles every scheme.
 func (g *generator) ToBytes(id string) ([16]byte, error) {
-	parsed, err := ulid.Parse(id)
+	if g.scheme != nil {
+		return g.schemeToBytes(id)
+	}
+
+	parsed, err := g.parse(id)
 	if err != nil {
 		return [16]byte{}, fmt.Errorf("invalid ULID: %w", err)
 	}
@@ -270,9 +473,57 @@ func (g *generator) ToBytes(id string) ([16]byte, error) {
 
 // FromBytes creates ULID string from binary representation
 func (g *generator) FromBytes(data [16]byte) string {
+	if g.scheme != nil {
+		return g.schemeFromBytes(data)
+	}
+
 	var u ulid.ULID
 	copy(u[:], data[:])
-	return u.String()
+	return g.encode(u)
+}
+
+// ByteSize returns the length of the binary form of the generator's IDs:
+// 16 bytes for ULIDs, or the scheme's Size
+func (g *generator) ByteSize() int {
+	if g.scheme != nil {
+		return g.scheme.Size()
+	}
+	return ulidSize
+}
+
+// AppendBytes appends the binary form of id to dst at its full ByteSize,
+// including schemes wider than ToBytes can return, and returns the extended
+// slice. dst is returned unchanged on error.
+func (g *generator) AppendBytes(dst []byte, id string) ([]byte, error) {
+	if g.scheme != nil {
+		raw, err := g.schemeDecode(id)
+		if err != nil {
+			return dst, err
+		}
+		return append(dst, raw...), nil
+	}
+
+	parsed, err := g.parse(id)
+	if err != nil {
+		return dst, fmt.Errorf("invalid ULID: %w", err)
+	}
+	return append(dst, parsed[:]...), nil
+}
+
+// ParseBytes encodes a binary form written by AppendBytes, which must be
+// exactly ByteSize bytes
+func (g *generator) ParseBytes(raw []byte) (string, error) {
+	if size := g.ByteSize(); len(raw) != size {
+		return "", fmt.Errorf("%w: got %d bytes, want %d", ErrInvalidEncoding, len(raw), size)
+	}
+	if g.scheme != nil {
+		id, err := g.scheme.Encode(raw)
+		if err != nil {
+			return "", fmt.Errorf("invalid %s: %w", g.scheme.Name(), err)
+		}
+		return id, nil
+	}
+	return g.encode(ulid.ULID(raw)), nil
 }
 
 // ToUUID converts ULID to UUID format (for compatibility)
@@ -304,38 +555,40 @@ func AnalyzeIDs(ids []string) (Stats, error) {
 	if len(ids) == 0 {
 		return Stats{}, nil
 	}
+	parsed, errs := ParseBatch(ids)
+	return analyzeParsed(ids, parsed, errs)
+}
 
-	// Create a temporary generator for parsing
-	g := NewGenerator()
-
-	// Parse all timestamps
-	timestamps := make([]time.Time, 0, len(ids))
-	validIDs := make([]string, 0, len(ids))
-
-	for _, id := range ids {
-		if timestamp, err := g.ExtractTimestamp(id); err == nil {
-			timestamps = append(timestamps, timestamp)
-			validIDs = append(validIDs, id)
+// analyzeParsed computes Stats for ids from their parsed forms, skipping
+// those with errors
+func analyzeParsed(ids []string, parsed []ID, errs []error) (Stats, error) {
+	// Find the earliest and latest valid IDs in one pass
+	count, first, last := 0, -1, -1
+	for i, err := range errs {
+		if err != nil {
+			continue
+		}
+		count++
+		if first < 0 || parsed[i].Time() < parsed[first].Time() {
+			first = i
+		}
+		if last < 0 || parsed[i].Time() >= parsed[last].Time() {
+			last = i
 		}
 	}
 
-	if len(validIDs) == 0 {
+	if count == 0 {
 		return Stats{}, errors.New("no valid ULIDs found")
 	}
 
-	// Sort by timestamp to find first and last
-	sort.Slice(validIDs, func(i, j int) bool {
-		return timestamps[i].Before(timestamps[j])
-	})
-
-	firstTime := timestamps[0]
-	lastTime := timestamps[len(timestamps)-1]
+	firstTime := parsed[first].Timestamp()
+	lastTime := parsed[last].Timestamp()
 
 	return Stats{
-		Count:     len(validIDs),
+		Count:     count,
 		TimeSpan:  lastTime.Sub(firstTime),
-		FirstID:   validIDs[0],
-		LastID:    validIDs[len(validIDs)-1],
+		FirstID:   ids[first],
+		LastID:    ids[last],
 		FirstTime: firstTime,
 		LastTime:  lastTime,
 	}, nil
@@ -343,40 +596,42 @@ func AnalyzeIDs(ids []string) (Stats, error) {
 
 // FilterByTimeRange filters ULIDs within time bounds
 func FilterByTimeRange(ids []string, start, end time.Time) []string {
-	g := NewGenerator()
+	parsed, errs := ParseBatch(ids)
+	return filterParsed(ids, parsed, errs, start, end)
+}
+
+// filterParsed keeps the ids whose parsed timestamps fall within start and
+// end inclusive, skipping those with errors
+func filterParsed(ids []string, parsed []ID, errs []error, start, end time.Time) []string {
 	result := make([]string, 0, len(ids))
 
-	for _, id := range ids {
-		if timestamp, err := g.ExtractTimestamp(id); err == nil {
-			if (timestamp.Equal(start) || timestamp.After(start)) &&
-				(timestamp.Equal(end) || timestamp.Before(end)) {
-				result = append(result, id)
-			}
+	for i, id := range ids {
+		if errs[i] != nil {
+			continue
+		}
+		timestamp := parsed[i].Timestamp()
+		if (timestamp.Equal(start) || timestamp.After(start)) &&
+			(timestamp.Equal(end) || timestamp.Before(end)) {
+			result = append(result, id)
 		}
 	}
 
 	return result
 }
 
-// SortChronologically sorts ULIDs by their timestamp component
+// SortChronologically sorts ULIDs by their timestamp component. IDs that are
+// all valid and share a letter case are sorted as plain strings without
+// parsing; mixed-case or invalid input falls back to parsing each ID once.
+// Invalid IDs are placed last in their original order. SortChronologicallyBy
+// sorts by other orders.
 func SortChronologically(ids []string) []string {
 	if len(ids) <= 1 {
 		return ids
 	}
-
-	g := NewGenerator()
-	result := make([]string, len(ids))
-	copy(result, ids)
-
-	sort.Slice(result, func(i, j int) bool {
-		cmp, err := g.Compare(result[i], result[j])
-		if err != nil {
-			return false // Keep original order if comparison fails
-		}
-		return cmp < 0
-	})
-
-	return result
+	if sorted, ok := sortLexicographic(ids); ok {
+		return sorted
+	}
+	return SortChronologicallyBy(ids)
 }
 
 // SortChronologicallyReverse sorts ULIDs by timestamp in reverse order (newest first)
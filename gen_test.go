@@ -2,6 +2,7 @@ package id_test
 
 import (
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -334,3 +335,34 @@ func Test_NewSecureGenerator(t *testing.T) {
 	assert.Len(t, id, 26)
 	assert.True(t, secureGen.IsIdValid(id))
 }
+
+func Test_Generate_Concurrent(t *testing.T) {
+	shared := id.NewGenerator()
+	lower := shared.WithFormatProfile(id.LowercaseProfile)
+	secure := id.NewSecureGenerator()
+
+	const goroutines, perGoroutine = 8, 500
+	results := make([][]string, goroutines)
+	var wg sync.WaitGroup
+	for i := range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			own := id.NewGenerator()
+			for range perGoroutine {
+				results[i] = append(results[i], shared.Generate(), strings.ToUpper(lower.Generate()), secure.Generate(), own.Generate())
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	seen := make(map[string]bool, goroutines*perGoroutine*4)
+	for _, ids := range results {
+		for _, s := range ids {
+			assert.False(t, seen[s], "duplicate ID %s", s)
+			seen[s] = true
+		}
+	}
+	assert.Len(t, seen, goroutines*perGoroutine*4)
+}
@@ -0,0 +1,89 @@
+package id
+
+import (
+	"context"
+	"time"
+)
+
+// GenerateContext provides a new ID like Generate, but returns entropy
+// failures instead of panicking and gives up when ctx is done, so a request
+// fails fast rather than hanging on a starved entropy source such as
+// crypto/rand at early boot. A read that is already blocked keeps running in
+// the background until the source returns; its ID is discarded.
+func (g *generator) GenerateContext(ctx context.Context) (string, error) {
+	return awaitEntropy(ctx, func() (string, error) {
+		return g.tryGenerateWithTime(g.now())
+	})
+}
+
+// GenerateBatchContext creates count IDs like GenerateBatch, stopping at the
+// first entropy failure or when ctx is done. It returns no IDs on failure.
+func (g *generator) GenerateBatchContext(ctx context.Context, count int) ([]string, error) {
+	return g.generateEachContext(ctx, count, func(int) time.Time {
+		return g.now()
+	})
+}
+
+// GenerateRangeContext creates count IDs spread across a time range like
+// GenerateRange, stopping at the first entropy failure or when ctx is done.
+// It returns no IDs on failure.
+func (g *generator) GenerateRangeContext(ctx context.Context, start, end time.Time, count int) ([]string, error) {
+	if end.Before(start) {
+		return []string{}, ctx.Err()
+	}
+	duration := end.Sub(start)
+	return g.generateEachContext(ctx, count, func(i int) time.Time {
+		return start.Add(time.Duration(int64(duration) * int64(i) / int64(count)))
+	})
+}
+
+// generateEachContext issues count IDs at the times from at, checking ctx
+// between IDs so an abandoned batch stops drawing entropy
+func (g *generator) generateEachContext(ctx context.Context, count int, at func(int) time.Time) ([]string, error) {
+	if count <= 0 {
+		return []string{}, ctx.Err()
+	}
+	return awaitEntropy(ctx, func() ([]string, error) {
+		result := make([]string, count)
+		for i := range result {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			id, err := g.tryGenerateWithTime(at(i))
+			if err != nil {
+				return nil, err
+			}
+			result[i] = id
+		}
+		return result, nil
+	})
+}
+
+// awaitEntropy runs fn, returning early with ctx's error if ctx is done
+// first. Contexts that can never be done run fn inline.
+func awaitEntropy[T any](ctx context.Context, fn func() (T, error)) (T, error) {
+	var zero T
+	if err := ctx.Err(); err != nil {
+		return zero, err
+	}
+	if ctx.Done() == nil {
+		return fn()
+	}
+
+	type result struct {
+		value T
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		value, err := fn()
+		done <- result{value, err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.value, r.err
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+}
@@ -0,0 +1,89 @@
+package id_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingReader blocks every read until release is closed
+type blockingReader struct {
+	release chan struct{}
+}
+
+func (r blockingReader) Read(p []byte) (int, error) {
+	<-r.release
+	clear(p)
+	return len(p), nil
+}
+
+func Test_GenerateContext(t *testing.T) {
+	gen := id.NewSecureGenerator()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// Act
+	single, err := gen.GenerateContext(ctx)
+	require.NoError(t, err)
+	batch, err := gen.GenerateBatchContext(ctx, 5)
+	require.NoError(t, err)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ranged, err := gen.GenerateRangeContext(context.Background(), start, start.Add(time.Hour), 4)
+	require.NoError(t, err)
+
+	// Assert
+	assert.True(t, gen.IsIdValid(single))
+	assert.Len(t, batch, 5)
+	require.Len(t, ranged, 4)
+	assert.Equal(t, ranged, id.SortChronologically(ranged))
+	last, err := gen.ExtractTimestamp(ranged[3])
+	require.NoError(t, err)
+	assert.True(t, last.Equal(start.Add(45*time.Minute)))
+}
+
+func Test_GenerateContext_Deadline(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	gen := id.NewGeneratorWithEntropy(blockingReader{release: release})
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	// Act
+	_, err := gen.GenerateContext(ctx)
+	_, batchErr := gen.GenerateBatchContext(ctx, 3)
+
+	// Assert
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.ErrorIs(t, batchErr, context.DeadlineExceeded)
+}
+
+func Test_GenerateContext_EntropyFailure(t *testing.T) {
+	gen := id.NewGeneratorWithEntropy(failingReader{})
+
+	// Act
+	_, err := gen.GenerateContext(context.Background())
+	batch, batchErr := gen.GenerateBatchContext(context.Background(), 3)
+
+	// Assert
+	require.ErrorContains(t, err, "hardware RNG exhausted")
+	require.ErrorContains(t, batchErr, "hardware RNG exhausted")
+	assert.Nil(t, batch)
+}
+
+func Test_GenerateContext_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	_, err := id.NewGenerator().GenerateContext(ctx)
+	empty, emptyErr := id.NewGenerator().GenerateBatchContext(ctx, 0)
+
+	// Assert
+	require.ErrorIs(t, err, context.Canceled)
+	require.ErrorIs(t, emptyErr, context.Canceled)
+	assert.Empty(t, empty)
+}
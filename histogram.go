@@ -0,0 +1,144 @@
+package id
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MaxBuckets is the most buckets HistogramOf and BucketByInterval allocate.
+// A single stray timestamp can stretch the span across decades, so wider
+// spans fail with ErrTooManyBuckets rather than exhausting memory.
+const MaxBuckets = 1 << 20
+
+// ErrTooManyBuckets is returned when covering the IDs' time span at the
+// requested interval would take more than MaxBuckets buckets
+var ErrTooManyBuckets = errors.New("too many buckets")
+
+// Histogram counts IDs in consecutive fixed-width time buckets
+type Histogram struct {
+	// Start is the beginning of the first bucket, aligned to Interval
+	Start time.Time
+	// Interval is the width of every bucket
+	Interval time.Duration
+	// Counts holds the number of IDs in each bucket, including empty ones
+	Counts []int
+}
+
+// BucketStart returns the beginning of bucket i
+func (h Histogram) BucketStart(i int) time.Time {
+	return h.Start.Add(time.Duration(i) * h.Interval)
+}
+
+// HistogramOf counts valid IDs per interval from the earliest bucket to the
+// latest. Invalid IDs are ignored. Every bucket in between is present, so
+// choose an interval suited to the collection's time span; spans needing
+// more than MaxBuckets return ErrTooManyBuckets.
+func HistogramOf(ids []string, interval time.Duration) (Histogram, error) {
+	h := Histogram{Interval: interval}
+	if interval <= 0 {
+		return h, nil
+	}
+
+	parsed, errs := ParseBatch(ids)
+	start, n, err := bucketSpan(parsed, errs, interval)
+	if err != nil || n == 0 {
+		return h, err
+	}
+
+	h.Start = start
+	h.Counts = make([]int, n)
+	for i, err := range errs {
+		if err == nil {
+			h.Counts[int(parsed[i].Timestamp().Sub(start)/interval)]++
+		}
+	}
+	return h, nil
+}
+
+// Bucket holds the IDs whose timestamps fall in [Start, End)
+type Bucket struct {
+	Start time.Time
+	End   time.Time
+	// IDs are the bucket's IDs in input order
+	IDs []string
+}
+
+// Count returns the number of IDs in the bucket
+func (b Bucket) Count() int {
+	return len(b.IDs)
+}
+
+// Rate returns the bucket's IDs per second over its full width
+func (b Bucket) Rate() float64 {
+	width := b.End.Sub(b.Start)
+	if width <= 0 {
+		return 0
+	}
+	return float64(len(b.IDs)) / width.Seconds()
+}
+
+// BucketByInterval groups valid IDs into buckets of width interval, aligned
+// like HistogramOf, from the earliest bucket to the latest. Empty buckets are
+// included so gaps in a backlog show up, up to MaxBuckets in all. Invalid IDs
+// are ignored.
+func BucketByInterval(ids []string, interval time.Duration) ([]Bucket, error) {
+	if interval <= 0 {
+		return []Bucket{}, nil
+	}
+
+	parsed, errs := ParseBatch(ids)
+	start, n, err := bucketSpan(parsed, errs, interval)
+	if err != nil {
+		return nil, err
+	}
+	buckets := make([]Bucket, n)
+	for i := range buckets {
+		buckets[i].Start = start.Add(time.Duration(i) * interval)
+		buckets[i].End = buckets[i].Start.Add(interval)
+	}
+	for i, err := range errs {
+		if err == nil {
+			b := &buckets[int(parsed[i].Timestamp().Sub(start)/interval)]
+			b.IDs = append(b.IDs, ids[i])
+		}
+	}
+	return buckets, nil
+}
+
+// bucketSpan returns the UTC-aligned start of the first bucket and the
+// number of buckets needed to cover the valid entries, or 0 if there are none
+func bucketSpan(parsed []ID, errs []error, interval time.Duration) (time.Time, int, error) {
+	var first, last time.Time
+	for i, err := range errs {
+		if err != nil {
+			continue
+		}
+		ts := parsed[i].Timestamp()
+		if first.IsZero() || ts.Before(first) {
+			first = ts
+		}
+		if last.IsZero() || ts.After(last) {
+			last = ts
+		}
+	}
+	if first.IsZero() {
+		return time.Time{}, 0, nil
+	}
+	return bucketRange(first, last, interval)
+}
+
+// bucketRange returns the UTC-aligned start of the bucket holding first and
+// the number of buckets through the one holding last, failing if that is
+// more than MaxBuckets or the span is too long for a Duration, where
+// time.Time.Sub saturates
+func bucketRange(first, last time.Time, interval time.Duration) (time.Time, int, error) {
+	start := first.UTC().Truncate(interval)
+	span := last.Sub(start)
+	n := span / interval
+	if n >= MaxBuckets || !start.Add(span).Equal(last) {
+		return time.Time{}, 0, fmt.Errorf("%w: %s to %s in %s buckets exceeds %d",
+			ErrTooManyBuckets, start.Format(time.RFC3339), last.UTC().Format(time.RFC3339), interval, MaxBuckets)
+	}
+	return start, int(n) + 1, nil
+}
@@ -0,0 +1,95 @@
+package id_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_HistogramOf(t *testing.T) {
+	gen := id.NewGenerator()
+	base := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	ids := []string{
+		gen.GenerateWithTime(base.Add(5 * time.Minute)),
+		gen.GenerateWithTime(base.Add(10 * time.Minute)),
+		"invalid",
+		gen.GenerateWithTime(base.Add(3*time.Hour + time.Minute)),
+	}
+
+	// Act
+	h, err := id.HistogramOf(ids, time.Hour)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, h.Start.Equal(base))
+	assert.Equal(t, []int{2, 0, 0, 1}, h.Counts)
+	assert.True(t, h.BucketStart(3).Equal(base.Add(3*time.Hour)))
+}
+
+func Test_HistogramOf_Empty(t *testing.T) {
+	for _, ids := range [][]string{nil, {"invalid"}} {
+		h, err := id.HistogramOf(ids, time.Hour)
+		require.NoError(t, err)
+		assert.Empty(t, h.Counts)
+	}
+	h, err := id.HistogramOf([]string{id.NewGenerator().Generate()}, 0)
+	require.NoError(t, err)
+	assert.Empty(t, h.Counts)
+}
+
+func Test_HistogramOf_TooManyBuckets(t *testing.T) {
+	gen := id.NewGenerator()
+	ids := []string{gen.GenerateWithTime(time.UnixMilli(0)), gen.Generate()}
+	snap := id.NewSnapshot(ids)
+
+	// Act
+	_, histErr := id.HistogramOf(ids, time.Second)
+	_, bucketErr := id.BucketByInterval(ids, time.Second)
+	_, snapErr := snap.Histogram(time.Second)
+
+	// Assert
+	assert.ErrorIs(t, histErr, id.ErrTooManyBuckets)
+	assert.ErrorIs(t, bucketErr, id.ErrTooManyBuckets)
+	assert.ErrorIs(t, snapErr, id.ErrTooManyBuckets)
+
+	far := []string{gen.GenerateWithTime(time.UnixMilli(0)), gen.GenerateWithTime(time.UnixMilli(1<<48 - 1))}
+	_, err := id.HistogramOf(far, 24*365*time.Hour)
+	assert.ErrorIs(t, err, id.ErrTooManyBuckets, "spans beyond a Duration cannot be bucketed")
+}
+
+func Test_BucketByInterval(t *testing.T) {
+	gen := id.NewGenerator()
+	base := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	early := gen.GenerateWithTime(base.Add(5 * time.Minute))
+	later := gen.GenerateWithTime(base.Add(10 * time.Minute))
+	last := gen.GenerateWithTime(base.Add(2*time.Hour + time.Minute))
+
+	// Act
+	buckets, err := id.BucketByInterval([]string{later, "invalid", last, early}, time.Hour)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, buckets, 3)
+	assert.True(t, buckets[0].Start.Equal(base))
+	assert.True(t, buckets[0].End.Equal(base.Add(time.Hour)))
+	assert.Equal(t, []string{later, early}, buckets[0].IDs)
+	assert.Zero(t, buckets[1].Count())
+	assert.Equal(t, []string{last}, buckets[2].IDs)
+	assert.InDelta(t, 2.0/3600, buckets[0].Rate(), 1e-12)
+	assert.Zero(t, buckets[1].Rate())
+}
+
+func Test_BucketByInterval_Empty(t *testing.T) {
+	for _, ids := range [][]string{nil, {"invalid"}} {
+		buckets, err := id.BucketByInterval(ids, time.Hour)
+		require.NoError(t, err)
+		assert.Empty(t, buckets)
+	}
+	buckets, err := id.BucketByInterval([]string{id.NewGenerator().Generate()}, 0)
+	require.NoError(t, err)
+	assert.Empty(t, buckets)
+	assert.Zero(t, id.Bucket{}.Rate())
+}
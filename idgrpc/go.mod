@@ -0,0 +1,23 @@
+module github.com/bold-minds/id/idgrpc
+
+go 1.24.0
+
+require (
+	github.com/bold-minds/id v0.0.0
+	github.com/stretchr/testify v1.11.1
+	google.golang.org/grpc v1.78.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/oklog/ulid v1.3.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/net v0.47.0 // indirect
+	golang.org/x/sys v0.38.0 // indirect
+	golang.org/x/text v0.31.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20251029180050-ab9386a59fda // indirect
+	google.golang.org/protobuf v1.36.10 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/bold-minds/id => ../
@@ -0,0 +1,83 @@
+package idgrpc
+
+import (
+	"context"
+
+	"github.com/bold-minds/id"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// UnaryServerInterceptor assigns every unary call a request ID. A valid
+// inbound ID is kept; otherwise gen issues a new one. The ID is stored in
+// the handler's context and sent in the response header. A nil gen uses
+// id.NewGenerator().
+func UnaryServerInterceptor(gen id.Generator) grpc.UnaryServerInterceptor {
+	if gen == nil {
+		gen = id.NewGenerator()
+	}
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, requestID := incomingContext(ctx, gen)
+		// SetHeader only fails once headers are sent, which the handler has not done yet
+		_ = grpc.SetHeader(ctx, metadata.Pairs(MetadataKey, requestID))
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor is UnaryServerInterceptor for streaming calls
+func StreamServerInterceptor(gen id.Generator) grpc.StreamServerInterceptor {
+	if gen == nil {
+		gen = id.NewGenerator()
+	}
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, requestID := incomingContext(ss.Context(), gen)
+		// SetHeader only fails once headers are sent, which the handler has not done yet
+		_ = ss.SetHeader(metadata.Pairs(MetadataKey, requestID))
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// UnaryClientInterceptor sends the request ID held by the call's context,
+// replacing any already in its outgoing metadata. Calls whose context has
+// no ID are sent unchanged.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoingContext(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor is UnaryClientInterceptor for streaming calls
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoingContext(ctx), desc, cc, method, opts...)
+	}
+}
+
+// incomingContext applies Incoming to the metadata received with ctx
+func incomingContext(ctx context.Context, gen id.Generator) (context.Context, string) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = Incoming(ctx, md, gen)
+	requestID, _ := id.FromContext(ctx)
+	return ctx, requestID
+}
+
+// outgoingContext applies Outgoing to a copy of the metadata sent with ctx
+func outgoingContext(ctx context.Context) context.Context {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+	if !Outgoing(ctx, md) {
+		return ctx
+	}
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// serverStream overrides the context a stream handler sees
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context carrying the request ID
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,196 @@
+package idgrpc_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/bold-minds/id"
+	"github.com/bold-minds/id/idgrpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// recorder captures the request ID each server handler sees and the
+// metadata it received
+type recorder struct {
+	ids      chan string
+	incoming chan metadata.MD
+}
+
+func (r *recorder) record(ctx context.Context) {
+	requestID, _ := id.FromContext(ctx)
+	md, _ := metadata.FromIncomingContext(ctx)
+	r.ids <- requestID
+	r.incoming <- md
+}
+
+// dial starts a health server with the idgrpc interceptors, followed by the
+// recorder, over an in-memory listener, and returns a client connected
+// through the idgrpc client interceptors
+func dial(t *testing.T) (healthpb.HealthClient, *recorder) {
+	t.Helper()
+	rec := &recorder{ids: make(chan string, 1), incoming: make(chan metadata.MD, 1)}
+	gen := id.NewGenerator()
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			idgrpc.UnaryServerInterceptor(gen),
+			func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+				rec.record(ctx)
+				return handler(ctx, req)
+			},
+		),
+		grpc.ChainStreamInterceptor(
+			idgrpc.StreamServerInterceptor(gen),
+			func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+				rec.record(ss.Context())
+				return handler(srv, ss)
+			},
+		),
+	)
+	healthpb.RegisterHealthServer(server, health.NewServer())
+
+	lis := bufconn.Listen(1 << 20)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(idgrpc.UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(idgrpc.StreamClientInterceptor()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return healthpb.NewHealthClient(conn), rec
+}
+
+func Test_Unary_PropagatesID(t *testing.T) {
+	client, rec := dial(t)
+	requestID := id.NewGenerator().Generate()
+	ctx := id.NewContext(context.Background(), requestID)
+	ctx = metadata.AppendToOutgoingContext(ctx, idgrpc.MetadataKey, "stale", "other", "kept")
+
+	// Act
+	var header metadata.MD
+	_, err := client.Check(ctx, &healthpb.HealthCheckRequest{}, grpc.Header(&header))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, requestID, <-rec.ids)
+	md := <-rec.incoming
+	assert.Equal(t, []string{requestID}, md.Get(idgrpc.MetadataKey))
+	assert.Equal(t, []string{"kept"}, md.Get("other"))
+	assert.Equal(t, []string{requestID}, header.Get(idgrpc.MetadataKey))
+}
+
+func Test_Unary_GeneratesID(t *testing.T) {
+	client, rec := dial(t)
+
+	// Act
+	var header metadata.MD
+	_, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{}, grpc.Header(&header))
+
+	// Assert
+	require.NoError(t, err)
+	requestID := <-rec.ids
+	assert.True(t, id.NewGenerator().IsIdValid(requestID))
+	assert.Empty(t, (<-rec.incoming).Get(idgrpc.MetadataKey))
+	assert.Equal(t, []string{requestID}, header.Get(idgrpc.MetadataKey))
+}
+
+func Test_Unary_ReplacesInvalidID(t *testing.T) {
+	client, rec := dial(t)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), idgrpc.MetadataKey, "invalid")
+
+	// Act
+	_, err := client.Check(ctx, &healthpb.HealthCheckRequest{})
+
+	// Assert
+	require.NoError(t, err)
+	requestID := <-rec.ids
+	<-rec.incoming
+	assert.NotEqual(t, "invalid", requestID)
+	assert.True(t, id.NewGenerator().IsIdValid(requestID))
+}
+
+func Test_Stream_PropagatesID(t *testing.T) {
+	client, rec := dial(t)
+	requestID := id.NewGenerator().Generate()
+	ctx, cancel := context.WithCancel(id.NewContext(context.Background(), requestID))
+	defer cancel()
+
+	// Act
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.NoError(t, err)
+	header, err := stream.Header()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, requestID, <-rec.ids)
+	assert.Equal(t, []string{requestID}, (<-rec.incoming).Get(idgrpc.MetadataKey))
+	assert.Equal(t, []string{requestID}, header.Get(idgrpc.MetadataKey))
+}
+
+func Test_Stream_GeneratesID(t *testing.T) {
+	client, rec := dial(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Act
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.NoError(t, err)
+	header, err := stream.Header()
+
+	// Assert
+	require.NoError(t, err)
+	requestID := <-rec.ids
+	<-rec.incoming
+	assert.True(t, id.NewGenerator().IsIdValid(requestID))
+	assert.Equal(t, []string{requestID}, header.Get(idgrpc.MetadataKey))
+}
+
+func Test_UnaryClientInterceptor_LeavesContextWithoutID(t *testing.T) {
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs("other", "value"))
+	var sent context.Context
+	invoker := func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		sent = ctx
+		return nil
+	}
+
+	// Act
+	err := idgrpc.UnaryClientInterceptor()(ctx, "/svc/Method", nil, nil, nil, invoker)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, ctx, sent)
+}
+
+func Test_UnaryClientInterceptor_DoesNotMutateCallerMetadata(t *testing.T) {
+	md := metadata.Pairs(idgrpc.MetadataKey, "stale")
+	requestID := id.NewGenerator().Generate()
+	ctx := metadata.NewOutgoingContext(id.NewContext(context.Background(), requestID), md)
+	var sent metadata.MD
+	invoker := func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		sent, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	}
+
+	// Act
+	err := idgrpc.UnaryClientInterceptor()(ctx, "/svc/Method", nil, nil, nil, invoker)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{requestID}, sent.Get(idgrpc.MetadataKey))
+	assert.Equal(t, []string{"stale"}, md.Get(idgrpc.MetadataKey))
+}
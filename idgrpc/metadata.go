@@ -0,0 +1,54 @@
+// Package idgrpc propagates request IDs through gRPC metadata, sharing the
+// context helpers used by idhttp so an ID flows across both protocols.
+//
+// Server interceptors keep a valid inbound ID or issue a new one, store it
+// in the handler's context for id.FromContext, and echo it in the response
+// header. Client interceptors forward the ID held by the call's context.
+//
+//	server := grpc.NewServer(
+//		grpc.ChainUnaryInterceptor(idgrpc.UnaryServerInterceptor(gen)),
+//		grpc.ChainStreamInterceptor(idgrpc.StreamServerInterceptor(gen)),
+//	)
+//	conn, err := grpc.NewClient(target,
+//		grpc.WithChainUnaryInterceptor(idgrpc.UnaryClientInterceptor()),
+//		grpc.WithChainStreamInterceptor(idgrpc.StreamClientInterceptor()),
+//	)
+//
+// It is a separate module so the core package stays free of the gRPC
+// dependency.
+package idgrpc
+
+import (
+	"context"
+
+	"github.com/bold-minds/id"
+)
+
+// MetadataKey carries the request ID in gRPC metadata. gRPC lowercases keys,
+// so it matches the X-Request-ID header idhttp uses.
+const MetadataKey = "x-request-id"
+
+// Incoming returns a copy of ctx carrying the request ID from inbound
+// metadata md. The first value gen accepts is kept; otherwise gen issues a
+// new one. A nil gen uses id.NewGenerator().
+func Incoming(ctx context.Context, md map[string][]string, gen id.Generator) context.Context {
+	if gen == nil {
+		gen = id.NewGenerator()
+	}
+	for _, value := range md[MetadataKey] {
+		if gen.IsIdValid(value) {
+			return id.NewContext(ctx, value)
+		}
+	}
+	return id.NewContext(ctx, gen.Generate())
+}
+
+// Outgoing sets the request ID stored in ctx on outbound metadata md,
+// replacing any value already there. It reports whether ctx held an ID.
+func Outgoing(ctx context.Context, md map[string][]string) bool {
+	requestID, ok := id.FromContext(ctx)
+	if ok {
+		md[MetadataKey] = []string{requestID}
+	}
+	return ok
+}
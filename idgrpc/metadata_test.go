@@ -0,0 +1,48 @@
+package idgrpc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bold-minds/id"
+	"github.com/bold-minds/id/idgrpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Incoming_KeepsValid(t *testing.T) {
+	incoming := id.NewGenerator().Generate()
+	md := map[string][]string{idgrpc.MetadataKey: {"invalid", incoming}}
+
+	// Act
+	ctx := idgrpc.Incoming(context.Background(), md, id.NewGenerator())
+
+	// Assert
+	requestID, ok := id.FromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, incoming, requestID)
+}
+
+func Test_Incoming_Generates(t *testing.T) {
+	md := map[string][]string{idgrpc.MetadataKey: {"invalid"}}
+
+	// Act
+	ctx := idgrpc.Incoming(context.Background(), md, nil)
+
+	// Assert
+	requestID, ok := id.FromContext(ctx)
+	assert.True(t, ok)
+	assert.True(t, id.NewGenerator().IsIdValid(requestID))
+}
+
+func Test_Outgoing(t *testing.T) {
+	requestID := id.NewGenerator().Generate()
+	md := map[string][]string{idgrpc.MetadataKey: {"stale"}}
+
+	// Act
+	ok := idgrpc.Outgoing(id.NewContext(context.Background(), requestID), md)
+
+	// Assert
+	assert.True(t, ok)
+	assert.Equal(t, []string{requestID}, md[idgrpc.MetadataKey])
+	assert.False(t, idgrpc.Outgoing(context.Background(), map[string][]string{}))
+}
@@ -0,0 +1,79 @@
+// Package idhttp provides net/http integrations for the id package
+package idhttp
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/bold-minds/id"
+)
+
+// InspectParam is the query parameter InspectHandler reads the ID from
+const InspectParam = "id"
+
+// inspectResponse is the JSON body served by InspectHandler
+type inspectResponse struct {
+	id.Info
+	Error string `json:"error,omitempty"`
+}
+
+var inspectPage = template.Must(template.New("inspect").Parse(`<!DOCTYPE html>
+<html>
+<head><title>ID inspector</title></head>
+<body>
+<form method="get"><input name="id" size="40" value="{{.Input}}"> <button>Inspect</button></form>
+{{if .Error}}<p><strong>Invalid:</strong> {{.Error}}</p>{{end}}
+{{if .ID}}<table>
+<tr><th align="left">ID</th><td><code>{{.ID}}</code></td></tr>
+<tr><th align="left">Timestamp</th><td>{{.Timestamp}}</td></tr>
+<tr><th align="left">Age</th><td>{{.Age}}</td></tr>
+<tr><th align="left">UUID</th><td><code>{{.UUID}}</code></td></tr>
+<tr><th align="left">Hex</th><td><code>{{.Hex}}</code></td></tr>
+</table>
+<table>
+<tr><th>Pos</th><th>Char</th><th>Value</th><th>Bits</th><th>Part</th></tr>
+{{range .Segments}}<tr><td>{{.Position}}</td><td><code>{{.Char}}</code></td><td>{{.Value}}</td><td>{{.StartBit}}-{{.EndBit}}</td><td>{{.Part}}</td></tr>
+{{end}}</table>{{end}}
+{{if .Notes}}<ul>{{range .Notes}}<li>{{.}}</li>{{end}}</ul>{{end}}
+</body>
+</html>
+`))
+
+// InspectHandler serves a decomposition of the ID passed in the "id" query
+// parameter. It responds with JSON unless the request asks for HTML via
+// ?format=html or an Accept header preferring text/html. Mount it on an
+// internal-only route such as /debug/id.
+func InspectHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input := r.URL.Query().Get(InspectParam)
+		info, err := id.Inspect(input)
+		resp := inspectResponse{Info: info}
+		status := http.StatusOK
+		if err != nil {
+			resp.Error = err.Error()
+			status = http.StatusBadRequest
+		}
+
+		if wantsHTML(r) {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.WriteHeader(status)
+			_ = inspectPage.Execute(w, resp)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+}
+
+// wantsHTML reports whether the client asked for the human-readable view
+func wantsHTML(r *http.Request) bool {
+	if format := r.URL.Query().Get("format"); format != "" {
+		return format == "html"
+	}
+	accept := r.Header.Get("Accept")
+	return strings.Contains(accept, "text/html")
+}
@@ -0,0 +1,58 @@
+package idhttp_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bold-minds/id"
+	"github.com/bold-minds/id/idhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_InspectHandler_JSON(t *testing.T) {
+	ulid := id.NewGenerator().Generate()
+	req := httptest.NewRequest(http.MethodGet, "/debug/id?id="+ulid, nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	idhttp.InspectHandler().ServeHTTP(rec, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, ulid, body["id"])
+	assert.NotContains(t, body, "error")
+}
+
+func Test_InspectHandler_HTML(t *testing.T) {
+	ulid := id.NewGenerator().Generate()
+	req := httptest.NewRequest(http.MethodGet, "/debug/id?id="+ulid, nil)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+	rec := httptest.NewRecorder()
+
+	// Act
+	idhttp.InspectHandler().ServeHTTP(rec, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get("Content-Type"), "text/html")
+	assert.Contains(t, rec.Body.String(), ulid)
+}
+
+func Test_InspectHandler_Invalid(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/debug/id?format=html&id=%3Cscript%3E", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	idhttp.InspectHandler().ServeHTTP(rec, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "<script>")
+	assert.Contains(t, rec.Body.String(), "Invalid")
+}
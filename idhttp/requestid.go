@@ -0,0 +1,32 @@
+package idhttp
+
+import (
+	"net/http"
+
+	"github.com/bold-minds/id"
+)
+
+// RequestIDHeader carries the request ID on requests and responses
+const RequestIDHeader = "X-Request-ID"
+
+// RequestID returns middleware that assigns every request an ID. An incoming
+// X-Request-ID header is kept when gen accepts it, so IDs propagate across
+// services; otherwise gen issues a new one. The ID is stored in the request
+// context, where handlers read it with id.FromContext, and echoed in the
+// response header. A nil gen uses id.NewGenerator().
+func RequestID(gen id.Generator) func(http.Handler) http.Handler {
+	if gen == nil {
+		gen = id.NewGenerator()
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestID := r.Header.Get(RequestIDHeader)
+			if !gen.IsIdValid(requestID) {
+				requestID = gen.Generate()
+			}
+
+			w.Header().Set(RequestIDHeader, requestID)
+			next.ServeHTTP(w, r.WithContext(id.NewContext(r.Context(), requestID)))
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package idhttp_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bold-minds/id"
+	"github.com/bold-minds/id/idhttp"
+	"github.com/stretchr/testify/assert"
+)
+
+// echoRequestID records the request ID handlers see in the context
+func echoRequestID(seen *string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*seen, _ = id.FromContext(r.Context())
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func Test_RequestID_Generates(t *testing.T) {
+	var seen string
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	idhttp.RequestID(nil)(echoRequestID(&seen)).ServeHTTP(rec, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.True(t, id.NewGenerator().IsIdValid(seen))
+	assert.Equal(t, seen, rec.Header().Get(idhttp.RequestIDHeader))
+}
+
+func Test_RequestID_KeepsValidIncoming(t *testing.T) {
+	var seen string
+	incoming := id.NewGenerator().Generate()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(idhttp.RequestIDHeader, incoming)
+	rec := httptest.NewRecorder()
+
+	// Act
+	idhttp.RequestID(id.NewGenerator())(echoRequestID(&seen)).ServeHTTP(rec, req)
+
+	// Assert
+	assert.Equal(t, incoming, seen)
+	assert.Equal(t, incoming, rec.Header().Get(idhttp.RequestIDHeader))
+}
+
+func Test_RequestID_ReplacesInvalidIncoming(t *testing.T) {
+	var seen string
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(idhttp.RequestIDHeader, "<script>")
+	rec := httptest.NewRecorder()
+
+	// Act
+	idhttp.RequestID(nil)(echoRequestID(&seen)).ServeHTTP(rec, req)
+
+	// Assert
+	assert.NotEqual(t, "<script>", seen)
+	assert.True(t, id.NewGenerator().IsIdValid(seen))
+	assert.Equal(t, seen, rec.Header().Get(idhttp.RequestIDHeader))
+}
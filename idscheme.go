@@ -0,0 +1,158 @@
+package id
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/oklog/ulid"
+)
+
+// ErrTooWide is returned when an ID's binary form does not fit in the 16
+// bytes ToBytes returns; AppendBytes handles wider schemes
+var ErrTooWide = errors.New("ID does not fit in 16 bytes")
+
+// ErrSchemeUnsupported is returned by features that only work on ULIDs when
+// they are used with a generator issuing another scheme
+var ErrSchemeUnsupported = errors.New("not supported by the generator's ID scheme")
+
+// SchemeName names an ID encoding a generator can issue. It is distinct from
+// Scheme, which names the formats a SchemePolicy accepts.
+type SchemeName string
+
+// SchemeNameULID names the default ULID encoding
+const SchemeNameULID SchemeName = "ulid"
+
+// IDScheme is a time-ordered ID encoding a generator can issue and parse in
+// place of ULIDs
+type IDScheme interface {
+	// Name identifies the scheme
+	Name() SchemeName
+	// Size is the length of the scheme's binary form in bytes
+	Size() int
+	// New encodes a new ID for t, drawing any randomness from entropy
+	New(t time.Time, entropy io.Reader) (string, error)
+	// Decode validates id and returns its binary form, which sorts in the
+	// same order as the IDs
+	Decode(id string) ([]byte, error)
+	// Encode renders a binary form in the scheme's canonical text
+	Encode(raw []byte) (string, error)
+	// Time returns the timestamp embedded in a binary form
+	Time(raw []byte) time.Time
+}
+
+// WithScheme makes the generator issue and parse IDs in a built-in scheme:
+// SchemeKSUID, or SchemeSnowflake with the Twitter epoch and node 0. Any
+// other name, including SchemeNameULID, selects ULIDs.
+//
+// Only the Provider methods honor the scheme. Methods built on the ID type,
+// such as GenerateID, Stream, and the UUIDv7 helpers, still work in ULIDs,
+// and format profiles and normalization pipelines are not applied.
+func WithScheme(name SchemeName) Option {
+	return func(g *generator) {
+		switch name {
+		case SchemeKSUID:
+			g.scheme = NewKSUIDScheme()
+		case SchemeSnowflake:
+			g.scheme, _ = NewSnowflakeScheme(TwitterEpoch, 0)
+		default:
+			g.scheme = nil
+		}
+	}
+}
+
+// WithIDScheme makes the generator issue and parse IDs in scheme, such as a
+// Snowflake scheme with a custom epoch and node. A nil scheme selects ULIDs.
+func WithIDScheme(scheme IDScheme) Option {
+	return func(g *generator) {
+		g.scheme = scheme
+	}
+}
+
+// schemeNew issues an ID in the generator's scheme, panicking like
+// ulid.MustNew if the scheme cannot encode t or entropy fails
+func (g *generator) schemeNew(t time.Time) string {
+	id, err := g.schemeTryNew(t)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// schemeTryNew issues an ID in the generator's scheme, returning failures
+func (g *generator) schemeTryNew(t time.Time) (string, error) {
+	g.lock()
+	defer g.unlock()
+
+	id, err := g.scheme.New(t, g.entropySource)
+	if err != nil {
+		return "", err
+	}
+	g.counters.record(ulid.Timestamp(t))
+	return id, nil
+}
+
+// requireULID fails with ErrSchemeUnsupported when the generator issues IDs
+// in a scheme other than ULID
+func (g *generator) requireULID(feature string) error {
+	if g.scheme != nil {
+		return fmt.Errorf("%s: %w %s", feature, ErrSchemeUnsupported, g.scheme.Name())
+	}
+	return nil
+}
+
+// schemeDecode parses id in the generator's scheme
+func (g *generator) schemeDecode(id string) ([]byte, error) {
+	if id == "" {
+		return nil, ErrEmpty
+	}
+	raw, err := g.scheme.Decode(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", g.scheme.Name(), err)
+	}
+	return raw, nil
+}
+
+// schemeCompare orders two IDs in the generator's scheme
+func (g *generator) schemeCompare(id1, id2 string) (int, error) {
+	raw1, err := g.schemeDecode(id1)
+	if err != nil {
+		return 0, fmt.Errorf("first ID: %w", err)
+	}
+	raw2, err := g.schemeDecode(id2)
+	if err != nil {
+		return 0, fmt.Errorf("second ID: %w", err)
+	}
+	return bytes.Compare(raw1, raw2), nil
+}
+
+// schemeToBytes right-aligns an ID's binary form in 16 bytes, which keeps
+// byte order intact
+func (g *generator) schemeToBytes(id string) ([16]byte, error) {
+	var result [16]byte
+	raw, err := g.schemeDecode(id)
+	if err != nil {
+		return result, err
+	}
+	if len(raw) > len(result) {
+		return result, fmt.Errorf("%w: %s is %d bytes", ErrTooWide, g.scheme.Name(), len(raw))
+	}
+	copy(result[len(result)-len(raw):], raw)
+	return result, nil
+}
+
+// schemeFromBytes encodes the trailing bytes written by schemeToBytes, or
+// returns "" when the scheme is too wide or the bytes are not a valid ID
+func (g *generator) schemeFromBytes(data [16]byte) string {
+	size := g.scheme.Size()
+	if size > len(data) {
+		return ""
+	}
+	id, err := g.scheme.Encode(data[len(data)-size:])
+	if err != nil {
+		return ""
+	}
+	return id
+}
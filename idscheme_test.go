@@ -0,0 +1,92 @@
+package id_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WithScheme_ULID(t *testing.T) {
+	gen := id.NewGenerator(id.WithScheme(id.SchemeKSUID), id.WithScheme(id.SchemeNameULID))
+
+	// Act
+	generated := gen.Generate()
+
+	// Assert
+	assert.NoError(t, id.Validate(generated))
+}
+
+func Test_WithIDScheme_Nil(t *testing.T) {
+	gen := id.NewGenerator(id.WithScheme(id.SchemeSnowflake), id.WithIDScheme(nil))
+
+	assert.NoError(t, id.Validate(gen.Generate()))
+}
+
+func Test_AppendBytes_EveryScheme(t *testing.T) {
+	sizes := map[id.SchemeName]int{id.SchemeNameULID: 16, id.SchemeKSUID: 20, id.SchemeSnowflake: 8}
+
+	for name, size := range sizes {
+		gen := id.NewGenerator(id.WithScheme(name))
+		generated := gen.Generate()
+		prefix := []byte("key:")
+
+		// Act
+		raw, err := gen.AppendBytes(prefix, generated)
+		require.NoError(t, err)
+		back, err := gen.ParseBytes(raw[len(prefix):])
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, size, gen.ByteSize())
+		assert.Len(t, raw, len(prefix)+size)
+		assert.Equal(t, generated, back)
+		_, err = gen.ParseBytes(raw)
+		assert.ErrorIs(t, err, id.ErrInvalidEncoding)
+		unchanged, err := gen.AppendBytes(prefix, "invalid")
+		assert.Error(t, err)
+		assert.Equal(t, prefix, unchanged)
+	}
+}
+
+func Test_WithScheme_StreamAndBatchTo(t *testing.T) {
+	for _, name := range []id.SchemeName{id.SchemeKSUID, id.SchemeSnowflake} {
+		gen := id.NewGenerator(id.WithScheme(name))
+		ctx, cancel := context.WithCancel(context.Background())
+		var buf bytes.Buffer
+
+		// Act
+		streamed := []string{<-gen.Stream(ctx), <-gen.Stream(ctx, id.WithRate(1000))}
+		cancel()
+		require.NoError(t, gen.GenerateBatchTo(&buf, 3, '\n'))
+
+		// Assert
+		written := strings.Fields(buf.String())
+		assert.Len(t, written, 3)
+		for _, generated := range append(streamed, written...) {
+			assert.True(t, gen.IsIdValid(generated), "%s: %q", name, generated)
+		}
+		for _, err := range gen.TryStreamIDs(context.Background()) {
+			assert.ErrorIs(t, err, id.ErrSchemeUnsupported)
+		}
+	}
+}
+
+func Test_WithScheme_RejectsULIDOnlyFeatures(t *testing.T) {
+	gen := id.NewGenerator(id.WithScheme(id.SchemeSnowflake))
+
+	// Act
+	_, signedErr := id.NewSignedGeneratorFrom(gen, []byte("0123456789abcdef"))
+	_, prefixedErr := id.NewPrefixedGeneratorFrom(gen, "cus")
+
+	// Assert
+	assert.ErrorIs(t, signedErr, id.ErrSchemeUnsupported)
+	assert.ErrorIs(t, prefixedErr, id.ErrSchemeUnsupported)
+	assert.Panics(t, func() { id.NewUniqueGeneratorFrom(gen, time.Minute) })
+	assert.Panics(t, func() { gen.GenerateReverse() })
+}
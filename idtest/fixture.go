@@ -0,0 +1,22 @@
+package idtest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"time"
+
+	"github.com/bold-minds/id"
+)
+
+// FixtureTime is the timestamp of every ID returned by Fixture
+var FixtureTime = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Fixture returns a valid ULID derived from name, so table-driven tests can
+// refer to IDs like Fixture("alice") instead of literal constants. The same
+// name always yields the same ID and different names yield different IDs.
+// Every fixture carries FixtureTime, so fixtures sort by their hashed
+// randomness rather than by name or creation order.
+func Fixture(name string) string {
+	sum := sha256.Sum256([]byte(name))
+	return id.NewGeneratorWithEntropy(bytes.NewReader(sum[:])).GenerateWithTime(FixtureTime)
+}
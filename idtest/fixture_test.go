@@ -0,0 +1,25 @@
+package idtest_test
+
+import (
+	"testing"
+
+	"github.com/bold-minds/id"
+	"github.com/bold-minds/id/idtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Fixture(t *testing.T) {
+	// Act
+	alice := idtest.Fixture("alice")
+
+	// Assert
+	assert.Equal(t, "01DXF6DT005FC0DJBZ1R0AY6GZ", alice)
+	assert.Equal(t, alice, idtest.Fixture("alice"))
+	assert.NotEqual(t, alice, idtest.Fixture("bob"))
+	require.NoError(t, id.Validate(alice))
+
+	ts, err := id.NewGenerator().ExtractTimestamp(alice)
+	require.NoError(t, err)
+	assert.True(t, ts.Equal(idtest.FixtureTime))
+}
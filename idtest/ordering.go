@@ -0,0 +1,48 @@
+package idtest
+
+import (
+	"testing"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+)
+
+// AssertStrictlyIncreasing asserts that ids are valid and each sorts after
+// the one before it, the guarantee a MonotonicGenerator keeps across clock
+// anomalies such as leap seconds and backward steps. It reports whether the
+// assertion held.
+func AssertStrictlyIncreasing(t testing.TB, ids []string) bool {
+	t.Helper()
+
+	parsed, ok := parseAll(t, ids)
+	for i := 1; ok && i < len(parsed); i++ {
+		ok = assert.Equal(t, 1, parsed[i].Compare(parsed[i-1]), "ID %d %q does not sort after ID %d %q", i, ids[i], i-1, ids[i-1])
+	}
+	return ok
+}
+
+// AssertNoTimeRegression asserts that ids are valid and no ID's timestamp is
+// earlier than the one before it, which a stepped leap second breaks for IDs
+// taken straight from the clock. It reports whether the assertion held.
+func AssertNoTimeRegression(t testing.TB, ids []string) bool {
+	t.Helper()
+
+	parsed, ok := parseAll(t, ids)
+	for i := 1; ok && i < len(parsed); i++ {
+		ok = assert.GreaterOrEqual(t, parsed[i].Time(), parsed[i-1].Time(), "ID %d %q is timestamped %s, before ID %d at %s", i, ids[i], parsed[i].Timestamp(), i-1, parsed[i-1].Timestamp())
+	}
+	return ok
+}
+
+// parseAll parses ids, failing t at the first invalid one
+func parseAll(t testing.TB, ids []string) ([]id.ID, bool) {
+	t.Helper()
+
+	parsed, errs := id.ParseBatch(ids)
+	for i, err := range errs {
+		if !assert.NoError(t, err, "ID %d %q", i, ids[i]) {
+			return nil, false
+		}
+	}
+	return parsed, true
+}
@@ -0,0 +1,71 @@
+package idtest_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/bold-minds/id/idtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// failureRecorder captures assertion failures that are expected, so they do
+// not fail the test
+type failureRecorder struct {
+	testing.TB
+}
+
+func (*failureRecorder) Helper()               {}
+func (*failureRecorder) Errorf(string, ...any) {}
+
+// leapIDs issues one ID per 100ms of base time across a stepped leap second
+func leapIDs(newID func(id.Clock) func() string) []string {
+	leap := time.Date(2016, 12, 31, 23, 59, 60, 0, time.UTC)
+	base := id.NewFakeClock(leap.Add(-time.Second))
+	next := newID(id.NewLeapSecondClock(base, leap, 0))
+
+	var ids []string
+	for range 20 {
+		ids = append(ids, next())
+		base.Advance(100 * time.Millisecond)
+	}
+	return ids
+}
+
+func Test_AssertNoTimeRegression_SteppedLeapSecond(t *testing.T) {
+	ids := leapIDs(func(clock id.Clock) func() string {
+		return id.NewGenerator(id.WithClock(clock)).Generate
+	})
+
+	// Act
+	ok := idtest.AssertNoTimeRegression(&failureRecorder{TB: t}, ids)
+
+	// Assert
+	assert.False(t, ok, "a stepped leap second moves plain IDs back in time")
+}
+
+func Test_AssertStrictlyIncreasing_MonotonicAcrossLeapSecond(t *testing.T) {
+	ids := leapIDs(func(clock id.Clock) func() string {
+		gen := id.NewMonotonicGenerator(id.MonotonicOptions{Clock: clock})
+		return func() string {
+			next, err := gen.Next()
+			if err != nil {
+				panic(err)
+			}
+			return next
+		}
+	})
+
+	// Act & Assert
+	idtest.AssertStrictlyIncreasing(t, ids)
+	idtest.AssertNoTimeRegression(t, ids)
+}
+
+func Test_AssertStrictlyIncreasing_Invalid(t *testing.T) {
+	ids := id.NewGenerator().GenerateBatch(2)
+
+	// Act & Assert
+	assert.False(t, idtest.AssertStrictlyIncreasing(&failureRecorder{TB: t}, []string{ids[1], ids[0]}))
+	assert.False(t, idtest.AssertStrictlyIncreasing(&failureRecorder{TB: t}, []string{ids[0], "invalid"}))
+	assert.True(t, idtest.AssertStrictlyIncreasing(t, nil))
+}
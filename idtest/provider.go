@@ -0,0 +1,120 @@
+// Package idtest provides helpers for testing code built on the id package
+package idtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// conformanceCount is how many IDs each uniqueness check generates
+const conformanceCount = 1000
+
+// TestProvider runs a conformance suite against p, asserting the uniqueness,
+// ordering, validation, timestamp, and conversion invariants every
+// id.Provider must uphold. Call it from a test of a custom scheme, mock, or
+// distributed generator to verify it can stand in for the built-in ones.
+func TestProvider(t *testing.T, p id.Provider) {
+	t.Helper()
+
+	t.Run("Uniqueness", func(t *testing.T) {
+		seen := make(map[string]bool, 2*conformanceCount)
+		for i := 0; i < conformanceCount; i++ {
+			generated := p.Generate()
+			require.False(t, seen[generated], "Generate returned duplicate %q", generated)
+			seen[generated] = true
+		}
+		for _, generated := range p.GenerateBatch(conformanceCount) {
+			require.False(t, seen[generated], "GenerateBatch returned duplicate %q", generated)
+			seen[generated] = true
+		}
+	})
+
+	t.Run("Ordering", func(t *testing.T) {
+		base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		earlier := p.GenerateWithTime(base)
+		later := p.GenerateWithTime(base.Add(time.Second))
+
+		cmp, err := p.Compare(earlier, later)
+		require.NoError(t, err)
+		assert.Equal(t, -1, cmp, "Compare(earlier, later)")
+		cmp, err = p.Compare(later, earlier)
+		require.NoError(t, err)
+		assert.Equal(t, 1, cmp, "Compare(later, earlier)")
+		cmp, err = p.Compare(earlier, earlier)
+		require.NoError(t, err)
+		assert.Equal(t, 0, cmp, "Compare(id, id)")
+
+		before, err := p.IsBefore(earlier, later)
+		require.NoError(t, err)
+		assert.True(t, before, "IsBefore(earlier, later)")
+		after, err := p.IsAfter(later, earlier)
+		require.NoError(t, err)
+		assert.True(t, after, "IsAfter(later, earlier)")
+
+		ranged := p.GenerateRange(base, base.Add(time.Hour), 100)
+		require.Len(t, ranged, 100)
+		for i := 1; i < len(ranged); i++ {
+			cmp, err := p.Compare(ranged[i-1], ranged[i])
+			require.NoError(t, err)
+			assert.Equal(t, -1, cmp, "GenerateRange not ascending at %d", i)
+		}
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		generated := p.Generate()
+		assert.True(t, p.IsIdValid(generated), "generated ID %q is invalid", generated)
+		for _, bad := range []string{"", "not-an-id", "!!!!!!!!!!!!!!!!!!!!!!!!!!"} {
+			assert.False(t, p.IsIdValid(bad), "IsIdValid(%q)", bad)
+			_, err := p.ValidateAndNormalize(bad)
+			assert.Error(t, err, "ValidateAndNormalize(%q)", bad)
+		}
+
+		normalized, err := p.ValidateAndNormalize(generated)
+		require.NoError(t, err)
+		assert.True(t, p.IsIdValid(normalized), "normalized ID %q is invalid", normalized)
+		again, err := p.ValidateAndNormalize(normalized)
+		require.NoError(t, err)
+		assert.Equal(t, normalized, again, "ValidateAndNormalize is not idempotent")
+	})
+
+	t.Run("Timestamps", func(t *testing.T) {
+		at := time.Date(2024, 6, 15, 12, 30, 45, 123_000_000, time.UTC)
+		extracted, err := p.ExtractTimestamp(p.GenerateWithTime(at))
+		require.NoError(t, err)
+		assert.True(t, extracted.Equal(at), "ExtractTimestamp = %v, want %v", extracted, at)
+
+		age, err := p.Age(p.Generate())
+		require.NoError(t, err)
+		assert.Less(t, age, time.Minute, "Age of a new ID")
+
+		expired, err := p.IsExpired(p.GenerateWithTime(time.Now().Add(-2*time.Hour)), time.Hour)
+		require.NoError(t, err)
+		assert.True(t, expired, "IsExpired of a two-hour-old ID with a one-hour limit")
+
+		_, err = p.ExtractTimestamp("not-an-id")
+		assert.Error(t, err, "ExtractTimestamp of an invalid ID")
+		assert.Empty(t, p.GenerateBatch(0), "GenerateBatch(0)")
+		assert.Empty(t, p.GenerateRange(at, at.Add(-time.Hour), 10), "GenerateRange with end before start")
+	})
+
+	t.Run("Conversion", func(t *testing.T) {
+		generated := p.Generate()
+		normalized, err := p.ValidateAndNormalize(generated)
+		require.NoError(t, err)
+
+		raw, err := p.ToBytes(generated)
+		require.NoError(t, err)
+		assert.Equal(t, normalized, p.FromBytes(raw), "FromBytes(ToBytes(id))")
+
+		uuid, err := p.ToUUID(generated)
+		require.NoError(t, err)
+		assert.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`, uuid)
+
+		_, err = p.ToBytes("not-an-id")
+		assert.Error(t, err, "ToBytes of an invalid ID")
+	})
+}
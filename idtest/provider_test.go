@@ -0,0 +1,27 @@
+package idtest_test
+
+import (
+	"testing"
+
+	"github.com/bold-minds/id"
+	"github.com/bold-minds/id/idtest"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TestProvider_Generator(t *testing.T) {
+	idtest.TestProvider(t, id.NewGenerator())
+}
+
+func Test_TestProvider_SecureGenerator(t *testing.T) {
+	idtest.TestProvider(t, id.NewSecureGenerator())
+}
+
+func Test_TestProvider_LowercaseGenerator(t *testing.T) {
+	idtest.TestProvider(t, id.NewLowercaseGenerator())
+}
+
+func Test_TestProvider_PrefixedGenerator(t *testing.T) {
+	gen, err := id.NewPrefixedGenerator("cus")
+	require.NoError(t, err)
+	idtest.TestProvider(t, gen)
+}
@@ -0,0 +1,192 @@
+package id
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// ErrColumnNotFound is returned when a CSV header has no column of the requested name
+var ErrColumnNotFound = errors.New("column not found")
+
+// ImportStats counts the values an ImportReader has seen
+type ImportStats struct {
+	// Valid values are ULIDs, returned by Read
+	Valid int
+	// Invalid values are present but not ULIDs
+	Invalid int
+	// Empty values are missing, null, or blank
+	Empty int
+}
+
+// Total returns the number of records seen
+func (s ImportStats) Total() int {
+	return s.Valid + s.Invalid + s.Empty
+}
+
+// CSVDialect describes a CSV variant. The zero value reads RFC 4180 files
+// with a comma separator.
+type CSVDialect struct {
+	// Comma separates fields; 0 means ','. Use '\t' for TSV or ';' for
+	// spreadsheets exported in locales with decimal commas.
+	Comma rune
+	// Comment starts a line to skip; 0 disables comments
+	Comment rune
+	// LazyQuotes accepts quotes inside unquoted fields
+	LazyQuotes bool
+}
+
+// ImportReader pulls ULIDs out of one column or field of a structured file,
+// validating each value and counting valid, invalid, and empty ones, so a
+// file can feed the analytics and migration helpers directly
+type ImportReader struct {
+	// next returns the raw value of the next record
+	next  func() (string, error)
+	stats ImportStats
+}
+
+// NewCSVImportReader creates an ImportReader over the named column of a CSV
+// file with a header row. A byte order mark before the header is ignored,
+// and rows too short to reach the column count as empty.
+func NewCSVImportReader(r io.Reader, column string, dialect CSVDialect) (*ImportReader, error) {
+	reader := csv.NewReader(r)
+	if dialect.Comma != 0 {
+		reader.Comma = dialect.Comma
+	}
+	reader.Comment = dialect.Comment
+	reader.LazyQuotes = dialect.LazyQuotes
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: %q in empty file", ErrColumnNotFound, column)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading CSV header: %w", err)
+	}
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+	index := slices.IndexFunc(header, func(name string) bool {
+		return strings.TrimSpace(name) == column
+	})
+	if index < 0 {
+		return nil, fmt.Errorf("%w: %q", ErrColumnNotFound, column)
+	}
+
+	return &ImportReader{next: func() (string, error) {
+		record, readErr := reader.Read()
+		if readErr != nil {
+			return "", readErr
+		}
+		if index >= len(record) {
+			return "", nil
+		}
+		return record[index], nil
+	}}, nil
+}
+
+// NewNDJSONImportReader creates an ImportReader over a field of
+// newline-delimited JSON objects. A dotted field such as "user.id" reaches
+// into nested objects. Blank lines are skipped; non-string values count as
+// invalid.
+func NewNDJSONImportReader(r io.Reader, field string) *ImportReader {
+	reader := bufio.NewReader(r)
+	path := strings.Split(field, ".")
+	line := 0
+
+	return &ImportReader{next: func() (string, error) {
+		for {
+			data, err := reader.ReadBytes('\n')
+			line++
+			data = bytes.TrimSpace(data)
+			if len(data) == 0 {
+				if err != nil {
+					return "", err
+				}
+				continue
+			}
+			if err != nil && !errors.Is(err, io.EOF) {
+				return "", err
+			}
+
+			var record map[string]any
+			if jerr := json.Unmarshal(data, &record); jerr != nil {
+				return "", fmt.Errorf("line %d: %w", line, jerr)
+			}
+			return lookupField(record, path), nil
+		}
+	}}
+}
+
+// lookupField walks path through nested objects. Missing and null values
+// give "", and other non-string values a string that never parses as a ULID.
+func lookupField(record map[string]any, path []string) string {
+	var value any = record
+	for _, key := range path {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return ""
+		}
+		value = object[key]
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// Read returns the next valid ULID in canonical form, skipping and counting
+// invalid and empty values. It returns io.EOF when the input ends cleanly.
+func (r *ImportReader) Read() (string, error) {
+	for {
+		value, err := r.next()
+		if err != nil {
+			return "", err
+		}
+		value = strings.TrimSpace(value)
+		if value == "" {
+			r.stats.Empty++
+			continue
+		}
+		parsed, perr := parseCanonical(value)
+		if perr != nil {
+			r.stats.Invalid++
+			continue
+		}
+		r.stats.Valid++
+		return parsed.String(), nil
+	}
+}
+
+// ReadAll returns the remaining valid ULIDs
+func (r *ImportReader) ReadAll() ([]string, error) {
+	var ids []string
+	for {
+		id, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return ids, nil
+		}
+		if err != nil {
+			return ids, err
+		}
+		ids = append(ids, id)
+	}
+}
+
+// Stats returns the counts so far
+func (r *ImportReader) Stats() ImportStats {
+	return r.stats
+}
@@ -0,0 +1,76 @@
+package id_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CSVImportReader(t *testing.T) {
+	ids := id.NewGenerator().GenerateBatch(2)
+	input := "\ufeffname;order_id\n" +
+		"alice;" + strings.ToLower(ids[0]) + "\n" +
+		"# skipped comment\n" +
+		"bob;not-an-id\n" +
+		"carol; \n" +
+		"dave\n" +
+		`"eve, jr";` + ids[1] + "\n"
+	reader, err := id.NewCSVImportReader(strings.NewReader(input), "order_id", id.CSVDialect{Comma: ';', Comment: '#'})
+	require.NoError(t, err)
+
+	// Act
+	got, err := reader.ReadAll()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, ids, got)
+	assert.Equal(t, id.ImportStats{Valid: 2, Invalid: 1, Empty: 2}, reader.Stats())
+	assert.Equal(t, 5, reader.Stats().Total())
+}
+
+func Test_CSVImportReader_Errors(t *testing.T) {
+	_, err := id.NewCSVImportReader(strings.NewReader("a,b\n"), "id", id.CSVDialect{})
+	require.ErrorIs(t, err, id.ErrColumnNotFound)
+	_, err = id.NewCSVImportReader(strings.NewReader(""), "id", id.CSVDialect{})
+	require.ErrorIs(t, err, id.ErrColumnNotFound)
+
+	reader, err := id.NewCSVImportReader(strings.NewReader("id\n\"unterminated\n"), "id", id.CSVDialect{})
+	require.NoError(t, err)
+	_, err = reader.Read()
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, io.EOF)
+}
+
+func Test_NDJSONImportReader(t *testing.T) {
+	ids := id.NewGenerator().GenerateBatch(2)
+	input := `{"user": {"id": "` + ids[0] + `"}}` + "\n" +
+		"\n" +
+		`{"user": {"id": 42}}` + "\n" +
+		`{"user": {"id": null}}` + "\n" +
+		`{"user": "flat"}` + "\n" +
+		`{"user": {"id": "` + strings.ToLower(ids[1]) + `"}}` // no trailing newline
+	reader := id.NewNDJSONImportReader(strings.NewReader(input), "user.id")
+
+	// Act
+	got, err := reader.ReadAll()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, ids, got)
+	assert.Equal(t, id.ImportStats{Valid: 2, Invalid: 1, Empty: 2}, reader.Stats())
+}
+
+func Test_NDJSONImportReader_Malformed(t *testing.T) {
+	reader := id.NewNDJSONImportReader(strings.NewReader("{}\n{broken\n"), "id")
+
+	// Act
+	_, err := reader.ReadAll()
+
+	// Assert
+	require.ErrorContains(t, err, "line 2")
+	assert.Equal(t, id.ImportStats{Empty: 1}, reader.Stats())
+}
@@ -0,0 +1,139 @@
+package id
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/oklog/ulid"
+)
+
+// Info is a full decomposition of a ULID
+type Info struct {
+	Input     string        `json:"input"`
+	ID        string        `json:"id"`
+	Timestamp time.Time     `json:"timestamp"`
+	Age       time.Duration `json:"age"`
+	Entropy   [10]byte      `json:"entropy"`
+	UUID      string        `json:"uuid"`
+	Hex       string        `json:"hex"`
+	Segments  []Segment     `json:"segments"`
+	Notes     []string      `json:"notes,omitempty"`
+}
+
+// Segment describes one Base32 character of a ULID and the bits it encodes.
+// Bits are numbered from 0 (most significant) to 127 and StartBit/EndBit
+// are inclusive. The first character only carries 3 bits because Base32
+// encodes 130 bits into 26 characters.
+type Segment struct {
+	Position int    `json:"position"`
+	Char     string `json:"char"`
+	Value    uint8  `json:"value"`
+	StartBit int    `json:"start_bit"`
+	EndBit   int    `json:"end_bit"`
+	Part     string `json:"part"`
+}
+
+// Segment parts
+const (
+	PartTimestamp = "timestamp"
+	PartEntropy   = "entropy"
+)
+
+// timestampChars is the number of Base32 characters holding the 48-bit timestamp
+const timestampChars = 10
+
+// Inspect decomposes a ULID into its timestamp, entropy, alternate encodings,
+// and per-character bit layout, annotated with notes about anything unusual.
+// Invalid input returns an error along with an Info whose Notes explain why.
+func Inspect(id string) (Info, error) {
+	info := Info{Input: id}
+
+	normalized := strings.ToUpper(id)
+	parsed, err := ulid.ParseStrict(normalized)
+	if err != nil {
+		info.Notes = append(info.Notes, inspectFailure(id, err))
+		return info, fmt.Errorf("invalid ULID: %w", err)
+	}
+
+	info.ID = parsed.String()
+	info.Timestamp = ulid.Time(parsed.Time()).UTC()
+	info.Age = time.Since(info.Timestamp)
+	copy(info.Entropy[:], parsed[6:])
+	info.Hex = hex.EncodeToString(parsed[:])
+	info.UUID = fmt.Sprintf("%x-%x-%x-%x-%x", parsed[0:4], parsed[4:6], parsed[6:8], parsed[8:10], parsed[10:16])
+	info.Segments = segments(info.ID)
+
+	if id != info.ID {
+		info.Notes = append(info.Notes, "input is not canonical uppercase; normalized")
+	}
+	if info.Age < 0 {
+		info.Notes = append(info.Notes, "timestamp is in the future")
+	}
+	if parsed.Time() == 0 {
+		info.Notes = append(info.Notes, "timestamp is the Unix epoch")
+	}
+	switch {
+	case allBytes(info.Entropy[:], 0x00):
+		info.Notes = append(info.Notes, "entropy is all zeros")
+	case allBytes(info.Entropy[:], 0xFF):
+		info.Notes = append(info.Notes, "entropy is all ones")
+	}
+
+	return info, nil
+}
+
+// segments maps every character of a canonical ULID to the bits it encodes
+func segments(id string) []Segment {
+	result := make([]Segment, len(id))
+	for i := range id {
+		start := 5*i - 2
+		if start < 0 {
+			start = 0
+		}
+		part := PartEntropy
+		if i < timestampChars {
+			part = PartTimestamp
+		}
+		result[i] = Segment{
+			Position: i,
+			Char:     id[i : i+1],
+			Value:    uint8(strings.IndexByte(ulid.Encoding, id[i])), //nolint:gosec // G115: index is below 32
+			StartBit: start,
+			EndBit:   5*i + 2,
+			Part:     part,
+		}
+	}
+	return result
+}
+
+// inspectFailure explains why a string failed to parse as a ULID
+func inspectFailure(id string, err error) string {
+	if id == "" {
+		return "input is empty"
+	}
+	if len(id) != ulid.EncodedSize {
+		return fmt.Sprintf("length is %d, want %d", len(id), ulid.EncodedSize)
+	}
+	upper := strings.ToUpper(id)
+	for i := 0; i < len(upper); i++ {
+		if strings.IndexByte(ulid.Encoding, upper[i]) < 0 {
+			return fmt.Sprintf("character %q at position %d is not Crockford Base32", id[i], i)
+		}
+	}
+	if upper[0] > '7' {
+		return "first character exceeds 7; timestamp overflows 48 bits"
+	}
+	return err.Error()
+}
+
+// allBytes reports whether every byte in b equals v
+func allBytes(b []byte, v byte) bool {
+	for _, c := range b {
+		if c != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,69 @@
+package id_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Inspect(t *testing.T) {
+	gen := id.NewGenerator()
+	testTime := time.Date(2023, 6, 15, 14, 30, 45, 123000000, time.UTC)
+	ulid := gen.GenerateWithTime(testTime)
+
+	// Act
+	info, err := id.Inspect(ulid)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, ulid, info.ID)
+	assert.True(t, testTime.Equal(info.Timestamp))
+	assert.Greater(t, info.Age, time.Duration(0))
+	assert.Len(t, info.Hex, 32)
+	uuid, err := gen.ToUUID(ulid)
+	require.NoError(t, err)
+	assert.Equal(t, uuid, info.UUID)
+	assert.Empty(t, info.Notes)
+
+	require.Len(t, info.Segments, 26)
+	assert.Equal(t, 0, info.Segments[0].StartBit)
+	assert.Equal(t, 2, info.Segments[0].EndBit)
+	assert.Equal(t, id.PartTimestamp, info.Segments[9].Part)
+	assert.Equal(t, 47, info.Segments[9].EndBit)
+	assert.Equal(t, id.PartEntropy, info.Segments[10].Part)
+	assert.Equal(t, 48, info.Segments[10].StartBit)
+	assert.Equal(t, 127, info.Segments[25].EndBit)
+}
+
+func Test_Inspect_Notes(t *testing.T) {
+	// Act
+	info, err := id.Inspect(strings.ToLower("01ARZ3NDEK0000000000000000"))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "01ARZ3NDEK0000000000000000", info.ID)
+	assert.Contains(t, info.Notes, "input is not canonical uppercase; normalized")
+	assert.Contains(t, info.Notes, "entropy is all zeros")
+}
+
+func Test_Inspect_Invalid(t *testing.T) {
+	cases := map[string]string{
+		"":                           "input is empty",
+		"short":                      "length is 5, want 26",
+		"01ARZ3NDEKTSV4RRFFQ69G5FA!": "character '!' at position 25 is not Crockford Base32",
+		"81ARZ3NDEKTSV4RRFFQ69G5FAV": "first character exceeds 7; timestamp overflows 48 bits",
+	}
+
+	for input, note := range cases {
+		// Act
+		info, err := id.Inspect(input)
+
+		// Assert
+		assert.Error(t, err, input)
+		assert.Equal(t, []string{note}, info.Notes, input)
+	}
+}
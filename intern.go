@@ -0,0 +1,103 @@
+package id
+
+import (
+	"strings"
+	"sync"
+)
+
+// DefaultInternPoolSize is the capacity of the pool behind Intern, and the
+// capacity NewInternPool uses for non-positive sizes
+const DefaultInternPoolSize = 1 << 16
+
+// defaultInternPool backs Intern
+var defaultInternPool = NewInternPool(DefaultInternPoolSize)
+
+// Intern returns a shared copy of id from a process-wide pool, so that many
+// references to the same ID hold one string on the heap
+func Intern(id string) string {
+	return defaultInternPool.Intern(id)
+}
+
+// InternPool deduplicates ID strings in a bounded map. Entries live in two
+// generations: once the current generation reaches capacity it replaces the
+// previous one, which is dropped, and entries found in the previous
+// generation are promoted. Recently used IDs therefore stay interned while
+// memory stays under twice the capacity, without per-entry bookkeeping. It is
+// safe for concurrent use.
+type InternPool struct {
+	capacity int
+
+	mu       sync.Mutex
+	current  map[string]string
+	previous map[string]string
+	stats    CacheStats
+}
+
+// NewInternPool creates a pool holding up to size IDs per generation
+func NewInternPool(size int) *InternPool {
+	if size <= 0 {
+		size = DefaultInternPoolSize
+	}
+	return &InternPool{
+		capacity: size,
+		current:  make(map[string]string),
+	}
+}
+
+// Intern returns the pool's copy of id, adding one if there is none. Added
+// IDs are cloned, so interning a substring of a large buffer, such as a
+// request body, does not keep the buffer alive.
+func (p *InternPool) Intern(id string) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if shared, ok := p.current[id]; ok {
+		p.stats.Hits++
+		return shared
+	}
+	shared, ok := p.previous[id]
+	if ok {
+		p.stats.Hits++
+	} else {
+		p.stats.Misses++
+		shared = strings.Clone(id)
+	}
+
+	if len(p.current) >= p.capacity {
+		for old := range p.previous {
+			if _, kept := p.current[old]; !kept {
+				p.stats.Evictions++
+			}
+		}
+		p.previous, p.current = p.current, make(map[string]string, p.capacity)
+	}
+	p.current[shared] = shared
+	return shared
+}
+
+// Stats returns a snapshot of the pool counters. Size counts distinct IDs
+// across both generations.
+func (p *InternPool) Stats() CacheStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stats := p.stats
+	stats.Size = len(p.current)
+	for id := range p.previous {
+		if _, ok := p.current[id]; !ok {
+			stats.Size++
+		}
+	}
+	stats.Capacity = p.capacity
+	return stats
+}
+
+// Reset empties the pool and zeroes its counters
+func (p *InternPool) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.current = make(map[string]string)
+	p.previous = nil
+	p.stats = CacheStats{}
+}
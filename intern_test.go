@@ -0,0 +1,78 @@
+package id_test
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"unsafe"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+)
+
+// sameString reports whether a and b share their backing bytes
+func sameString(a, b string) bool {
+	return unsafe.StringData(a) == unsafe.StringData(b)
+}
+
+func Test_InternPool_Intern(t *testing.T) {
+	pool := id.NewInternPool(4)
+	ulid := id.NewGenerator().Generate()
+	copy1 := strings.Clone(ulid)
+	copy2 := strings.Clone(ulid)
+
+	// Act
+	first := pool.Intern(copy1)
+	second := pool.Intern(copy2)
+
+	// Assert
+	assert.Equal(t, ulid, second)
+	assert.True(t, sameString(first, second))
+	assert.False(t, sameString(first, copy1), "interned IDs are cloned")
+	assert.Equal(t, id.CacheStats{Hits: 1, Misses: 1, Size: 1, Capacity: 4}, pool.Stats())
+}
+
+func Test_InternPool_Bounded(t *testing.T) {
+	pool := id.NewInternPool(2)
+	ids := id.NewGenerator().GenerateBatch(5)
+	hot := pool.Intern(ids[0])
+
+	// Act
+	for _, s := range ids[1:] {
+		pool.Intern(s)
+		assert.True(t, sameString(hot, pool.Intern(strings.Clone(ids[0]))), "recently used IDs stay interned")
+	}
+
+	// Assert
+	stats := pool.Stats()
+	assert.LessOrEqual(t, stats.Size, 4)
+	assert.Equal(t, uint64(2), stats.Evictions)
+	assert.Equal(t, uint64(5), stats.Misses)
+
+	pool.Reset()
+	assert.Equal(t, id.CacheStats{Capacity: 2}, pool.Stats())
+}
+
+func Test_Intern(t *testing.T) {
+	ulid := id.NewGenerator().Generate()
+
+	assert.True(t, sameString(id.Intern(strings.Clone(ulid)), id.Intern(strings.Clone(ulid))))
+}
+
+func Test_InternPool_Concurrent(t *testing.T) {
+	pool := id.NewInternPool(64)
+	ids := id.NewGenerator().GenerateBatch(256)
+	var wg sync.WaitGroup
+
+	// Act & Assert
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, s := range ids {
+				assert.Equal(t, s, pool.Intern(s))
+			}
+		}()
+	}
+	wg.Wait()
+}
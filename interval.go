@@ -0,0 +1,106 @@
+package id
+
+import (
+	"fmt"
+	"math/bits"
+	"time"
+
+	"github.com/oklog/ulid"
+)
+
+// Interval is an inclusive time window [Start, End] over ULID timestamps,
+// matching the bounds used by FilterByTimeRange
+type Interval struct {
+	Start time.Time
+	End   time.Time
+}
+
+// FromTimes creates an Interval between two times, in either order
+func FromTimes(t1, t2 time.Time) Interval {
+	if t2.Before(t1) {
+		t1, t2 = t2, t1
+	}
+	return Interval{Start: t1, End: t2}
+}
+
+// FromIDs creates an Interval spanning the timestamps of two ULIDs, in either order
+func FromIDs(a, b string) (Interval, error) {
+	ua, err := parseCanonical(a)
+	if err != nil {
+		return Interval{}, fmt.Errorf("invalid first ULID: %w", err)
+	}
+	ub, err := parseCanonical(b)
+	if err != nil {
+		return Interval{}, fmt.Errorf("invalid second ULID: %w", err)
+	}
+	return FromTimes(ulid.Time(ua.Time()), ulid.Time(ub.Time())), nil
+}
+
+// Contains reports whether a ULID's timestamp falls within the interval.
+// Invalid ULIDs are never contained.
+func (iv Interval) Contains(id string) bool {
+	parsed, err := parseCanonical(id)
+	if err != nil {
+		return false
+	}
+	return iv.ContainsTime(ulid.Time(parsed.Time()))
+}
+
+// ContainsTime reports whether t falls within the interval
+func (iv Interval) ContainsTime(t time.Time) bool {
+	return !t.Before(iv.Start) && !t.After(iv.End)
+}
+
+// Overlaps reports whether two intervals share at least one instant
+func (iv Interval) Overlaps(other Interval) bool {
+	return !iv.End.Before(other.Start) && !other.End.Before(iv.Start)
+}
+
+// Duration returns the length of the interval
+func (iv Interval) Duration() time.Duration {
+	return iv.End.Sub(iv.Start)
+}
+
+// Split divides the interval into n contiguous, non-overlapping intervals of
+// near-equal length. Because ULID timestamps have millisecond precision, each
+// piece ends one millisecond before the next begins; the last piece ends at
+// End. Fewer than n pieces are returned when the interval is too short, and
+// none when n is not positive or End is before Start.
+func (iv Interval) Split(n int) []Interval {
+	span := iv.Duration()
+	if n <= 0 || span < 0 {
+		return []Interval{}
+	}
+	if pieces := int64(span/time.Millisecond) + 1; int64(n) > pieces {
+		n = int(pieces)
+	}
+
+	result := make([]Interval, n)
+	for i := 0; i < n; i++ {
+		start := iv.Start.Add(splitOffset(span, i, n))
+		end := iv.End
+		if i < n-1 {
+			end = iv.Start.Add(splitOffset(span, i+1, n)).Add(-time.Millisecond)
+		}
+		result[i] = Interval{Start: start, End: end}
+	}
+	return result
+}
+
+// splitOffset returns span*i/n rounded down for 0 <= i <= n, multiplying in
+// 128 bits so long spans do not overflow
+func splitOffset(span time.Duration, i, n int) time.Duration {
+	hi, lo := bits.Mul64(uint64(span), uint64(i)) //nolint:gosec // G115: non-negative
+	q, _ := bits.Div64(hi, lo, uint64(n))         //nolint:gosec // G115: positive
+	return time.Duration(q)                       //nolint:gosec // G115: at most span
+}
+
+// Filter returns the ULIDs whose timestamps fall within the interval
+func (iv Interval) Filter(ids []string) []string {
+	return FilterByTimeRange(ids, iv.Start, iv.End)
+}
+
+// String formats the interval as RFC 3339 bounds
+func (iv Interval) String() string {
+	return fmt.Sprintf("[%s, %s]", iv.Start.Format(time.RFC3339Nano), iv.End.Format(time.RFC3339Nano))
+}
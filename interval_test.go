@@ -0,0 +1,103 @@
+package id_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Interval(t *testing.T) {
+	gen := id.NewGenerator()
+	start := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	// Act
+	iv, err := id.FromIDs(gen.GenerateWithTime(end), gen.GenerateWithTime(start))
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, start.Equal(iv.Start))
+	assert.True(t, end.Equal(iv.End))
+	assert.Equal(t, time.Hour, iv.Duration())
+	assert.True(t, iv.Contains(gen.GenerateWithTime(start)))
+	assert.True(t, iv.Contains(gen.GenerateWithTime(end)))
+	assert.False(t, iv.Contains(gen.GenerateWithTime(end.Add(time.Millisecond))))
+	assert.False(t, iv.Contains("invalid"))
+
+	ids := []string{gen.GenerateWithTime(start.Add(-time.Minute)), gen.GenerateWithTime(start.Add(time.Minute))}
+	assert.Equal(t, ids[1:], iv.Filter(ids))
+	assert.Contains(t, iv.String(), "2023-01-01T12:00:00Z")
+
+	_, err = id.FromIDs("invalid", gen.Generate())
+	assert.Error(t, err)
+	_, err = id.FromIDs(gen.Generate(), "invalid")
+	assert.Error(t, err)
+	_, err = id.FromIDs(gen.Generate(), "01ARZ3NDEKTSV4RRFFQ69G5F!!")
+	assert.Error(t, err)
+	assert.False(t, id.FromTimes(time.UnixMilli(0), time.Now()).Contains("01ARZ3NDEKTSV4RRFFQ69G5F!!"))
+}
+
+func Test_Interval_Overlaps(t *testing.T) {
+	base := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	a := id.FromTimes(base, base.Add(time.Hour))
+
+	// Act & Assert
+	assert.True(t, a.Overlaps(id.FromTimes(base.Add(30*time.Minute), base.Add(2*time.Hour))))
+	assert.True(t, a.Overlaps(id.FromTimes(base.Add(time.Hour), base.Add(2*time.Hour)))) // Shared endpoint
+	assert.False(t, a.Overlaps(id.FromTimes(base.Add(2*time.Hour), base.Add(3*time.Hour))))
+	assert.True(t, a.Overlaps(id.FromTimes(base.Add(10*time.Minute), base.Add(20*time.Minute))))
+}
+
+func Test_Interval_Split(t *testing.T) {
+	gen := id.NewGenerator()
+	base := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	iv := id.FromTimes(base, base.Add(time.Hour))
+
+	// Act
+	pieces := iv.Split(4)
+
+	// Assert
+	require.Len(t, pieces, 4)
+	assert.True(t, base.Equal(pieces[0].Start))
+	assert.True(t, iv.End.Equal(pieces[3].End))
+	for i := 0; i < len(pieces)-1; i++ {
+		assert.False(t, pieces[i].Overlaps(pieces[i+1]))
+		assert.Equal(t, time.Millisecond, pieces[i+1].Start.Sub(pieces[i].End))
+	}
+
+	// Every ID lands in exactly one piece
+	for _, ulid := range gen.GenerateRange(iv.Start, iv.End, 100) {
+		count := 0
+		for _, p := range pieces {
+			if p.Contains(ulid) {
+				count++
+			}
+		}
+		assert.Equal(t, 1, count)
+	}
+
+	assert.Empty(t, iv.Split(0))
+	assert.Len(t, id.FromTimes(base, base.Add(2*time.Millisecond)).Split(10), 3)
+}
+
+func Test_Interval_Split_Bounds(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	long := id.Interval{Start: now, End: now.Add(200 * 365 * 24 * time.Hour)}
+
+	// Act
+	reversed := id.Interval{Start: now, End: now.Add(-time.Second)}.Split(3)
+	pieces := long.Split(1000)
+
+	// Assert
+	assert.Empty(t, reversed)
+	require.Len(t, pieces, 1000)
+	assert.True(t, long.Start.Equal(pieces[0].Start))
+	assert.True(t, long.End.Equal(pieces[999].End))
+	for i := 1; i < len(pieces); i++ {
+		assert.Equal(t, time.Millisecond, pieces[i].Start.Sub(pieces[i-1].End), "gap before piece %d", i)
+		assert.InDelta(t, long.Duration()/1000, pieces[i].Start.Sub(pieces[i-1].Start), float64(time.Millisecond))
+	}
+}
@@ -0,0 +1,151 @@
+package id
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// SchemeKSUID selects K-Sortable Unique IDs
+	SchemeKSUID SchemeName = "ksuid"
+
+	// ksuidEpoch is the Unix time, in seconds, of KSUID timestamp zero
+	ksuidEpoch = 1_400_000_000
+	// ksuidSize and ksuidEncodedSize are the binary and text lengths of a KSUID
+	ksuidSize        = 20
+	ksuidEncodedSize = 27
+	// base62Alphabet is ordered like ASCII so text and binary sort alike
+	base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+)
+
+// errKSUIDOverflow is returned for 27-character text above the largest 160-bit value
+var errKSUIDOverflow = errors.New("KSUID overflows 160 bits")
+
+// KSUIDScheme issues K-Sortable Unique IDs: a 32-bit count of seconds since
+// 2014-05-13T16:53:20Z followed by a 128-bit random payload, encoded as 27
+// base62 characters. Within one second each ID increments the previous
+// payload, as ULIDs do within a millisecond, so IDs from one scheme stay in
+// issue order. It is safe for concurrent use.
+type KSUIDScheme struct {
+	mu      sync.Mutex
+	issued  bool
+	lastSec uint32
+	last    [16]byte
+}
+
+// NewKSUIDScheme creates a KSUID scheme
+func NewKSUIDScheme() *KSUIDScheme {
+	return &KSUIDScheme{}
+}
+
+// Name returns SchemeKSUID
+func (s *KSUIDScheme) Name() SchemeName {
+	return SchemeKSUID
+}
+
+// Size returns 20
+func (s *KSUIDScheme) Size() int {
+	return ksuidSize
+}
+
+// New encodes a KSUID for t, which must fall between 2014-05-13T16:53:20Z
+// and 2150-06-19T23:21:35Z
+func (s *KSUIDScheme) New(t time.Time, entropy io.Reader) (string, error) {
+	secs := t.Unix() - ksuidEpoch
+	if secs < 0 || secs > math.MaxUint32 {
+		return "", fmt.Errorf("time %s outside the KSUID range", t.UTC().Format(time.RFC3339))
+	}
+	sec := uint32(secs) //nolint:gosec // G115: range checked above
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var raw [ksuidSize]byte
+	binary.BigEndian.PutUint32(raw[:4], sec)
+	payload := s.last
+	if !s.issued || sec != s.lastSec || !incrementBytes(payload[:]) {
+		if _, err := io.ReadFull(entropy, payload[:]); err != nil {
+			return "", fmt.Errorf("reading KSUID payload: %w", err)
+		}
+	}
+	copy(raw[4:], payload[:])
+	s.issued, s.lastSec, s.last = true, sec, payload
+	return encodeBase62(raw), nil
+}
+
+// Decode parses a 27-character base62 KSUID
+func (s *KSUIDScheme) Decode(id string) ([]byte, error) {
+	if len(id) != ksuidEncodedSize {
+		return nil, fmt.Errorf("got %d characters, want %d", len(id), ksuidEncodedSize)
+	}
+
+	raw := make([]byte, ksuidSize)
+	for i := 0; i < len(id); i++ {
+		digit := strings.IndexByte(base62Alphabet, id[i])
+		if digit < 0 {
+			r, _ := utf8.DecodeRuneInString(id[i:])
+			return nil, ErrInvalidCharacter{Pos: i, Rune: r}
+		}
+		// raw = raw*62 + digit
+		carry := digit
+		for j := len(raw) - 1; j >= 0; j-- {
+			v := int(raw[j])*62 + carry
+			raw[j] = byte(v)
+			carry = v >> 8
+		}
+		if carry != 0 {
+			return nil, errKSUIDOverflow
+		}
+	}
+	return raw, nil
+}
+
+// Encode renders a 20-byte KSUID as base62
+func (s *KSUIDScheme) Encode(raw []byte) (string, error) {
+	if len(raw) != ksuidSize {
+		return "", fmt.Errorf("KSUID is %d bytes, got %d", ksuidSize, len(raw))
+	}
+	return encodeBase62([ksuidSize]byte(raw)), nil
+}
+
+// Time returns the KSUID's timestamp, which has one-second precision
+func (s *KSUIDScheme) Time(raw []byte) time.Time {
+	if len(raw) != ksuidSize {
+		return time.Time{}
+	}
+	return time.Unix(int64(binary.BigEndian.Uint32(raw[:4]))+ksuidEpoch, 0)
+}
+
+// encodeBase62 renders a 160-bit big-endian value as 27 zero-padded base62 digits
+func encodeBase62(num [ksuidSize]byte) string {
+	var text [ksuidEncodedSize]byte
+	for pos := len(text) - 1; pos >= 0; pos-- {
+		// num, rem = num/62, num%62
+		rem := 0
+		for i := range num {
+			v := rem<<8 | int(num[i])
+			num[i] = byte(v / 62)
+			rem = v % 62
+		}
+		text[pos] = base62Alphabet[rem]
+	}
+	return string(text[:])
+}
+
+// incrementBytes adds one to a big-endian value in place, reporting false if it wrapped
+func incrementBytes(b []byte) bool {
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i]++
+		if b[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package id_test
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_KSUIDScheme_Vector(t *testing.T) {
+	scheme := id.NewKSUIDScheme()
+
+	// Act
+	raw, err := scheme.Decode("0ujtsYcgvSTl8PAuAdqWYSMnLOv")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "0669F7EFB5A1CD34B5F99D1154FB6853345C9735", strings.ToUpper(hex.EncodeToString(raw)))
+	assert.True(t, scheme.Time(raw).Equal(time.Date(2017, 10, 10, 4, 0, 47, 0, time.UTC)))
+	encoded, err := scheme.Encode(raw)
+	require.NoError(t, err)
+	assert.Equal(t, "0ujtsYcgvSTl8PAuAdqWYSMnLOv", encoded)
+}
+
+func Test_KSUIDScheme_Decode_Invalid(t *testing.T) {
+	scheme := id.NewKSUIDScheme()
+
+	_, err := scheme.Decode("aWgEPTl1tmebfsQzFP4bxwgy80V")
+	assert.NoError(t, err, "largest KSUID")
+	_, err = scheme.Decode("aWgEPTl1tmebfsQzFP4bxwgy80W")
+	assert.Error(t, err, "overflow")
+	_, err = scheme.Decode("0ujtsYcgvSTl8PAuAdqWYSMnLO")
+	assert.Error(t, err, "short")
+	_, err = scheme.Decode("0ujtsYcgvSTl8PAuAdqWYSMnLO-")
+	assert.ErrorIs(t, err, id.ErrInvalidCharacter{})
+}
+
+func Test_KSUIDScheme_New(t *testing.T) {
+	scheme := id.NewKSUIDScheme()
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	first, err := scheme.New(at, zeroReader{})
+	require.NoError(t, err)
+	second, err := scheme.New(at, zeroReader{})
+	require.NoError(t, err)
+
+	// Assert
+	assert.Len(t, first, 27)
+	assert.Less(t, first, second, "same-second KSUIDs increment")
+	raw, err := scheme.Decode(second)
+	require.NoError(t, err)
+	assert.True(t, scheme.Time(raw).Equal(at))
+
+	_, err = scheme.New(time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC), zeroReader{})
+	assert.Error(t, err, "before the KSUID epoch")
+}
+
+func Test_Generator_WithScheme_KSUID(t *testing.T) {
+	gen := id.NewGenerator(id.WithScheme(id.SchemeKSUID))
+	at := time.Date(2024, 6, 15, 12, 30, 45, 0, time.UTC)
+
+	// Act
+	ids := gen.GenerateRange(at, at.Add(time.Hour), 50)
+
+	// Assert
+	for i, s := range ids {
+		assert.True(t, gen.IsIdValid(s), s)
+		if i > 0 {
+			before, err := gen.IsBefore(ids[i-1], s)
+			require.NoError(t, err)
+			assert.True(t, before)
+		}
+	}
+	ts, err := gen.ExtractTimestamp(ids[0])
+	require.NoError(t, err)
+	assert.True(t, ts.Equal(at))
+	assert.False(t, gen.IsIdValid(id.NewGenerator().Generate()))
+
+	_, err = gen.ToBytes(ids[0])
+	assert.ErrorIs(t, err, id.ErrTooWide)
+	assert.Empty(t, gen.FromBytes([16]byte{}))
+}
@@ -0,0 +1,116 @@
+package id
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// labelsKey is the context key ContextWithLabels stores labels under
+type labelsKey struct{}
+
+// ContextWithLabels returns a copy of ctx carrying labels merged over any it
+// already holds, so middleware can attach a tenant or endpoint once and every
+// GenerateLabeled call beneath it is attributed to them
+func ContextWithLabels(ctx context.Context, labels map[string]string) context.Context {
+	return context.WithValue(ctx, labelsKey{}, mergeLabels(LabelsFromContext(ctx), labels))
+}
+
+// LabelsFromContext returns the labels stored in ctx, or nil
+func LabelsFromContext(ctx context.Context) map[string]string {
+	labels, _ := ctx.Value(labelsKey{}).(map[string]string)
+	return labels
+}
+
+// mergeLabels returns a new map holding base overlaid with extra
+func mergeLabels(base, extra map[string]string) map[string]string {
+	merged := make(map[string]string, len(base)+len(extra))
+	maps.Copy(merged, base)
+	maps.Copy(merged, extra)
+	return merged
+}
+
+// LabeledCount is how many IDs a generator issued under one label set
+type LabeledCount struct {
+	Labels map[string]string
+	Issued uint64
+}
+
+// labeledCounters counts labeled issuance per distinct label set
+type labeledCounters struct {
+	// sets maps a label set's key to its *labeledCounter
+	sets sync.Map
+}
+
+// labeledCounter is the count for one label set
+type labeledCounter struct {
+	labels map[string]string
+	issued atomic.Uint64
+}
+
+// record counts one ID issued under labels
+func (c *labeledCounters) record(labels map[string]string) {
+	key := labelSetKey(labels)
+	counter, ok := c.sets.Load(key)
+	if !ok {
+		counter, _ = c.sets.LoadOrStore(key, &labeledCounter{labels: maps.Clone(labels)})
+	}
+	if lc, ok := counter.(*labeledCounter); ok {
+		lc.issued.Add(1)
+	}
+}
+
+// snapshot returns every label set's count, ordered by label set
+func (c *labeledCounters) snapshot() []LabeledCount {
+	var keys []string
+	counts := make(map[string]LabeledCount)
+	c.sets.Range(func(key, value any) bool {
+		k, _ := key.(string)
+		if lc, ok := value.(*labeledCounter); ok {
+			keys = append(keys, k)
+			counts[k] = LabeledCount{Labels: maps.Clone(lc.labels), Issued: lc.issued.Load()}
+		}
+		return true
+	})
+	slices.Sort(keys)
+
+	result := make([]LabeledCount, len(keys))
+	for i, k := range keys {
+		result[i] = counts[k]
+	}
+	return result
+}
+
+// labelSetKey renders labels in sorted, quoted form, so equal sets share a
+// key and no two sets collide
+func labelSetKey(labels map[string]string) string {
+	var b strings.Builder
+	for _, name := range slices.Sorted(maps.Keys(labels)) {
+		b.WriteString(strconv.Quote(name))
+		b.WriteByte('=')
+		b.WriteString(strconv.Quote(labels[name]))
+		b.WriteByte(',')
+	}
+	return b.String()
+}
+
+// GenerateLabeled provides a new ULID like Generate and attributes it to
+// labels merged over any carried by ctx, such as {"endpoint": "/orders",
+// "tenant": "acme"}. IssuedByLabels reports the counts and
+// OpenMetricsExporter.ExportIssued exports them. Every distinct label set
+// is kept for the generator's lifetime, so use labels with few values.
+func (g *generator) GenerateLabeled(ctx context.Context, labels map[string]string) string {
+	id := g.Generate()
+	g.counters.labeled.record(mergeLabels(LabelsFromContext(ctx), labels))
+	return id
+}
+
+// IssuedByLabels returns how many IDs GenerateLabeled has issued under each
+// label set, ordered by label set
+func (g *generator) IssuedByLabels() []LabeledCount {
+	return g.counters.labeled.snapshot()
+}
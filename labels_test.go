@@ -0,0 +1,53 @@
+package id_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GenerateLabeled(t *testing.T) {
+	gen := id.NewGenerator()
+	ctx := id.ContextWithLabels(context.Background(), map[string]string{"tenant": "acme"})
+
+	// Act
+	for range 2 {
+		assert.True(t, gen.IsIdValid(gen.GenerateLabeled(ctx, map[string]string{"endpoint": "/orders"})))
+	}
+	gen.GenerateLabeled(ctx, map[string]string{"endpoint": "/users", "tenant": "globex"})
+	gen.GenerateLabeled(context.Background(), nil)
+	gen.Generate()
+
+	// Assert
+	assert.Equal(t, []id.LabeledCount{
+		{Labels: map[string]string{}, Issued: 1},
+		{Labels: map[string]string{"endpoint": "/orders", "tenant": "acme"}, Issued: 2},
+		{Labels: map[string]string{"endpoint": "/users", "tenant": "globex"}, Issued: 1},
+	}, gen.IssuedByLabels())
+	assert.Equal(t, uint64(5), gen.Issued())
+}
+
+func Test_ContextWithLabels_Merges(t *testing.T) {
+	ctx := id.ContextWithLabels(context.Background(), map[string]string{"tenant": "acme", "job": "import"})
+
+	// Act
+	inner := id.ContextWithLabels(ctx, map[string]string{"job": "backfill"})
+
+	// Assert
+	assert.Equal(t, map[string]string{"tenant": "acme", "job": "backfill"}, id.LabelsFromContext(inner))
+	assert.Equal(t, map[string]string{"tenant": "acme", "job": "import"}, id.LabelsFromContext(ctx))
+	assert.Nil(t, id.LabelsFromContext(context.Background()))
+}
+
+func Test_IssuedByLabels_SharedByClones(t *testing.T) {
+	gen := id.NewGenerator()
+	clone := gen.WithFormatProfile(id.FormatProfile{})
+
+	// Act
+	clone.GenerateLabeled(context.Background(), map[string]string{"job": "a"})
+
+	// Assert
+	assert.Equal(t, []id.LabeledCount{{Labels: map[string]string{"job": "a"}, Issued: 1}}, gen.IssuedByLabels())
+}
@@ -0,0 +1,45 @@
+package id
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrAlreadyStarted is returned when Start is called on a running component
+	ErrAlreadyStarted = errors.New("already started")
+	// ErrClosed is returned when Start is called on a component that has been closed
+	ErrClosed = errors.New("closed")
+)
+
+// Lifecycle is implemented by components that run background work. Start
+// launches the work, which also stops when ctx is canceled. Close stops the
+// work, flushes any pending state, and waits for background goroutines to
+// exit; it is safe to call more than once and without a prior Start.
+type Lifecycle interface {
+	Start(ctx context.Context) error
+	Close() error
+}
+
+// StartAll starts components in order. If one fails, the ones already
+// started are closed in reverse order and the start error is returned.
+func StartAll(ctx context.Context, components ...Lifecycle) error {
+	for i, c := range components {
+		if err := c.Start(ctx); err != nil {
+			return errors.Join(err, CloseAll(components[:i]...))
+		}
+	}
+	return nil
+}
+
+// CloseAll closes components in reverse order, so dependents shut down
+// before what they depend on, and joins any errors
+func CloseAll(components ...Lifecycle) error {
+	var errs []error
+	for i := len(components) - 1; i >= 0; i-- {
+		if err := components[i].Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,58 @@
+package id_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingComponent struct {
+	name     string
+	log      *[]string
+	startErr error
+}
+
+func (c recordingComponent) Start(context.Context) error {
+	*c.log = append(*c.log, "start "+c.name)
+	return c.startErr
+}
+
+func (c recordingComponent) Close() error {
+	*c.log = append(*c.log, "close "+c.name)
+	return nil
+}
+
+func Test_StartAll_CloseAll(t *testing.T) {
+	var log []string
+	a := recordingComponent{name: "a", log: &log}
+	b := recordingComponent{name: "b", log: &log}
+
+	// Act
+	assert.NoError(t, id.StartAll(context.Background(), a, b))
+	assert.NoError(t, id.CloseAll(a, b))
+
+	// Assert
+	assert.Equal(t, []string{"start a", "start b", "close b", "close a"}, log)
+}
+
+func Test_StartAll_RollsBack(t *testing.T) {
+	var log []string
+	boom := errors.New("boom")
+	a := recordingComponent{name: "a", log: &log}
+	b := recordingComponent{name: "b", log: &log, startErr: boom}
+	c := recordingComponent{name: "c", log: &log}
+
+	// Act
+	err := id.StartAll(context.Background(), a, b, c)
+
+	// Assert
+	assert.ErrorIs(t, err, boom)
+	assert.Equal(t, []string{"start a", "start b", "close a"}, log)
+}
+
+func Test_Lifecycle_BufferedGenerator(t *testing.T) {
+	var _ id.Lifecycle = id.NewBufferedGenerator(1)
+}
@@ -0,0 +1,250 @@
+package id
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/oklog/ulid"
+)
+
+var (
+	// ErrNotMapped is returned when no mapping exists for an ID
+	ErrNotMapped = errors.New("no mapping for ID")
+	// ErrMappingConflict is returned when linking an ID that is already mapped to a different partner
+	ErrMappingConflict = errors.New("ID already mapped to a different partner")
+)
+
+// MappingStore persists UUID↔ULID pairs for a Mapping. IDs passed in are
+// always canonical: lowercase hyphenated UUIDs and uppercase ULIDs.
+type MappingStore interface {
+	Put(uuid, ulid string) error
+	ByUUID(uuid string) (string, bool, error)
+	ByULID(ulid string) (string, bool, error)
+	// Each calls fn for every pair until fn returns an error
+	Each(fn func(uuid, ulid string) error) error
+}
+
+// MemoryMappingStore is an in-process MappingStore
+type MemoryMappingStore struct {
+	mu     sync.RWMutex
+	byUUID map[string]string
+	byULID map[string]string
+}
+
+// NewMemoryMappingStore creates an empty in-process MappingStore
+func NewMemoryMappingStore() *MemoryMappingStore {
+	return &MemoryMappingStore{
+		byUUID: make(map[string]string),
+		byULID: make(map[string]string),
+	}
+}
+
+// Put stores a pair
+func (s *MemoryMappingStore) Put(uuid, ulid string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.byUUID[uuid] = ulid
+	s.byULID[ulid] = uuid
+	return nil
+}
+
+// ByUUID looks up the ULID paired with a UUID
+func (s *MemoryMappingStore) ByUUID(uuid string) (string, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ulid, ok := s.byUUID[uuid]
+	return ulid, ok, nil
+}
+
+// ByULID looks up the UUID paired with a ULID
+func (s *MemoryMappingStore) ByULID(ulid string) (string, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	uuid, ok := s.byULID[ulid]
+	return uuid, ok, nil
+}
+
+// Each calls fn for every stored pair
+func (s *MemoryMappingStore) Each(fn func(uuid, ulid string) error) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for uuid, ulid := range s.byUUID {
+		if err := fn(uuid, ulid); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Mapping maintains a bidirectional UUID↔ULID mapping for entities known by
+// a legacy UUID in old systems and a ULID in new ones. Inputs in any case are
+// canonicalized before they reach the store.
+type Mapping struct {
+	mu    sync.Mutex
+	store MappingStore
+	gen   Generator
+}
+
+// NewMapping creates a Mapping backed by store, minting new ULIDs with gen
+func NewMapping(store MappingStore, gen Generator) *Mapping {
+	return &Mapping{
+		store: store,
+		gen:   gen,
+	}
+}
+
+// Link records that uuid and ulid identify the same entity. Re-linking an
+// existing pair is a no-op; linking either side to a different partner
+// returns ErrMappingConflict.
+func (m *Mapping) Link(uuid, ulid string) error {
+	u, l, err := canonicalPair(uuid, ulid)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.link(u, l)
+}
+
+// ULIDFor returns the ULID linked to a UUID
+func (m *Mapping) ULIDFor(uuid string) (string, error) {
+	raw, err := parseUUID(uuid)
+	if err != nil {
+		return "", err
+	}
+	result, ok, err := m.store.ByUUID(formatUUID(raw))
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrNotMapped, uuid)
+	}
+	return result, nil
+}
+
+// UUIDFor returns the UUID linked to a ULID
+func (m *Mapping) UUIDFor(id string) (string, error) {
+	parsed, err := parseCanonical(id)
+	if err != nil {
+		return "", fmt.Errorf("invalid ULID: %w", err)
+	}
+	result, ok, err := m.store.ByULID(parsed.String())
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrNotMapped, id)
+	}
+	return result, nil
+}
+
+// Ensure returns the ULID linked to a UUID, minting and linking a new one if
+// the UUID has not been seen before
+func (m *Mapping) Ensure(uuid string) (string, error) {
+	raw, err := parseUUID(uuid)
+	if err != nil {
+		return "", err
+	}
+	canonical := formatUUID(raw)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	existing, ok, err := m.store.ByUUID(canonical)
+	if err != nil {
+		return "", err
+	}
+	if ok {
+		return existing, nil
+	}
+
+	minted, err := ulid.Parse(m.gen.Generate())
+	if err != nil {
+		return "", fmt.Errorf("invalid ULID: %w", err)
+	}
+	if err := m.link(canonical, minted.String()); err != nil {
+		return "", err
+	}
+	return minted.String(), nil
+}
+
+// Import reads "uuid,ulid" CSV records from r and links each pair, returning
+// how many records were imported before any error
+func (m *Mapping) Import(r io.Reader) (int, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+
+	count := 0
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return count, nil
+		}
+		if err != nil {
+			return count, err
+		}
+		if err := m.Link(record[0], record[1]); err != nil {
+			line, _ := reader.FieldPos(0)
+			return count, fmt.Errorf("line %d: %w", line, err)
+		}
+		count++
+	}
+}
+
+// Export writes every pair to w as "uuid,ulid" CSV records
+func (m *Mapping) Export(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	err := m.store.Each(func(uuid, ulid string) error {
+		return writer.Write([]string{uuid, ulid})
+	})
+	if err != nil {
+		return err
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// link stores a canonical pair after checking for conflicts; callers must hold m.mu
+func (m *Mapping) link(uuid, id string) error {
+	existingULID, okUUID, err := m.store.ByUUID(uuid)
+	if err != nil {
+		return err
+	}
+	existingUUID, okULID, err := m.store.ByULID(id)
+	if err != nil {
+		return err
+	}
+	if okUUID && existingULID == id && okULID && existingUUID == uuid {
+		return nil
+	}
+	if okUUID {
+		return fmt.Errorf("%w: %s is mapped to %s", ErrMappingConflict, uuid, existingULID)
+	}
+	if okULID {
+		return fmt.Errorf("%w: %s is mapped to %s", ErrMappingConflict, id, existingUUID)
+	}
+	return m.store.Put(uuid, id)
+}
+
+// canonicalPair validates and canonicalizes a UUID/ULID pair
+func canonicalPair(uuid, id string) (string, string, error) {
+	raw, err := parseUUID(uuid)
+	if err != nil {
+		return "", "", err
+	}
+	parsed, err := parseCanonical(id)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid ULID: %w", err)
+	}
+	return formatUUID(raw), parsed.String(), nil
+}
@@ -0,0 +1,86 @@
+package id_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const legacyUUID = "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+
+func Test_Mapping_Link(t *testing.T) {
+	gen := id.NewGenerator()
+	m := id.NewMapping(id.NewMemoryMappingStore(), gen)
+	ulid := gen.Generate()
+
+	// Act
+	require.NoError(t, m.Link(strings.ToUpper(legacyUUID), strings.ToLower(ulid)))
+
+	// Assert
+	got, err := m.ULIDFor(legacyUUID)
+	require.NoError(t, err)
+	assert.Equal(t, ulid, got)
+
+	back, err := m.UUIDFor(ulid)
+	require.NoError(t, err)
+	assert.Equal(t, legacyUUID, back)
+
+	assert.NoError(t, m.Link(legacyUUID, ulid)) // Idempotent
+	assert.ErrorIs(t, m.Link(legacyUUID, gen.Generate()), id.ErrMappingConflict)
+	assert.ErrorIs(t, m.Link("00000000-0000-4000-8000-000000000000", ulid), id.ErrMappingConflict)
+
+	_, err = m.UUIDFor(gen.Generate())
+	assert.ErrorIs(t, err, id.ErrNotMapped)
+	assert.Error(t, m.Link("not-a-uuid", ulid))
+	assert.Error(t, m.Link(legacyUUID, "invalid"))
+	assert.Error(t, m.Link("00000000-0000-4000-8000-000000000001", "01ARZ3NDEKTSV4RRFFQ69G5F!!"))
+	_, err = m.UUIDFor(ulid[:24] + "!!")
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, id.ErrNotMapped)
+}
+
+func Test_Mapping_Ensure(t *testing.T) {
+	gen := id.NewGenerator()
+	m := id.NewMapping(id.NewMemoryMappingStore(), gen)
+
+	// Act
+	first, err := m.Ensure(legacyUUID)
+	require.NoError(t, err)
+	second, err := m.Ensure(strings.ReplaceAll(legacyUUID, "-", ""))
+	require.NoError(t, err)
+
+	// Assert
+	assert.True(t, gen.IsIdValid(first))
+	assert.Equal(t, first, second)
+}
+
+func Test_Mapping_ImportExport(t *testing.T) {
+	gen := id.NewGenerator()
+	source := id.NewMapping(id.NewMemoryMappingStore(), gen)
+	for i := 0; i < 10; i++ {
+		_, err := source.Ensure(strings.Replace(legacyUUID, "f", string(rune('0'+i)), 1))
+		require.NoError(t, err)
+	}
+
+	// Act
+	var buf bytes.Buffer
+	require.NoError(t, source.Export(&buf))
+	target := id.NewMapping(id.NewMemoryMappingStore(), gen)
+	n, err := target.Import(&buf)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 10, n)
+	a, err := source.ULIDFor("047ac10b-58cc-4372-a567-0e02b2c3d479")
+	require.NoError(t, err)
+	b, err := target.ULIDFor("047ac10b-58cc-4372-a567-0e02b2c3d479")
+	require.NoError(t, err)
+	assert.Equal(t, a, b)
+
+	_, err = target.Import(strings.NewReader(legacyUUID + ",invalid\n"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,152 @@
+package id
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+
+	"github.com/oklog/ulid"
+)
+
+// Value implements driver.Valuer, writing the ID in the CurrentStorageProfile form
+func (id ID) Value() (driver.Value, error) {
+	return storedValue(CurrentStorageProfile(), id)
+}
+
+// Scan implements sql.Scanner, accepting 16 bytes in the CurrentStorageProfile
+// layout, a ULID string, base64url, or UUID text
+func (id *ID) Scan(src any) error {
+	return id.scan(CurrentStorageProfile(), src)
+}
+
+// GobEncode implements gob.GobEncoder using the CurrentStorageProfile form.
+// Binary forms are tagged with their profile so GobDecode reads them back
+// correctly whatever profile is current by then.
+func (id ID) GobEncode() ([]byte, error) {
+	return encodeGob(CurrentStorageProfile(), id)
+}
+
+// GobDecode implements gob.GobDecoder, accepting every storage profile form.
+// Untagged 16-byte values are read in the CurrentStorageProfile layout.
+func (id *ID) GobDecode(data []byte) error {
+	parsed, err := decodeGob(CurrentStorageProfile(), data)
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
+
+// storedValue returns id as a driver.Value in profile p
+func storedValue(p StorageProfile, id ID) (driver.Value, error) {
+	b, err := p.Encode(id)
+	if err != nil {
+		return nil, err
+	}
+	if p.IsBinary() {
+		return b, nil
+	}
+	return string(b), nil
+}
+
+// scan reads a database value into id, taking 16 bytes in profile p's layout
+func (id *ID) scan(p StorageProfile, src any) error {
+	switch v := src.(type) {
+	case []byte:
+		parsed, err := p.Decode(v)
+		if err != nil {
+			return err
+		}
+		*id = parsed
+		return nil
+	case string:
+		return id.UnmarshalText([]byte(v))
+	case nil:
+		return errors.New("cannot scan NULL into ID")
+	default:
+		return fmt.Errorf("cannot scan %T into ID", src)
+	}
+}
+
+// encodeGob returns id in profile p, prefixed with the profile if p is binary
+func encodeGob(p StorageProfile, id ID) ([]byte, error) {
+	b, err := p.Encode(id)
+	if err != nil || !p.IsBinary() {
+		return b, err
+	}
+	return append([]byte{byte(p)}, b...), nil
+}
+
+// decodeGob reads a value written by encodeGob, or an untagged one written
+// before binary forms were tagged, which it reads in profile p
+func decodeGob(p StorageProfile, data []byte) (ID, error) {
+	if len(data) == ulidSize+1 {
+		tagged := StorageProfile(data[0])
+		if !tagged.IsBinary() {
+			return ID{}, fmt.Errorf("invalid gob ID: unknown binary profile tag %d", data[0])
+		}
+		return tagged.Decode(data[1:])
+	}
+	return p.Decode(data)
+}
+
+// MarshalText implements encoding.TextMarshaler using the CurrentTextProfile
+func (id ID) MarshalText() ([]byte, error) {
+	if p := CurrentTextProfile(); p != StorageText {
+		return p.Encode(id)
+	}
+	b := make([]byte, ulid.EncodedSize)
+	return b, ulid.ULID(id).MarshalTextTo(b)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting a ULID in
+// either case, unpadded base64url, or UUID text
+func (id *ID) UnmarshalText(text []byte) error {
+	switch len(text) {
+	case ulid.EncodedSize:
+		parsed, err := parseCanonical(string(text))
+		if err != nil {
+			return fmt.Errorf("invalid ULID: %w", err)
+		}
+		*id = ID(parsed)
+		return nil
+	case Base64URLSize:
+		parsed, err := Base64URL.Decode(string(text))
+		if err != nil {
+			return err
+		}
+		*id = parsed
+		return nil
+	}
+
+	parsed, err := parseUUID(string(text))
+	if err != nil {
+		return fmt.Errorf("invalid ID %q: want a ULID, base64url, or UUID", text)
+	}
+	*id = parsed
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler as a string in the CurrentTextProfile
+func (id ID) MarshalJSON() ([]byte, error) {
+	text, err := id.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	b := make([]byte, 0, len(text)+2)
+	b = append(b, '"')
+	b = append(b, text...)
+	return append(b, '"'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. JSON null leaves the ID unchanged.
+func (id *ID) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
+		return fmt.Errorf("invalid ID: want a JSON string, got %s", data)
+	}
+	return id.UnmarshalText(data[1 : len(data)-1])
+}
@@ -0,0 +1,369 @@
+package id_test
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	_ driver.Valuer            = id.ID{}
+	_ sql.Scanner              = (*id.ID)(nil)
+	_ json.Marshaler           = id.ID{}
+	_ json.Unmarshaler         = (*id.ID)(nil)
+	_ encoding.TextMarshaler   = id.ID{}
+	_ encoding.TextUnmarshaler = (*id.ID)(nil)
+	_ gob.GobEncoder           = id.ID{}
+	_ gob.GobDecoder           = (*id.ID)(nil)
+)
+
+// sqlServerOrder lists byte positions in SQL Server's UNIQUEIDENTIFIER
+// comparison order, most significant first
+var sqlServerOrder = []int{10, 11, 12, 13, 14, 15, 8, 9, 6, 7, 4, 5, 0, 1, 2, 3}
+
+func orderedBytes(value id.ID) []byte {
+	out := make([]byte, 16)
+	for i, pos := range sqlServerOrder {
+		out[pos] = value[i]
+	}
+	return out
+}
+
+func compareSQLServer(a, b []byte) int {
+	for _, pos := range sqlServerOrder {
+		if c := int(a[pos]) - int(b[pos]); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+func withStorageProfile(t *testing.T, p id.StorageProfile) {
+	t.Helper()
+	prev := id.CurrentStorageProfile()
+	id.SetStorageProfile(p)
+	t.Cleanup(func() { id.SetStorageProfile(prev) })
+}
+
+func withTextProfile(t *testing.T, p id.StorageProfile) {
+	t.Helper()
+	prev := id.CurrentTextProfile()
+	id.SetTextProfile(p)
+	t.Cleanup(func() { id.SetTextProfile(prev) })
+}
+
+func Test_ID_Value(t *testing.T) {
+	gen := id.NewGenerator()
+	value := gen.GenerateID()
+	uuid, err := gen.ToUUID(value.String())
+	require.NoError(t, err)
+
+	tests := []struct {
+		profile id.StorageProfile
+		want    driver.Value
+	}{
+		{id.StorageText, value.String()},
+		{id.StorageBinary, value[:]},
+		{id.StorageUUID, uuid},
+		{id.StorageBase64, id.Base64URL.Encode(value)},
+		{id.StorageOrderedBinary, orderedBytes(value)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.profile.String(), func(t *testing.T) {
+			withStorageProfile(t, tt.profile)
+
+			// Act
+			got, err := value.Value()
+			require.NoError(t, err)
+			var scanned id.ID
+			require.NoError(t, scanned.Scan(got))
+
+			// Assert
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, value, scanned)
+		})
+	}
+}
+
+func Test_ID_Scan(t *testing.T) {
+	value := id.NewGenerator().GenerateID()
+
+	var fromBytes, fromText, fromLower id.ID
+	require.NoError(t, fromBytes.Scan(value[:]))
+	require.NoError(t, fromText.Scan([]byte(value.String())))
+	require.NoError(t, fromLower.Scan(strings.ToLower(value.String())))
+	assert.Equal(t, value, fromBytes)
+	assert.Equal(t, value, fromText)
+	assert.Equal(t, value, fromLower)
+
+	var bad id.ID
+	assert.Error(t, bad.Scan(nil))
+	assert.Error(t, bad.Scan(42))
+	assert.Error(t, bad.Scan("nope"))
+	assert.Error(t, bad.Scan([]byte{1, 2, 3}))
+}
+
+func Test_ID_JSON(t *testing.T) {
+	type record struct {
+		ID     id.ID  `json:"id"`
+		Parent *id.ID `json:"parent"`
+	}
+	value := id.NewGenerator().GenerateID()
+
+	// Act
+	data, err := json.Marshal(record{ID: value})
+	require.NoError(t, err)
+	var decoded record
+	require.NoError(t, json.Unmarshal(data, &decoded))
+
+	// Assert
+	assert.JSONEq(t, `{"id":"`+value.String()+`","parent":null}`, string(data))
+	assert.Equal(t, value, decoded.ID)
+	assert.Nil(t, decoded.Parent)
+
+	var bad id.ID
+	assert.Error(t, json.Unmarshal([]byte(`123`), &bad))
+	assert.Error(t, json.Unmarshal([]byte(`"nope"`), &bad))
+}
+
+func Test_ID_Text(t *testing.T) {
+	value := id.NewGenerator().GenerateID()
+
+	// Act
+	text, err := value.MarshalText()
+	require.NoError(t, err)
+	var decoded id.ID
+	require.NoError(t, decoded.UnmarshalText(text))
+
+	// Assert
+	assert.Equal(t, value.String(), string(text))
+	assert.Equal(t, value, decoded)
+}
+
+func Test_ID_UnmarshalText_RejectsInvalidCharacters(t *testing.T) {
+	const invalid = "01ARZ3NDEKTSV4RRFFQ69G5F!!"
+	var decoded id.ID
+
+	// Act
+	err := decoded.UnmarshalText([]byte(invalid))
+
+	// Assert
+	var charErr id.ErrInvalidCharacter
+	require.ErrorAs(t, err, &charErr)
+	assert.Equal(t, 24, charErr.Pos)
+	assert.Error(t, json.Unmarshal([]byte(`"`+invalid+`"`), &decoded))
+	assert.Error(t, decoded.Scan(invalid))
+	assert.Error(t, decoded.Scan("01ARZ3NDEKTSV4RRFFQ69G5FAU"), "U is outside the alphabet")
+	assert.Equal(t, id.ID{}, decoded)
+}
+
+func Test_ID_Text_Profiles(t *testing.T) {
+	value := id.NewGenerator().GenerateID()
+	uuid := mustToUUID(t, value)
+
+	tests := []struct {
+		profile id.StorageProfile
+		want    string
+	}{
+		{id.StorageText, value.String()},
+		{id.StorageBinary, value.String()},
+		{id.StorageUUID, uuid},
+		{id.StorageBase64, id.Base64URL.Encode(value)},
+		{id.StorageOrderedBinary, value.String()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.profile.String(), func(t *testing.T) {
+			withTextProfile(t, tt.profile)
+
+			// Act
+			data, err := json.Marshal(value)
+			require.NoError(t, err)
+			var decoded id.ID
+			require.NoError(t, json.Unmarshal(data, &decoded))
+
+			// Assert
+			assert.Equal(t, `"`+tt.want+`"`, string(data))
+			assert.Equal(t, value, decoded)
+		})
+	}
+}
+
+func Test_StorageAndTextProfiles_AreIndependent(t *testing.T) {
+	value := id.NewGenerator().GenerateID()
+	withStorageProfile(t, id.StorageBinary)
+	withTextProfile(t, id.StorageUUID)
+
+	// Act
+	stored, err := value.Value()
+	require.NoError(t, err)
+	data, err := json.Marshal(value)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, value[:], stored)
+	assert.Equal(t, `"`+mustToUUID(t, value)+`"`, string(data))
+	assert.Equal(t, id.StorageBinary, id.CurrentStorageProfile())
+	assert.Equal(t, id.StorageUUID, id.CurrentTextProfile())
+}
+
+func Test_ID_Gob(t *testing.T) {
+	type record struct {
+		ID id.ID
+	}
+	value := id.NewGenerator().GenerateID()
+
+	for _, p := range []id.StorageProfile{id.StorageText, id.StorageBinary, id.StorageUUID, id.StorageBase64, id.StorageOrderedBinary} {
+		t.Run(p.String(), func(t *testing.T) {
+			withStorageProfile(t, p)
+			want, err := p.Encode(value)
+			require.NoError(t, err)
+
+			// Act
+			var buf bytes.Buffer
+			require.NoError(t, gob.NewEncoder(&buf).Encode(record{ID: value}))
+			encoded := buf.Bytes()
+			var decoded record
+			require.NoError(t, gob.NewDecoder(&buf).Decode(&decoded))
+
+			// Assert
+			assert.True(t, bytes.Contains(encoded, want))
+			assert.Equal(t, value, decoded.ID)
+		})
+	}
+}
+
+func Test_ID_Gob_SurvivesProfileSwitch(t *testing.T) {
+	value := id.NewGenerator().GenerateID()
+	withStorageProfile(t, id.StorageOrderedBinary)
+	var buf bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&buf).Encode(value))
+
+	// Act
+	id.SetStorageProfile(id.StorageBinary)
+	var decoded id.ID
+	err := gob.NewDecoder(&buf).Decode(&decoded)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, value, decoded)
+}
+
+func Test_ProfiledID(t *testing.T) {
+	value := id.NewGenerator().GenerateID()
+	withStorageProfile(t, id.StorageBinary)
+	withTextProfile(t, id.StorageUUID)
+	ordered, err := id.StorageOrderedBinary.Encode(value)
+	require.NoError(t, err)
+
+	// Act
+	stored, err := id.ProfiledID{Profile: id.StorageOrderedBinary, ID: value}.Value()
+	require.NoError(t, err)
+	scanned := id.ProfiledID{Profile: id.StorageOrderedBinary}
+	require.NoError(t, scanned.Scan(ordered))
+	data, err := json.Marshal(id.ProfiledID{Profile: id.StorageBase64, ID: value})
+	require.NoError(t, err)
+	var fromJSON id.ProfiledID
+	require.NoError(t, json.Unmarshal(data, &fromJSON))
+	var buf bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&buf).Encode(id.ProfiledID{Profile: id.StorageOrderedBinary, ID: value}))
+	var fromGob id.ProfiledID
+	require.NoError(t, gob.NewDecoder(&buf).Decode(&fromGob))
+
+	// Assert
+	assert.Equal(t, ordered, stored)
+	assert.Equal(t, value, scanned.ID)
+	assert.Equal(t, `"`+id.Base64URL.Encode(value)+`"`, string(data))
+	assert.Equal(t, value, fromJSON.ID)
+	assert.Equal(t, value, fromGob.ID)
+	text, err := id.ProfiledID{Profile: id.StorageBinary, ID: value}.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, value.String(), string(text))
+	assert.Error(t, new(id.ProfiledID).GobDecode(append([]byte{byte(id.StorageUUID)}, value[:]...)))
+}
+
+func Test_StorageProfile_Decode_AcceptsEveryTextForm(t *testing.T) {
+	value := id.NewGenerator().GenerateID()
+	forms := []string{
+		value.String(),
+		strings.ToLower(value.String()),
+		mustToUUID(t, value),
+		id.Base64URL.Encode(value),
+	}
+
+	for _, p := range []id.StorageProfile{id.StorageText, id.StorageBinary, id.StorageOrderedBinary} {
+		for _, form := range forms {
+			// Act
+			got, err := p.Decode([]byte(form))
+
+			// Assert
+			require.NoError(t, err, "%s/%s", p, form)
+			assert.Equal(t, value, got)
+		}
+	}
+
+	_, err := id.StorageProfile(99).Encode(value)
+	assert.Error(t, err)
+	_, err = id.StorageBase64.Decode([]byte("!!!!!!!!!!!!!!!!!!!!!!"))
+	assert.Error(t, err)
+}
+
+func Test_StorageOrderedBinary_SortsBySQLServerOrder(t *testing.T) {
+	gen := id.NewGenerator()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var prev []byte
+	for i := range 100 {
+		value := gen.GenerateIDWithTime(base.Add(time.Duration(i) * time.Millisecond))
+
+		// Act
+		got, err := id.StorageOrderedBinary.Encode(value)
+		require.NoError(t, err)
+		back, err := id.StorageOrderedBinary.Decode(got)
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, value, back)
+		if prev != nil {
+			assert.Positive(t, compareSQLServer(got, prev), "index %d", i)
+		}
+		prev = got
+	}
+}
+
+func Test_ParseStorageProfile(t *testing.T) {
+	tests := map[string]id.StorageProfile{
+		"text":           id.StorageText,
+		"text-26":        id.StorageText,
+		"binary-16":      id.StorageBinary,
+		" UUID-Text ":    id.StorageUUID,
+		"base64-22":      id.StorageBase64,
+		"ordered-binary": id.StorageOrderedBinary,
+	}
+	for name, want := range tests {
+		// Act
+		got, err := id.ParseStorageProfile(name)
+
+		// Assert
+		require.NoError(t, err, name)
+		assert.Equal(t, want, got, name)
+	}
+
+	_, err := id.ParseStorageProfile("bson")
+	assert.Error(t, err)
+}
+
+func mustToUUID(t *testing.T, value id.ID) string {
+	t.Helper()
+	uuid, err := id.NewGenerator().ToUUID(value.String())
+	require.NoError(t, err)
+	return uuid
+}
@@ -0,0 +1,160 @@
+package id
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/oklog/ulid"
+)
+
+// DefaultMembershipBucket is the bucket width used when NewMembership is given a non-positive width
+const DefaultMembershipBucket = time.Minute
+
+// Membership is a compact set of ULIDs for memory-constrained services.
+// IDs are bucketed by timestamp and each one is stored as an 8-byte entry
+// holding its millisecond offset within the bucket and a 32-bit hash of its
+// entropy, instead of the 26-byte string.
+//
+// Contains is probabilistic: two distinct ULIDs from the same millisecond
+// whose entropy hashes collide are indistinguishable. CountInRange is exact.
+//
+// A Membership is safe for concurrent reads but not for concurrent writes.
+type Membership struct {
+	widthMs uint64
+	buckets map[uint64][]uint64
+	count   int
+}
+
+// NewMembership creates an empty Membership with the given bucket width.
+// Widths are clamped to the range 1ms to ~49 days.
+func NewMembership(bucketWidth time.Duration) *Membership {
+	if bucketWidth <= 0 {
+		bucketWidth = DefaultMembershipBucket
+	}
+	widthMs := uint64(bucketWidth.Milliseconds()) //nolint:gosec // G115: bucketWidth is positive
+	if widthMs == 0 {
+		widthMs = 1
+	}
+	if widthMs > math.MaxUint32 {
+		widthMs = math.MaxUint32
+	}
+
+	return &Membership{
+		widthMs: widthMs,
+		buckets: make(map[uint64][]uint64),
+	}
+}
+
+// Add records a ULID. Adding the same ULID twice is a no-op.
+func (m *Membership) Add(id string) error {
+	parsed, err := parseCanonical(id)
+	if err != nil {
+		return fmt.Errorf("invalid ULID: %w", err)
+	}
+
+	key, entry := m.entry(parsed)
+	bucket := m.buckets[key]
+	pos, found := slices.BinarySearch(bucket, entry)
+	if found {
+		return nil
+	}
+
+	m.buckets[key] = slices.Insert(bucket, pos, entry)
+	m.count++
+	return nil
+}
+
+// Contains reports whether a ULID has probably been added.
+// False positives are possible; false negatives are not.
+func (m *Membership) Contains(id string) bool {
+	parsed, err := parseCanonical(id)
+	if err != nil {
+		return false
+	}
+
+	key, entry := m.entry(parsed)
+	_, found := slices.BinarySearch(m.buckets[key], entry)
+	return found
+}
+
+// CountInRange returns the number of added ULIDs whose timestamps fall
+// within [start, end] at millisecond precision
+func (m *Membership) CountInRange(start, end time.Time) int {
+	if end.Before(start) {
+		return 0
+	}
+
+	lo := clampTimestamp(start)
+	hi := clampTimestamp(end)
+	count := 0
+
+	for key, bucket := range m.buckets {
+		bucketStart := key * m.widthMs
+		bucketEnd := bucketStart + m.widthMs - 1
+		if bucketEnd < lo || bucketStart > hi {
+			continue
+		}
+		if bucketStart >= lo && bucketEnd <= hi {
+			count += len(bucket)
+			continue
+		}
+
+		// Entries sort by offset first, so the range is a contiguous run
+		from := uint64(0)
+		if lo > bucketStart {
+			from = (lo - bucketStart) << 32
+		}
+		to := uint64(math.MaxUint64)
+		if hi < bucketEnd {
+			to = (hi-bucketStart)<<32 | math.MaxUint32
+		}
+		i, _ := slices.BinarySearch(bucket, from)
+		j, found := slices.BinarySearch(bucket, to)
+		if found {
+			j++
+		}
+		count += j - i
+	}
+
+	return count
+}
+
+// Len returns the number of distinct entries recorded
+func (m *Membership) Len() int {
+	return m.count
+}
+
+// entry returns the bucket key and the packed offset/fingerprint entry for a ULID
+func (m *Membership) entry(u ulid.ULID) (uint64, uint64) {
+	ms := u.Time()
+	offset := ms % m.widthMs
+	return ms / m.widthMs, offset<<32 | uint64(entropyHash(u))
+}
+
+// entropyHash returns the 32-bit FNV-1a hash of a ULID's entropy bytes
+func entropyHash(u ulid.ULID) uint32 {
+	const (
+		offset32 = 2166136261
+		prime32  = 16777619
+	)
+	h := uint32(offset32)
+	for _, b := range u[6:] {
+		h ^= uint32(b)
+		h *= prime32
+	}
+	return h
+}
+
+// clampTimestamp converts t to ULID milliseconds, clamped to the representable range
+func clampTimestamp(t time.Time) uint64 {
+	if t.Before(time.Unix(0, 0)) {
+		return 0
+	}
+	ms := ulid.Timestamp(t)
+	if ms > ulid.MaxTime() {
+		return ulid.MaxTime()
+	}
+	return ms
+}
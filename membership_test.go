@@ -0,0 +1,71 @@
+package id_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Membership_Contains(t *testing.T) {
+	gen := id.NewGenerator()
+	m := id.NewMembership(time.Minute)
+	ids := gen.GenerateBatch(100)
+
+	// Act
+	for _, id := range ids {
+		require.NoError(t, m.Add(id))
+	}
+	require.NoError(t, m.Add(ids[0])) // Duplicate
+
+	// Assert
+	assert.Equal(t, 100, m.Len())
+	for _, id := range ids {
+		assert.True(t, m.Contains(id))
+	}
+	assert.False(t, m.Contains(gen.Generate()))
+	assert.False(t, m.Contains("invalid"))
+	assert.Error(t, m.Add("invalid"))
+}
+
+func Test_Membership_RejectsInvalidCharacters(t *testing.T) {
+	m := id.NewMembership(time.Minute)
+
+	// Act
+	err := m.Add("01ARZ3NDEKTSV4RRFFQ69G5F!!")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, 0, m.Len())
+	require.NoError(t, m.Add("01ARZ3NDEKTSV4RRFFQ69G5FZZ"))
+	assert.False(t, m.Contains("01ARZ3NDEKTSV4RRFFQ69G5F!!"))
+}
+
+func Test_Membership_CountInRange(t *testing.T) {
+	gen := id.NewGenerator()
+	m := id.NewMembership(10 * time.Minute)
+	start := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 60; i++ {
+		require.NoError(t, m.Add(gen.GenerateWithTime(start.Add(time.Duration(i)*time.Minute))))
+	}
+
+	// Act & Assert
+	assert.Equal(t, 60, m.CountInRange(start, start.Add(time.Hour)))
+	assert.Equal(t, 16, m.CountInRange(start.Add(5*time.Minute), start.Add(20*time.Minute)))
+	assert.Equal(t, 1, m.CountInRange(start.Add(7*time.Minute), start.Add(7*time.Minute)))
+	assert.Equal(t, 0, m.CountInRange(start.Add(-time.Hour), start.Add(-time.Minute)))
+	assert.Equal(t, 0, m.CountInRange(start.Add(time.Hour), start))
+}
+
+func Test_NewMembership_DefaultWidth(t *testing.T) {
+	m := id.NewMembership(0)
+
+	// Act
+	require.NoError(t, m.Add(id.NewGenerator().Generate()))
+
+	// Assert
+	assert.Equal(t, 1, m.CountInRange(time.Now().Add(-time.Minute), time.Now().Add(time.Minute)))
+}
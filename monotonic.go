@@ -0,0 +1,94 @@
+package id
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/oklog/ulid"
+)
+
+// ErrMonotonicOverflow is returned by a MonotonicGenerator that has exhausted
+// the entropy space of the current millisecond and is not set to wait
+var ErrMonotonicOverflow = errors.New("monotonic entropy exhausted for this millisecond")
+
+// MonotonicOptions configures a MonotonicGenerator. The zero value is usable.
+type MonotonicOptions struct {
+	// Entropy is the random source; nil uses the default fast source
+	Entropy io.Reader
+	// Increment bounds the random step between IDs in the same millisecond;
+	// 0 uses the ulid default of 2^32. Smaller steps make overflow rarer but
+	// successive IDs easier to guess.
+	Increment uint64
+	// WaitOnOverflow waits for the next millisecond instead of returning
+	// ErrMonotonicOverflow when a millisecond's entropy is exhausted
+	WaitOnOverflow bool
+	// Clock supplies the current time and, with WaitOnOverflow, the wait for
+	// the next millisecond; nil uses SystemClock
+	Clock Clock
+}
+
+// MonotonicGenerator issues strictly increasing IDs: every ID sorts after the
+// one before it, even within a millisecond or when the clock steps backwards.
+// Use a single MonotonicGenerator per process for a process-wide guarantee.
+// It is safe for concurrent use.
+type MonotonicGenerator struct {
+	mu      sync.Mutex
+	entropy io.Reader
+	wait    bool
+	clock   Clock
+	last    ulid.ULID
+}
+
+// NewMonotonicGenerator creates a strictly monotonic generator
+func NewMonotonicGenerator(opts MonotonicOptions) *MonotonicGenerator {
+	source := opts.Entropy
+	if source == nil {
+		source = NewSourceReader(newDefaultSource())
+	}
+	return &MonotonicGenerator{
+		entropy: ulid.Monotonic(source, opts.Increment),
+		wait:    opts.WaitOnOverflow,
+		clock:   clockOrSystem(opts.Clock),
+	}
+}
+
+// Next returns the next ID in canonical form
+func (g *MonotonicGenerator) Next() (string, error) {
+	next, err := g.NextID()
+	if err != nil {
+		return "", err
+	}
+	return next.String(), nil
+}
+
+// NextID returns the next ID. If the clock is behind the last ID issued, the
+// last ID's millisecond is reused. When that millisecond's entropy runs out
+// it returns ErrMonotonicOverflow or, with WaitOnOverflow, waits for the
+// clock to reach the next millisecond.
+func (g *MonotonicGenerator) NextID() (ID, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	ms := max(ulid.Timestamp(g.clock.Now()), g.last.Time())
+	for {
+		u, err := ulid.New(ms, g.entropy)
+		if err != nil && !errors.Is(err, ulid.ErrMonotonicOverflow) {
+			return ID{}, fmt.Errorf("entropy source failed: %w", err)
+		}
+		// Entropy wraps around once exhausted, so a non-increasing ID is an overflow too
+		if err == nil && u.Compare(g.last) > 0 {
+			g.last = u
+			return ID(u), nil
+		}
+		if !g.wait {
+			return ID{}, fmt.Errorf("%w: %d", ErrMonotonicOverflow, ms)
+		}
+
+		for ms <= g.last.Time() {
+			sleep(g.clock, ulid.Time(g.last.Time()+1).Sub(g.clock.Now()))
+			ms = ulid.Timestamp(g.clock.Now())
+		}
+	}
+}
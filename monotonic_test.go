@@ -0,0 +1,139 @@
+package id_test
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// maxEntropy yields all-ones entropy so the first increment overflows
+type maxEntropy struct{}
+
+func (maxEntropy) Read(p []byte) (int, error) {
+	copy(p, bytes.Repeat([]byte{0xFF}, len(p)))
+	return len(p), nil
+}
+
+func Test_MonotonicGenerator_StrictlyIncreasing(t *testing.T) {
+	fixed := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	gen := id.NewMonotonicGenerator(id.MonotonicOptions{Clock: id.NewFakeClock(fixed)})
+
+	// Act
+	prev, err := gen.NextID()
+	require.NoError(t, err)
+	for i := 0; i < 1000; i++ {
+		next, err := gen.NextID()
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, 1, next.Compare(prev))
+		assert.True(t, next.Timestamp().Equal(fixed))
+		prev = next
+	}
+}
+
+func Test_MonotonicGenerator_ClockRegression(t *testing.T) {
+	clock := id.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 10, 0, time.UTC))
+	gen := id.NewMonotonicGenerator(id.MonotonicOptions{Clock: clock})
+	first, err := gen.Next()
+	require.NoError(t, err)
+
+	// Act
+	clock.Advance(-5 * time.Second)
+	second, err := gen.Next()
+	require.NoError(t, err)
+
+	// Assert
+	assert.Less(t, first, second)
+	assert.Equal(t, first[:10], second[:10])
+}
+
+func Test_MonotonicGenerator_Overflow(t *testing.T) {
+	fixed := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	gen := id.NewMonotonicGenerator(id.MonotonicOptions{
+		Entropy:   maxEntropy{},
+		Increment: 1,
+		Clock:     id.NewFakeClock(fixed),
+	})
+	_, err := gen.NextID()
+	require.NoError(t, err)
+
+	// Act
+	_, err = gen.NextID()
+	_, again := gen.NextID()
+
+	// Assert
+	assert.ErrorIs(t, err, id.ErrMonotonicOverflow)
+	assert.ErrorIs(t, again, id.ErrMonotonicOverflow)
+}
+
+func Test_MonotonicGenerator_WaitOnOverflow(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := id.NewFakeClock(now)
+	gen := id.NewMonotonicGenerator(id.MonotonicOptions{
+		Entropy:        maxEntropy{},
+		Increment:      1,
+		WaitOnOverflow: true,
+		Clock:          clock,
+	})
+	first, err := gen.NextID()
+	require.NoError(t, err)
+
+	// Act
+	second, err := gen.NextID()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, second.Compare(first))
+	assert.Equal(t, first.Time()+1, second.Time())
+	assert.Equal(t, now.Add(time.Millisecond), clock.Now(), "the wait advanced the fake clock")
+}
+
+func Test_MonotonicGenerator_EntropyFailure(t *testing.T) {
+	gen := id.NewMonotonicGenerator(id.MonotonicOptions{Entropy: failingReader{}})
+
+	// Act
+	_, err := gen.NextID()
+
+	// Assert
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, id.ErrMonotonicOverflow)
+}
+
+func Test_MonotonicGenerator_Concurrent(t *testing.T) {
+	gen := id.NewMonotonicGenerator(id.MonotonicOptions{})
+	results := make([][]id.ID, 4)
+
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 500 {
+				next, err := gen.NextID()
+				if err == nil {
+					results[i] = append(results[i], next)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	seen := make(map[id.ID]bool)
+	for _, ids := range results {
+		require.Len(t, ids, 500)
+		for j, next := range ids {
+			assert.False(t, seen[next])
+			seen[next] = true
+			if j > 0 {
+				assert.Equal(t, 1, next.Compare(ids[j-1]))
+			}
+		}
+	}
+}
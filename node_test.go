@@ -0,0 +1,97 @@
+package id_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewGeneratorWithNode(t *testing.T) {
+	for _, node := range []uint16{0, 42, id.MaxNode} {
+		gen, err := id.NewGeneratorWithNode(node)
+		require.NoError(t, err)
+		assert.Equal(t, node, gen.Node())
+
+		// Act
+		ids := append(gen.GenerateBatch(10), gen.GenerateRange(time.Now().Add(-time.Hour), time.Now(), 5)...)
+
+		// Assert
+		for _, ulid := range ids {
+			assert.True(t, gen.IsIdValid(ulid))
+			extracted, err := id.ExtractNode(ulid)
+			require.NoError(t, err)
+			assert.Equal(t, node, extracted)
+		}
+	}
+}
+
+func Test_NewGeneratorWithNode_Distinct(t *testing.T) {
+	// Same clock and entropy, so only the node bits can tell the IDs apart
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	base1 := id.NewGeneratorWithEntropy(zeroReader{})
+	base2 := id.NewGeneratorWithEntropy(zeroReader{})
+	gen1, err := id.NewNodeGeneratorWithBase(1, base1)
+	require.NoError(t, err)
+	gen2, err := id.NewNodeGeneratorWithBase(2, base2)
+	require.NoError(t, err)
+
+	// Act & Assert
+	assert.NotEqual(t, gen1.GenerateWithTime(at), gen2.GenerateWithTime(at))
+}
+
+func Test_NewGeneratorWithNode_Errors(t *testing.T) {
+	// Act
+	_, err := id.NewGeneratorWithNode(id.MaxNode + 1)
+
+	// Assert
+	require.ErrorIs(t, err, id.ErrInvalidNode)
+	_, err = id.ExtractNode("invalid")
+	assert.Error(t, err)
+}
+
+func Test_WithNode_CombinesWithRegion(t *testing.T) {
+	regions, err := id.NewRegionMap(id.MaxRegionBits, map[string]uint16{"eu-west": 0xBEEF})
+	require.NoError(t, err)
+	gen := id.NewGenerator(id.WithRegion(regions, "eu-west"), id.WithNode(id.MaxNode))
+
+	// Act
+	ulid := gen.Generate()
+
+	// Assert
+	region, err := regions.ExtractRegion(ulid)
+	require.NoError(t, err)
+	assert.Equal(t, "eu-west", region)
+	node, err := id.ExtractNode(ulid)
+	require.NoError(t, err)
+	assert.Equal(t, uint16(id.MaxNode), node)
+}
+
+func Test_NodeGenerator_EveryPathStamps(t *testing.T) {
+	gen, err := id.NewGeneratorWithNode(7)
+	require.NoError(t, err)
+
+	tried, err := gen.TryGenerateBatch(2)
+	require.NoError(t, err)
+	ids := append(tried, gen.GenerateID().String(), <-gen.Stream(context.Background()))
+	fromUUID, err := gen.FromUUID(gen.GenerateUUIDv7())
+	require.NoError(t, err)
+	ids = append(ids, fromUUID)
+
+	for _, ulid := range ids {
+		// Act
+		node, err := id.ExtractNode(ulid)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, uint16(7), node, ulid)
+	}
+}
+
+func Test_WithNode_Invalid(t *testing.T) {
+	// Act & Assert
+	assert.Panics(t, func() { id.WithNode(id.MaxNode + 1) })
+}
@@ -0,0 +1,78 @@
+package id
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/oklog/ulid"
+)
+
+// Normalizer is one step of a Pipeline: it rewrites raw input toward the
+// canonical form or rejects it
+type Normalizer func(string) (string, error)
+
+// Pipeline applies Normalizers in order, so each intake surface (API, CSV
+// upload, manual entry) can choose how strict to be before an ID is parsed
+type Pipeline []Normalizer
+
+// LenientPipeline accepts IDs as people type them: surrounding space,
+// hyphen grouping, any case, and the Crockford look-alikes I, L, and O
+var LenientPipeline = Pipeline{Trim, StripHyphens, Uppercase, HomoglyphFix}
+
+// Normalize runs every step over input, stopping at the first error
+func (p Pipeline) Normalize(input string) (string, error) {
+	s := input
+	for _, step := range p {
+		var err error
+		if s, err = step(s); err != nil {
+			return "", err
+		}
+	}
+	return s, nil
+}
+
+// Trim removes leading and trailing white space
+func Trim(s string) (string, error) {
+	return strings.TrimSpace(s), nil
+}
+
+// StripHyphens removes hyphens, which Crockford Base32 ignores for readability
+func StripHyphens(s string) (string, error) {
+	return strings.ReplaceAll(s, "-", ""), nil
+}
+
+// Uppercase converts letters to the canonical uppercase form
+func Uppercase(s string) (string, error) {
+	return strings.ToUpper(s), nil
+}
+
+// homoglyphs maps characters Crockford Base32 excludes to the digits they are mistaken for
+var homoglyphs = strings.NewReplacer("I", "1", "i", "1", "L", "1", "l", "1", "O", "0", "o", "0")
+
+// HomoglyphFix replaces I and L with 1 and O with 0, as Crockford Base32
+// decoding specifies, repairing IDs that were read aloud or retyped
+func HomoglyphFix(s string) (string, error) {
+	return homoglyphs.Replace(s), nil
+}
+
+// ChecksumVerify requires a trailing Crockford check symbol, as emitted by a
+// FormatProfile with Checksum set, verifies it, and strips it. It expects
+// canonical input, so place it after Uppercase and StripHyphens.
+func ChecksumVerify(s string) (string, error) {
+	if len(s) != ulid.EncodedSize+1 {
+		return "", fmt.Errorf("missing check symbol: want %d characters, got %d", ulid.EncodedSize+1, len(s))
+	}
+	body := s[:ulid.EncodedSize]
+	if _, err := parseChecked(body, s[ulid.EncodedSize]); err != nil {
+		return "", err
+	}
+	return body, nil
+}
+
+// WithNormalization runs pipeline over every ID the generator parses, so
+// validation, timestamps, comparison, and conversion all accept the same input
+func WithNormalization(pipeline Pipeline) Option {
+	return func(g *generator) {
+		g.normalize = pipeline
+	}
+}
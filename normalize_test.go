@@ -0,0 +1,84 @@
+package id_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Pipeline_Normalize(t *testing.T) {
+	// Act
+	normalized, err := id.LenientPipeline.Normalize("  01arz3ndek-tsv4rrffq6-9g5fav\n")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "01ARZ3NDEKTSV4RRFFQ69G5FAV", normalized)
+}
+
+func Test_HomoglyphFix(t *testing.T) {
+	fixed, err := id.HomoglyphFix("OIL oil")
+
+	require.NoError(t, err)
+	assert.Equal(t, "011 011", fixed)
+}
+
+func Test_ChecksumVerify(t *testing.T) {
+	gen := id.NewGenerator()
+	ulid := gen.Generate()
+	checked, err := id.Reformat(ulid, id.FormatProfile{Checksum: true})
+	require.NoError(t, err)
+
+	// Act
+	stripped, err := id.ChecksumVerify(checked)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, ulid, stripped)
+
+	_, err = id.ChecksumVerify(ulid)
+	assert.Error(t, err)
+
+	wrong := checked[:26] + "*"
+	if wrong == checked {
+		wrong = checked[:26] + "~"
+	}
+	_, err = id.ChecksumVerify(wrong)
+	assert.ErrorIs(t, err, id.ErrChecksumMismatch)
+}
+
+func Test_WithNormalization(t *testing.T) {
+	strict := id.NewGenerator()
+	lenient := id.NewGeneratorWithOptions(id.WithNormalization(id.LenientPipeline))
+	ulid := strict.Generate()
+	typed := " " + strings.ToLower(ulid[:13]) + "-" + ulid[13:] + " "
+	typed = strings.Replace(typed, "0", "o", 1)
+
+	// Act
+	normalized, err := lenient.ValidateAndNormalize(typed)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, ulid, normalized)
+	assert.True(t, lenient.IsIdValid(typed))
+	assert.False(t, strict.IsIdValid(typed))
+
+	ts, err := lenient.ExtractTimestamp(typed)
+	require.NoError(t, err)
+	want, err := strict.ExtractTimestamp(ulid)
+	require.NoError(t, err)
+	assert.True(t, want.Equal(ts))
+}
+
+func Test_WithNormalization_RequiresChecksum(t *testing.T) {
+	gen := id.NewGeneratorWithOptions(id.WithNormalization(id.Pipeline{id.Trim, id.Uppercase, id.ChecksumVerify}))
+	ulid := gen.Generate()
+	checked, err := id.Reformat(ulid, id.FormatProfile{Checksum: true, Case: id.CaseLower})
+	require.NoError(t, err)
+
+	// Assert
+	assert.True(t, gen.IsIdValid(checked))
+	assert.False(t, gen.IsIdValid(ulid))
+}
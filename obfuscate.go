@@ -0,0 +1,93 @@
+package id
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/oklog/ulid"
+)
+
+const (
+	// ObfuscatedPrefix starts every obfuscated ID. It is URL-safe but not in
+	// the base64url alphabet, so an obfuscated ID is never mistaken for the
+	// Base64URL form of a plain ID, which UnmarshalText would accept.
+	ObfuscatedPrefix = '~'
+	// ObfuscatedSize is the length of an obfuscated ID: the prefix and 22
+	// characters of base64url. It differs from every form UnmarshalText accepts.
+	ObfuscatedSize = 1 + Base64URLSize
+)
+
+// ErrInvalidObfuscatedID is returned when an external ID is not the prefix
+// followed by 22 characters of base64url
+var ErrInvalidObfuscatedID = errors.New("invalid obfuscated ID")
+
+// obfuscatedEncoding renders encrypted IDs; Strict rejects stray low bits
+// so each ID has exactly one external form
+var obfuscatedEncoding = base64.RawURLEncoding.Strict()
+
+// ObfuscatedCodec hides ULIDs behind a keyed permutation so they can be
+// exposed in public URLs without leaking creation time or being enumerated.
+// Each ULID's 16 bytes are encrypted as a single AES block, which maps every
+// ULID to exactly one stable external ID and back. External IDs do not sort
+// chronologically. The permutation is not authenticated: any well-formed
+// external ID decodes to some ULID, almost certainly one that was never
+// issued, so look decoded IDs up rather than trusting them. It is safe for
+// concurrent use.
+type ObfuscatedCodec struct {
+	block cipher.Block
+}
+
+// NewObfuscatedCodec creates a codec from a 16, 24, or 32 byte AES key
+func NewObfuscatedCodec(key []byte) (*ObfuscatedCodec, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid obfuscation key: %w", err)
+	}
+	return &ObfuscatedCodec{block: block}, nil
+}
+
+// Encode returns the external form of a ULID given in either case
+func (c *ObfuscatedCodec) Encode(id string) (string, error) {
+	parsed, err := parseCanonical(id)
+	if err != nil {
+		return "", fmt.Errorf("invalid ULID: %w", err)
+	}
+	return c.EncodeID(ID(parsed)), nil
+}
+
+// EncodeID returns the external form of an ID
+func (c *ObfuscatedCodec) EncodeID(id ID) string {
+	var sealed [ulidSize]byte
+	c.block.Encrypt(sealed[:], id[:])
+	return string(ObfuscatedPrefix) + obfuscatedEncoding.EncodeToString(sealed[:])
+}
+
+// Decode returns the canonical ULID behind an external ID
+func (c *ObfuscatedCodec) Decode(external string) (string, error) {
+	id, err := c.DecodeID(external)
+	if err != nil {
+		return "", err
+	}
+	return ulid.ULID(id).String(), nil
+}
+
+// DecodeID returns the ID behind an external ID
+func (c *ObfuscatedCodec) DecodeID(external string) (ID, error) {
+	if len(external) != ObfuscatedSize {
+		return ID{}, fmt.Errorf("%w: got %d characters, want %d", ErrInvalidObfuscatedID, len(external), ObfuscatedSize)
+	}
+	if external[0] != ObfuscatedPrefix {
+		return ID{}, fmt.Errorf("%w: missing %q prefix", ErrInvalidObfuscatedID, ObfuscatedPrefix)
+	}
+	sealed, err := obfuscatedEncoding.DecodeString(external[1:])
+	if err != nil {
+		return ID{}, fmt.Errorf("%w: %w", ErrInvalidObfuscatedID, err)
+	}
+
+	var id ID
+	c.block.Decrypt(id[:], sealed)
+	return id, nil
+}
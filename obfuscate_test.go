@@ -0,0 +1,87 @@
+package id_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var obfuscationKey = []byte("0123456789abcdef")
+
+func Test_ObfuscatedCodec_RoundTrip(t *testing.T) {
+	codec, err := id.NewObfuscatedCodec(obfuscationKey)
+	require.NoError(t, err)
+	ulid := id.NewGenerator().Generate()
+
+	// Act
+	external, err := codec.Encode(ulid)
+	require.NoError(t, err)
+	decoded, err := codec.Decode(external)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, ulid, decoded)
+	assert.Len(t, external, id.ObfuscatedSize)
+	assert.NotContains(t, external, ulid[:10], "the timestamp is hidden")
+	again, err := codec.Encode(strings.ToLower(ulid))
+	require.NoError(t, err)
+	assert.Equal(t, external, again, "encoding is stable")
+}
+
+func Test_ObfuscatedCodec_Vector(t *testing.T) {
+	codec, err := id.NewObfuscatedCodec(obfuscationKey)
+	require.NoError(t, err)
+
+	// Act
+	external, err := codec.Encode("01ARZ3NDEKTSV4RRFFQ69G5FAV")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "~Pi04p6MTRAEvsTbxDrvWMA", external)
+}
+
+func Test_ObfuscatedCodec_NotMistakenForBase64URL(t *testing.T) {
+	codec, err := id.NewObfuscatedCodec(obfuscationKey)
+	require.NoError(t, err)
+	external := codec.EncodeID(id.NewGenerator().GenerateID())
+
+	// Act
+	var decoded id.ID
+	err = decoded.UnmarshalText([]byte(external))
+
+	// Assert
+	assert.Error(t, err, "obfuscated IDs only decode through their codec")
+	assert.NotEqual(t, id.Base64URLSize, len(external))
+}
+
+func Test_ObfuscatedCodec_Keys(t *testing.T) {
+	a, err := id.NewObfuscatedCodec(obfuscationKey)
+	require.NoError(t, err)
+	b, err := id.NewObfuscatedCodec([]byte("fedcba9876543210"))
+	require.NoError(t, err)
+	generated := id.NewGenerator().GenerateID()
+
+	// Act & Assert
+	assert.NotEqual(t, a.EncodeID(generated), b.EncodeID(generated))
+	decoded, err := a.DecodeID(a.EncodeID(generated))
+	require.NoError(t, err)
+	assert.Equal(t, generated, decoded)
+
+	_, err = id.NewObfuscatedCodec([]byte("short"))
+	assert.Error(t, err)
+}
+
+func Test_ObfuscatedCodec_Invalid(t *testing.T) {
+	codec, err := id.NewObfuscatedCodec(obfuscationKey)
+	require.NoError(t, err)
+
+	_, err = codec.Encode("invalid")
+	assert.Error(t, err)
+	for _, bad := range []string{"", "01ARZ3NDEKTSV4RRFFQ69G5FAV", "!!!!!!!!!!!!!!!!!!!!!!!", "~AAAAAAAAAAAAAAAAAAAAAB", "AAAAAAAAAAAAAAAAAAAAAAA", "AAAAAAAAAAAAAAAAAAAAAA"} {
+		_, err := codec.Decode(bad)
+		assert.ErrorIs(t, err, id.ErrInvalidObfuscatedID, bad)
+	}
+}
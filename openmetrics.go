@@ -0,0 +1,168 @@
+package id
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultMetricsNamespace prefixes metric names when an exporter sets none
+const DefaultMetricsNamespace = "ulid"
+
+// OpenMetricsExporter renders analytics in the OpenMetrics text exposition
+// format, so backfill jobs can push results to a Prometheus Pushgateway or
+// serve them for scraping
+type OpenMetricsExporter struct {
+	// Namespace prefixes every metric name; empty uses DefaultMetricsNamespace
+	Namespace string
+	// Labels are attached to every sample, e.g. {"job": "backfill"}
+	Labels map[string]string
+}
+
+// Export writes stats and, when it has buckets, hist as one complete
+// exposition terminated by "# EOF"
+func (e OpenMetricsExporter) Export(w io.Writer, stats Stats, hist Histogram) error {
+	ns := e.Namespace
+	if ns == "" {
+		ns = DefaultMetricsNamespace
+	}
+	if !validMetricName(ns) {
+		return fmt.Errorf("invalid metric namespace %q", ns)
+	}
+	if err := validLabelNames(e.Labels); err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	labels := formatLabels(e.Labels, "", "")
+
+	gauge(&b, ns+"_ids", "", "Number of valid IDs analyzed.", labels, float64(stats.Count))
+	gauge(&b, ns+"_time_span_seconds", "seconds", "Time between the first and last ID.", labels, stats.TimeSpan.Seconds())
+	gauge(&b, ns+"_rate_per_second", "", "IDs per second across the time span.", labels, stats.Rate())
+	if stats.Count > 0 {
+		gauge(&b, ns+"_first_timestamp_seconds", "seconds", "Unix time of the earliest ID.", labels, unixSeconds(stats.FirstTime))
+		gauge(&b, ns+"_last_timestamp_seconds", "seconds", "Unix time of the latest ID.", labels, unixSeconds(stats.LastTime))
+	}
+
+	if len(hist.Counts) > 0 {
+		name := ns + "_bucket_ids"
+		fmt.Fprintf(&b, "# TYPE %s gauge\n# HELP %s Number of IDs per %s time bucket, labeled by bucket start.\n", name, name, hist.Interval)
+		for i, count := range hist.Counts {
+			start := hist.BucketStart(i).UTC().Format(time.RFC3339Nano)
+			fmt.Fprintf(&b, "%s%s %d\n", name, formatLabels(e.Labels, "bucket_start", start), count)
+		}
+	}
+
+	b.WriteString("# EOF\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// ExportIssued writes counts from IssuedByLabels as an issued-IDs counter
+// with one sample per label set, in one complete exposition terminated by
+// "# EOF". The exporter's Labels are attached to every sample; a per-call
+// label of the same name takes precedence.
+func (e OpenMetricsExporter) ExportIssued(w io.Writer, counts []LabeledCount) error {
+	ns := e.Namespace
+	if ns == "" {
+		ns = DefaultMetricsNamespace
+	}
+	if !validMetricName(ns) {
+		return fmt.Errorf("invalid metric namespace %q", ns)
+	}
+	if err := validLabelNames(e.Labels); err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	name := ns + "_issued"
+	fmt.Fprintf(&b, "# TYPE %s counter\n# HELP %s IDs issued by GenerateLabeled, by label set.\n", name, name)
+	for _, count := range counts {
+		if err := validLabelNames(count.Labels); err != nil {
+			return err
+		}
+		fmt.Fprintf(&b, "%s_total%s %d\n", name, formatLabels(mergeLabels(e.Labels, count.Labels), "", ""), count.Issued)
+	}
+
+	b.WriteString("# EOF\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// validLabelNames returns an error for the first label name the exposition
+// format does not allow
+func validLabelNames(labels map[string]string) error {
+	for name := range labels {
+		if !validMetricName(name) || strings.Contains(name, ":") {
+			return fmt.Errorf("invalid label name %q", name)
+		}
+	}
+	return nil
+}
+
+// gauge writes a single-sample gauge family
+func gauge(w *strings.Builder, name, unit, help, labels string, value float64) {
+	fmt.Fprintf(w, "# TYPE %s gauge\n", name)
+	if unit != "" {
+		fmt.Fprintf(w, "# UNIT %s %s\n", name, unit)
+	}
+	fmt.Fprintf(w, "# HELP %s %s\n%s%s %s\n", name, help, name, labels, strconv.FormatFloat(value, 'f', -1, 64))
+}
+
+// formatLabels renders a sorted label set, plus an optional extra label, as {k="v",...}
+func formatLabels(labels map[string]string, extraName, extraValue string) string {
+	names := slices.Sorted(maps.Keys(labels))
+	if len(names) == 0 && extraName == "" {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, name := range names {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, "%s=\"%s\"", name, escapeLabelValue(labels[name]))
+	}
+	if extraName != "" {
+		if len(names) > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, "%s=\"%s\"", extraName, escapeLabelValue(extraValue))
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+// labelEscaper escapes label values as the exposition format requires
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// escapeLabelValue escapes backslashes, double quotes, and newlines
+func escapeLabelValue(v string) string {
+	return labelEscaper.Replace(v)
+}
+
+// validMetricName reports whether name matches [a-zA-Z_:][a-zA-Z0-9_:]*
+func validMetricName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, c := range name {
+		switch {
+		case c == '_' || c == ':' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z'):
+		case c >= '0' && c <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// unixSeconds converts t to fractional Unix seconds
+func unixSeconds(t time.Time) float64 {
+	return float64(t.UnixMilli()) / 1000
+}
@@ -0,0 +1,102 @@
+package id_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_OpenMetricsExporter_Export(t *testing.T) {
+	gen := id.NewGenerator()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ids := []string{
+		gen.GenerateWithTime(base),
+		gen.GenerateWithTime(base.Add(30 * time.Second)),
+		gen.GenerateWithTime(base.Add(90 * time.Second)),
+	}
+	stats, err := id.AnalyzeIDs(ids)
+	require.NoError(t, err)
+	hist, err := id.HistogramOf(ids, time.Minute)
+	require.NoError(t, err)
+	exporter := id.OpenMetricsExporter{Namespace: "backfill", Labels: map[string]string{"job": "orders", "note": "a \"quoted\"\nvalue"}}
+	var out strings.Builder
+
+	// Act
+	err = exporter.Export(&out, stats, hist)
+
+	// Assert
+	require.NoError(t, err)
+	want := `# TYPE backfill_ids gauge
+# HELP backfill_ids Number of valid IDs analyzed.
+backfill_ids{job="orders",note="a \"quoted\"\nvalue"} 3
+# TYPE backfill_time_span_seconds gauge
+# UNIT backfill_time_span_seconds seconds
+# HELP backfill_time_span_seconds Time between the first and last ID.
+backfill_time_span_seconds{job="orders",note="a \"quoted\"\nvalue"} 90
+# TYPE backfill_rate_per_second gauge
+# HELP backfill_rate_per_second IDs per second across the time span.
+backfill_rate_per_second{job="orders",note="a \"quoted\"\nvalue"} 0.03333333333333333
+# TYPE backfill_first_timestamp_seconds gauge
+# UNIT backfill_first_timestamp_seconds seconds
+# HELP backfill_first_timestamp_seconds Unix time of the earliest ID.
+backfill_first_timestamp_seconds{job="orders",note="a \"quoted\"\nvalue"} 1704067200
+# TYPE backfill_last_timestamp_seconds gauge
+# UNIT backfill_last_timestamp_seconds seconds
+# HELP backfill_last_timestamp_seconds Unix time of the latest ID.
+backfill_last_timestamp_seconds{job="orders",note="a \"quoted\"\nvalue"} 1704067290
+# TYPE backfill_bucket_ids gauge
+# HELP backfill_bucket_ids Number of IDs per 1m0s time bucket, labeled by bucket start.
+backfill_bucket_ids{job="orders",note="a \"quoted\"\nvalue",bucket_start="2024-01-01T00:00:00Z"} 2
+backfill_bucket_ids{job="orders",note="a \"quoted\"\nvalue",bucket_start="2024-01-01T00:01:00Z"} 1
+# EOF
+`
+	assert.Equal(t, want, out.String())
+}
+
+func Test_OpenMetricsExporter_Defaults(t *testing.T) {
+	var out strings.Builder
+
+	// Act
+	err := id.OpenMetricsExporter{}.Export(&out, id.Stats{}, id.Histogram{})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "\nulid_ids 0\n")
+	assert.NotContains(t, out.String(), "first_timestamp")
+	assert.NotContains(t, out.String(), "bucket_ids")
+	assert.True(t, strings.HasSuffix(out.String(), "# EOF\n"))
+}
+
+func Test_OpenMetricsExporter_InvalidNames(t *testing.T) {
+	var out strings.Builder
+	assert.Error(t, id.OpenMetricsExporter{Namespace: "bad-name"}.Export(&out, id.Stats{}, id.Histogram{}))
+	assert.Error(t, id.OpenMetricsExporter{Labels: map[string]string{"1x": "v"}}.Export(&out, id.Stats{}, id.Histogram{}))
+	assert.Empty(t, out.String())
+}
+
+func Test_OpenMetricsExporter_ExportIssued(t *testing.T) {
+	var b strings.Builder
+	counts := []id.LabeledCount{
+		{Labels: map[string]string{"tenant": "acme"}, Issued: 3},
+		{Labels: map[string]string{"tenant": "globex", "job": "sync"}, Issued: 1},
+	}
+
+	// Act
+	err := id.OpenMetricsExporter{Labels: map[string]string{"job": "api"}}.ExportIssued(&b, counts)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, `# TYPE ulid_issued counter
+# HELP ulid_issued IDs issued by GenerateLabeled, by label set.
+ulid_issued_total{job="api",tenant="acme"} 3
+ulid_issued_total{job="sync",tenant="globex"} 1
+# EOF
+`, b.String())
+
+	err = id.OpenMetricsExporter{}.ExportIssued(&b, []id.LabeledCount{{Labels: map[string]string{"bad-name": "x"}}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,115 @@
+package id
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/oklog/ulid"
+)
+
+// Option configures a generator created by NewGenerator
+type Option func(*generator)
+
+// WithClock makes the generator read the current time from clock, which
+// drives Generate, GenerateBatch, Age, and IsExpired
+func WithClock(clock Clock) Option {
+	return func(g *generator) {
+		g.clock = clock
+	}
+}
+
+// WithEntropy makes the generator draw randomness from source. The generator
+// serializes its reads, as with NewGeneratorWithEntropy.
+func WithEntropy(source io.Reader) Option {
+	return func(g *generator) {
+		g.entropySource = source
+		g.unwrapped = nil
+		g.mu = new(sync.Mutex)
+	}
+}
+
+// WithSecureEntropy makes the generator draw randomness from crypto/rand,
+// as NewSecureGenerator does. crypto/rand is safe for concurrent use, so
+// generation takes no lock.
+func WithSecureEntropy() Option {
+	return func(g *generator) {
+		g.entropySource = rand.Reader
+		g.unwrapped = nil
+		g.mu = nil
+	}
+}
+
+// WithMonotonic controls whether IDs issued within the same millisecond
+// increment from the previous one, keeping them in generation order. The
+// default entropy is monotonic. true wraps the current entropy source;
+// false removes the monotonic layer, drawing independently from the source
+// underneath, such as the default random source or crypto/rand. It panics
+// if the monotonic source was passed to WithEntropy, since the source under
+// it cannot be recovered; pass that source instead.
+func WithMonotonic(monotonic bool) Option {
+	return func(g *generator) {
+		_, isMonotonic := g.entropySource.(monotonicReader)
+		switch {
+		case monotonic && !isMonotonic:
+			g.unwrapped = g.entropySource
+			g.entropySource = ulid.Monotonic(g.entropySource, 0)
+			g.mu = new(sync.Mutex)
+		case !monotonic && isMonotonic:
+			if g.unwrapped == nil {
+				panic("id: WithMonotonic(false) cannot unwrap a monotonic source passed to WithEntropy")
+			}
+			g.entropySource, g.unwrapped = g.unwrapped, nil
+			g.mu = new(sync.Mutex)
+		}
+	}
+}
+
+// WithPrefix makes New issue Stripe-style IDs such as "cus_01H..." through
+// a PrefixedGenerator. It applies only to New, and only to ULIDs.
+func WithPrefix(prefix string) Option {
+	return func(g *generator) {
+		g.prefix = prefix
+	}
+}
+
+// New creates a Provider from opts, applied in order, combining any choice
+// of entropy, clock, monotonicity, prefix, and scheme. With no options it is
+// equivalent to NewGenerator. Options are fixed at startup, so New panics if
+// they are invalid, such as a malformed prefix or a prefix combined with a
+// non-ULID scheme, or if the WithSelfTest self-test fails; TryNew returns
+// those errors instead.
+func New(opts ...Option) Provider {
+	p, err := TryNew(opts...)
+	if err != nil {
+		panic("id: " + err.Error())
+	}
+	return p
+}
+
+// TryNew is New returning an invalid prefix or a failed self-test as an
+// error instead of panicking
+func TryNew(opts ...Option) (Provider, error) {
+	g, err := TryNewGenerator(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if g.prefix == "" {
+		return g, nil
+	}
+	if g.scheme != nil {
+		return nil, fmt.Errorf("prefix %q requires ULIDs, not the %s scheme", g.prefix, g.scheme.Name())
+	}
+	p, err := NewPrefixedGeneratorFrom(g, g.prefix)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// NewGeneratorWithOptions is NewGenerator with options, kept for callers
+// written before NewGenerator accepted them
+func NewGeneratorWithOptions(opts ...Option) *generator {
+	return NewGenerator(opts...)
+}
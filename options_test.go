@@ -0,0 +1,96 @@
+package id_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/bold-minds/id/idtest"
+	"github.com/oklog/ulid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_New_Conformance(t *testing.T) {
+	idtest.TestProvider(t, id.New())
+	idtest.TestProvider(t, id.New(id.WithSecureEntropy(), id.WithMonotonic(false)))
+}
+
+func Test_New_WithPrefix(t *testing.T) {
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := id.New(id.WithPrefix("cus"), id.WithClock(id.NewFakeClock(at)))
+
+	// Act
+	generated := p.Generate()
+	ts, err := p.ExtractTimestamp(generated)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(generated, "cus_"), generated)
+	assert.True(t, at.Equal(ts), ts)
+	assert.False(t, p.IsIdValid(id.NewGenerator().Generate()))
+}
+
+func Test_New_WithScheme(t *testing.T) {
+	// Act
+	generated := id.New(id.WithScheme(id.SchemeKSUID)).Generate()
+
+	// Assert
+	assert.Len(t, generated, 27)
+}
+
+func Test_New_InvalidOptions(t *testing.T) {
+	assert.Panics(t, func() { id.New(id.WithPrefix("Bad!")) })
+	assert.Panics(t, func() { id.New(id.WithPrefix("cus"), id.WithScheme(id.SchemeKSUID)) })
+}
+
+func Test_WithMonotonic(t *testing.T) {
+	clock := id.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	// Act
+	plain := id.New(id.WithClock(clock), id.WithEntropy(constReader(7)))
+	monotonic := id.New(id.WithClock(clock), id.WithEntropy(constReader(7)), id.WithMonotonic(true))
+	independent := id.New(id.WithClock(clock), id.WithMonotonic(false))
+
+	// Assert
+	assert.Equal(t, plain.Generate(), plain.Generate())
+	first, second := monotonic.Generate(), monotonic.Generate()
+	assert.Less(t, first, second)
+	assert.NotEqual(t, independent.Generate(), independent.Generate())
+}
+
+func Test_WithMonotonic_False_KeepsUnderlyingSource(t *testing.T) {
+	clock := id.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	// Act
+	plain := id.New(id.WithClock(clock), id.WithEntropy(constReader(7)))
+	unwrapped := id.New(id.WithClock(clock), id.WithEntropy(constReader(7)), id.WithMonotonic(true), id.WithMonotonic(false))
+
+	// Assert
+	assert.Equal(t, plain.Generate(), unwrapped.Generate(), "draws from the source under the monotonic layer")
+	assert.Equal(t, unwrapped.Generate(), unwrapped.Generate())
+	assert.Panics(t, func() {
+		id.New(id.WithEntropy(ulid.Monotonic(constReader(7), 0)), id.WithMonotonic(false))
+	})
+}
+
+// constReader fills every read with the same byte
+type constReader byte
+
+func (r constReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = byte(r)
+	}
+	return len(p), nil
+}
+
+func Test_LegacyConstructors(t *testing.T) {
+	// Act
+	custom := id.NewGeneratorWithEntropy(zeroReader{}).Generate()
+	secure := id.NewSecureGenerator()
+
+	// Assert
+	assert.Equal(t, "0000000000000000", custom[10:])
+	assert.True(t, secure.IsIdValid(secure.Generate()))
+}
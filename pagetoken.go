@@ -0,0 +1,118 @@
+package id
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/oklog/ulid"
+)
+
+// pageTokenVersion is the wire format version written by PageTokenCodec
+const pageTokenVersion = 1
+
+// MaxPageTokenFilter is the largest encoded filter state a PageToken may carry
+const MaxPageTokenFilter = 1024
+
+// ErrInvalidPageToken is returned when a token cannot be authenticated or decoded
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// PageToken is an opaque pagination cursor: a ULID boundary plus the filter
+// state that produced the page. FilterVersion lets APIs evolve their filter
+// schema and still interpret tokens issued under an older one.
+type PageToken struct {
+	After         string
+	FilterVersion uint8
+	Filter        map[string]string
+}
+
+// PageTokenCodec seals PageTokens with AES-GCM so clients can neither read
+// nor alter the boundary or filter state
+type PageTokenCodec struct {
+	aead cipher.AEAD
+}
+
+// NewPageTokenCodec creates a codec from a 16, 24, or 32 byte AES key
+func NewPageTokenCodec(key []byte) (*PageTokenCodec, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid page token key: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &PageTokenCodec{aead: aead}, nil
+}
+
+// Encode seals a PageToken into a URL-safe string
+func (c *PageTokenCodec) Encode(token PageToken) (string, error) {
+	boundary, err := parseCanonical(token.After)
+	if err != nil {
+		return "", fmt.Errorf("invalid ULID: %w", err)
+	}
+
+	var filter []byte
+	if len(token.Filter) > 0 {
+		if filter, err = json.Marshal(token.Filter); err != nil {
+			return "", err
+		}
+	}
+	if len(filter) > MaxPageTokenFilter {
+		return "", fmt.Errorf("filter state too large: %d bytes", len(filter))
+	}
+
+	plaintext := make([]byte, 0, ulidSize+1+len(filter))
+	plaintext = append(plaintext, boundary[:]...)
+	plaintext = append(plaintext, token.FilterVersion)
+	plaintext = append(plaintext, filter...)
+
+	header := []byte{pageTokenVersion}
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := append(header, nonce...)
+	sealed = c.aead.Seal(sealed, nonce, plaintext, header)
+	return base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+// Decode authenticates and opens a token produced by Encode
+func (c *PageTokenCodec) Decode(token string) (PageToken, error) {
+	sealed, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return PageToken{}, ErrInvalidPageToken
+	}
+
+	nonceSize := c.aead.NonceSize()
+	if len(sealed) < 1+nonceSize+c.aead.Overhead() {
+		return PageToken{}, ErrInvalidPageToken
+	}
+	if sealed[0] != pageTokenVersion {
+		return PageToken{}, fmt.Errorf("%w: unsupported version %d", ErrInvalidPageToken, sealed[0])
+	}
+
+	header, nonce, ciphertext := sealed[:1], sealed[1:1+nonceSize], sealed[1+nonceSize:]
+	plaintext, err := c.aead.Open(nil, nonce, ciphertext, header)
+	if err != nil || len(plaintext) < ulidSize+1 {
+		return PageToken{}, ErrInvalidPageToken
+	}
+
+	var boundary ulid.ULID
+	copy(boundary[:], plaintext[:ulidSize])
+	result := PageToken{
+		After:         boundary.String(),
+		FilterVersion: plaintext[ulidSize],
+	}
+	if filter := plaintext[ulidSize+1:]; len(filter) > 0 {
+		if err := json.Unmarshal(filter, &result.Filter); err != nil {
+			return PageToken{}, ErrInvalidPageToken
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,60 @@
+package id_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PageTokenCodec_RoundTrip(t *testing.T) {
+	codec, err := id.NewPageTokenCodec(bytes.Repeat([]byte{1}, 32))
+	require.NoError(t, err)
+	token := id.PageToken{
+		After:         id.NewGenerator().Generate(),
+		FilterVersion: 2,
+		Filter:        map[string]string{"status": "open", "owner": "alice"},
+	}
+
+	// Act
+	encoded, err := codec.Encode(token)
+	require.NoError(t, err)
+	decoded, err := codec.Decode(encoded)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, token, decoded)
+	assert.NotContains(t, encoded, "open")
+}
+
+func Test_PageTokenCodec_Rejects(t *testing.T) {
+	codec, err := id.NewPageTokenCodec(bytes.Repeat([]byte{1}, 16))
+	require.NoError(t, err)
+	other, err := id.NewPageTokenCodec(bytes.Repeat([]byte{2}, 16))
+	require.NoError(t, err)
+
+	encoded, err := codec.Encode(id.PageToken{After: id.NewGenerator().Generate()})
+	require.NoError(t, err)
+
+	// Act & Assert
+	_, err = other.Decode(encoded)
+	assert.ErrorIs(t, err, id.ErrInvalidPageToken)
+
+	tampered := []byte(encoded)
+	tampered[10] = map[bool]byte{true: 'B', false: 'A'}[tampered[10] == 'A']
+	_, err = codec.Decode(string(tampered))
+	assert.ErrorIs(t, err, id.ErrInvalidPageToken)
+
+	_, err = codec.Decode("not-a-token")
+	assert.ErrorIs(t, err, id.ErrInvalidPageToken)
+
+	_, err = codec.Encode(id.PageToken{After: "invalid"})
+	assert.Error(t, err)
+	_, err = codec.Encode(id.PageToken{After: "01ARZ3NDEKTSV4RRFFQ69G5F!!"})
+	assert.Error(t, err)
+
+	_, err = id.NewPageTokenCodec([]byte("short"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,45 @@
+package id
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/oklog/ulid"
+)
+
+// Partition key layouts for PartitionKey, in time.Format syntax
+const (
+	PartitionMonthly = "2006-01"
+	PartitionDaily   = "2006-01-02"
+	PartitionHourly  = "2006-01-02T15"
+)
+
+// TruncateToInterval returns the smallest ULID in the interval of width d
+// containing id's timestamp: the timestamp is rounded down to a multiple of
+// d and the entropy is zeroed. Intervals are aligned to the Unix epoch, so a
+// 24h interval starts at midnight UTC. d must be at least a millisecond.
+func TruncateToInterval(id string, d time.Duration) (string, error) {
+	if d < time.Millisecond {
+		return "", errors.New("interval must be at least 1ms")
+	}
+	u, err := parseCanonical(id)
+	if err != nil {
+		return "", fmt.Errorf("invalid ULID: %w", err)
+	}
+	step := uint64(d.Milliseconds()) //nolint:gosec // G115: d is at least 1ms
+	var truncated ulid.ULID
+	_ = truncated.SetTime(u.Time() - u.Time()%step)
+	return truncated.String(), nil
+}
+
+// PartitionKey formats id's timestamp in UTC with layout, such as
+// PartitionDaily for "2024-05-17" or PartitionHourly for "2024-05-17T13",
+// for routing IDs to time-partitioned tables
+func PartitionKey(id string, layout string) (string, error) {
+	u, err := parseCanonical(id)
+	if err != nil {
+		return "", fmt.Errorf("invalid ULID: %w", err)
+	}
+	return ulid.Time(u.Time()).UTC().Format(layout), nil
+}
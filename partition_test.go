@@ -0,0 +1,73 @@
+package id_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TruncateToInterval(t *testing.T) {
+	at := time.Date(2024, 5, 17, 13, 45, 12, 345_000_000, time.UTC)
+	generated := id.NewGenerator().GenerateWithTime(at)
+
+	tests := []struct {
+		d    time.Duration
+		want time.Time
+	}{
+		{time.Millisecond, at},
+		{time.Second, time.Date(2024, 5, 17, 13, 45, 12, 0, time.UTC)},
+		{15 * time.Minute, time.Date(2024, 5, 17, 13, 45, 0, 0, time.UTC)},
+		{time.Hour, time.Date(2024, 5, 17, 13, 0, 0, 0, time.UTC)},
+		{24 * time.Hour, time.Date(2024, 5, 17, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.d.String(), func(t *testing.T) {
+			// Act
+			got, err := id.TruncateToInterval(strings.ToLower(generated), tt.d)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, id.MinIDForTime(tt.want), got)
+		})
+	}
+}
+
+func Test_TruncateToInterval_Errors(t *testing.T) {
+	generated := id.NewGenerator().Generate()
+
+	_, err := id.TruncateToInterval(generated, 0)
+	assert.Error(t, err)
+	_, err = id.TruncateToInterval(generated, time.Microsecond)
+	assert.Error(t, err)
+	_, err = id.TruncateToInterval("nope", time.Hour)
+	assert.Error(t, err)
+	_, err = id.TruncateToInterval(generated[:24]+"!!", time.Hour)
+	assert.Error(t, err)
+}
+
+func Test_PartitionKey(t *testing.T) {
+	at := time.Date(2024, 5, 17, 13, 45, 0, 0, time.FixedZone("UTC+10", 10*3600))
+	generated := id.NewGenerator().GenerateWithTime(at)
+
+	// Act
+	daily, err := id.PartitionKey(generated, id.PartitionDaily)
+	require.NoError(t, err)
+	hourly, err := id.PartitionKey(generated, id.PartitionHourly)
+	require.NoError(t, err)
+	monthly, err := id.PartitionKey(generated, id.PartitionMonthly)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, "2024-05-17", daily)
+	assert.Equal(t, "2024-05-17T03", hourly, "keys are in UTC")
+	assert.Equal(t, "2024-05", monthly)
+
+	_, err = id.PartitionKey("nope", id.PartitionDaily)
+	assert.Error(t, err)
+	_, err = id.PartitionKey(generated[:24]+"!!", id.PartitionDaily)
+	assert.Error(t, err)
+}
@@ -0,0 +1,163 @@
+package id
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// ErrInsufficientEntropy is returned by PlanBits when reservations leave too
+// few random bits for the requested collision probability
+var ErrInsufficientEntropy = errors.New("insufficient entropy")
+
+// BitField is a named reservation of entropy bits, counted from the start of
+// the entropy with the most significant bit first
+type BitField struct {
+	Name   string
+	Offset int
+	Width  int
+}
+
+// overlaps reports whether f and other share any bit
+func (f BitField) overlaps(other BitField) bool {
+	return f.Offset < other.Offset+other.Width && other.Offset < f.Offset+f.Width
+}
+
+// uuidv7Fields are the entropy bits WithUUIDv7Layout writes, which PlanBits
+// leaves free so a planned layout still converts to UUIDv7 losslessly
+var uuidv7Fields = []BitField{
+	{Name: "uuidv7 version", Offset: uuidv7VersionOffset, Width: 4},
+	{Name: "uuidv7 variant", Offset: uuidv7VariantOffset, Width: 2},
+}
+
+// Plan is the validated layout of a ULID's 80 entropy bits
+type Plan struct {
+	// Fields are the reservations in offset order
+	Fields []BitField
+	// ReservedBits is the total width of all Fields
+	ReservedBits int
+	// RandomBits is the entropy left for collision resistance
+	RandomBits int
+	// RequiredBits is the fewest random bits that meet the acceptable probability
+	RequiredBits int
+	// CollisionProbability is the chance of a collision within one millisecond at peak load
+	CollisionProbability float64
+}
+
+// Headroom returns how many more bits could be reserved without exceeding
+// the acceptable collision probability
+func (p Plan) Headroom() int {
+	return p.RandomBits - p.RequiredBits
+}
+
+// PlanBits checks whether reserving reservedBits (e.g. node, region, version)
+// leaves enough randomness for expectedPeakPerMs IDs per millisecond to
+// collide with at most acceptableCollisionProb. The "node" and "region"
+// reservations are placed where WithNode and WithRegion stamp them, at
+// NodeOffset and RegionOffset, so node must be NodeBits wide and region at
+// most MaxRegionBits. Other reservations take the lowest free offsets in
+// name order, clear of those and of the UUIDv7 version and variant bits.
+// Use PlanFields to choose every offset yourself. Run it at configuration
+// time to fail early rather than at collision time.
+func PlanBits(expectedPeakPerMs int, acceptableCollisionProb float64, reservedBits map[string]int) (Plan, error) {
+	names := make([]string, 0, len(reservedBits))
+	for name := range reservedBits {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var fields, rest []BitField
+	for _, name := range names {
+		field := BitField{Name: name, Width: reservedBits[name]}
+		if field.Width < 1 {
+			return Plan{}, fmt.Errorf("reservation %q must be at least 1 bit, got %d", name, field.Width)
+		}
+		switch name {
+		case "node":
+			if field.Width != NodeBits {
+				return Plan{}, fmt.Errorf("reservation %q must be %d bits to match WithNode, got %d", name, NodeBits, field.Width)
+			}
+			field.Offset = NodeOffset
+			fields = append(fields, field)
+		case "region":
+			if field.Width > MaxRegionBits {
+				return Plan{}, fmt.Errorf("reservation %q must be at most %d bits to match WithRegion, got %d", name, MaxRegionBits, field.Width)
+			}
+			field.Offset = RegionOffset
+			fields = append(fields, field)
+		default:
+			rest = append(rest, field)
+		}
+	}
+
+	for _, field := range rest {
+		offset, ok := firstFreeOffset(append(slices.Clone(uuidv7Fields), fields...), field.Width)
+		if !ok {
+			return Plan{}, fmt.Errorf("no room for the %d bits of reservation %q clear of the node, region, and UUIDv7 fields", field.Width, field.Name)
+		}
+		field.Offset = offset
+		fields = append(fields, field)
+	}
+	return PlanFields(expectedPeakPerMs, acceptableCollisionProb, fields...)
+}
+
+// firstFreeOffset returns the lowest offset at which width bits overlap none
+// of taken, reporting false if there is none
+func firstFreeOffset(taken []BitField, width int) (int, bool) {
+	for offset := 0; offset+width <= entropySize*8; offset++ {
+		candidate := BitField{Offset: offset, Width: width}
+		if !slices.ContainsFunc(taken, candidate.overlaps) {
+			return offset, true
+		}
+	}
+	return 0, false
+}
+
+// PlanFields is PlanBits for reservations at offsets the caller chooses, such
+// as a layout that must match IDs already issued. It rejects fields that
+// fall outside the 80 entropy bits, share a name, or overlap one another.
+func PlanFields(expectedPeakPerMs int, acceptableCollisionProb float64, fields ...BitField) (Plan, error) {
+	if expectedPeakPerMs < 1 {
+		return Plan{}, fmt.Errorf("expected peak per millisecond must be positive, got %d", expectedPeakPerMs)
+	}
+	if !(acceptableCollisionProb > 0 && acceptableCollisionProb < 1) {
+		return Plan{}, fmt.Errorf("acceptable collision probability must be between 0 and 1, got %g", acceptableCollisionProb)
+	}
+
+	var plan Plan
+	for i, field := range fields {
+		if field.Width < 1 {
+			return Plan{}, fmt.Errorf("reservation %q must be at least 1 bit, got %d", field.Name, field.Width)
+		}
+		if field.Offset < 0 || field.Offset+field.Width > entropySize*8 {
+			return Plan{}, fmt.Errorf("reservation %q at offset %d does not fit in the %d entropy bits", field.Name, field.Offset, entropySize*8)
+		}
+		for _, other := range fields[:i] {
+			if other.Name == field.Name {
+				return Plan{}, fmt.Errorf("reservation %q is listed twice", field.Name)
+			}
+			if other.overlaps(field) {
+				return Plan{}, fmt.Errorf("reservations %q and %q overlap", other.Name, field.Name)
+			}
+		}
+		plan.ReservedBits += field.Width
+	}
+	plan.Fields = slices.SortedFunc(slices.Values(fields), func(a, b BitField) int {
+		return cmp.Compare(a.Offset, b.Offset)
+	})
+
+	plan.RandomBits = entropySize*8 - plan.ReservedBits
+	plan.CollisionProbability = CollisionProbability(plan.RandomBits, expectedPeakPerMs)
+	for plan.RequiredBits < entropySize*8 && CollisionProbability(plan.RequiredBits, expectedPeakPerMs) > acceptableCollisionProb {
+		plan.RequiredBits++
+	}
+
+	if plan.CollisionProbability > acceptableCollisionProb {
+		return plan, fmt.Errorf("%w: %d random bits give collision probability %g at %d IDs/ms, need %d bits for %g",
+			ErrInsufficientEntropy, plan.RandomBits, plan.CollisionProbability,
+			expectedPeakPerMs, plan.RequiredBits, acceptableCollisionProb)
+	}
+	return plan, nil
+}
@@ -0,0 +1,125 @@
+package id_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PlanBits_Layout(t *testing.T) {
+	// Act
+	plan, err := id.PlanBits(1000, 1e-9, map[string]int{"region": 8, "node": 10, "version": 2})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []id.BitField{
+		{Name: "node", Offset: id.NodeOffset, Width: id.NodeBits},
+		{Name: "version", Offset: 14, Width: 2},
+		{Name: "region", Offset: id.RegionOffset, Width: 8},
+	}, plan.Fields)
+	assert.Equal(t, 20, plan.ReservedBits)
+	assert.Equal(t, 60, plan.RandomBits)
+	assert.LessOrEqual(t, plan.CollisionProbability, 1e-9)
+	assert.Positive(t, plan.Headroom())
+	assert.Greater(t, id.CollisionProbability(plan.RequiredBits-1, 1000), 1e-9)
+}
+
+func Test_PlanBits_MatchesStamps(t *testing.T) {
+	regions, err := id.NewRegionMap(8, map[string]uint16{"us": 1, "eu": 2})
+	require.NoError(t, err)
+	at := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	var ulids []string
+	for _, stamp := range []struct {
+		node   uint16
+		region string
+	}{{3, "us"}, {1, "eu"}, {2, "us"}} {
+		gen := id.NewGenerator(id.WithNode(stamp.node), id.WithRegion(regions, stamp.region))
+		ulids = append(ulids, gen.GenerateWithTime(at))
+	}
+	plan, err := id.PlanBits(1000, 1e-9, map[string]int{"node": id.NodeBits, "region": regions.Bits()})
+	require.NoError(t, err)
+	node, region := plan.Fields[0], plan.Fields[1]
+
+	// Act
+	byNode := id.SortChronologicallyBy(ulids, id.ByEntropyBits(node.Offset, node.Width))
+	byRegion := id.SortChronologicallyBy(ulids, id.ByEntropyBits(region.Offset, region.Width))
+
+	// Assert
+	for i, s := range byNode {
+		n, err := id.ExtractNode(s)
+		require.NoError(t, err)
+		assert.Equal(t, uint16(i+1), n)
+	}
+	for i, want := range []string{"us", "us", "eu"} {
+		got, err := regions.ExtractRegion(byRegion[i])
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+
+	_, err = id.PlanBits(1000, 1e-9, map[string]int{"node": 8})
+	assert.Error(t, err)
+	_, err = id.PlanBits(1000, 1e-9, map[string]int{"region": id.MaxRegionBits + 1})
+	assert.Error(t, err)
+}
+
+func Test_PlanFields(t *testing.T) {
+	// Act
+	plan, err := id.PlanFields(1000, 1e-9,
+		id.BitField{Name: "shard", Offset: 40, Width: 6},
+		id.BitField{Name: "node", Offset: id.NodeOffset, Width: id.NodeBits})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []id.BitField{
+		{Name: "node", Offset: id.NodeOffset, Width: id.NodeBits},
+		{Name: "shard", Offset: 40, Width: 6},
+	}, plan.Fields)
+	assert.Equal(t, 64, plan.RandomBits)
+
+	_, err = id.PlanFields(1000, 1e-9, id.BitField{Name: "a", Offset: 0, Width: 8}, id.BitField{Name: "b", Offset: 7, Width: 2})
+	assert.ErrorContains(t, err, "overlap")
+	_, err = id.PlanFields(1000, 1e-9, id.BitField{Name: "a", Offset: 0, Width: 2}, id.BitField{Name: "a", Offset: 4, Width: 2})
+	assert.Error(t, err)
+	_, err = id.PlanFields(1000, 1e-9, id.BitField{Name: "a", Offset: 75, Width: 6})
+	assert.Error(t, err)
+	_, err = id.PlanFields(1000, 1e-9, id.BitField{Name: "a", Offset: -1, Width: 2})
+	assert.Error(t, err)
+}
+
+func Test_PlanBits_Insufficient(t *testing.T) {
+	// Act
+	plan, err := id.PlanBits(100000, 1e-12, map[string]int{"worker": 32, "shard": 16})
+
+	// Assert
+	require.ErrorIs(t, err, id.ErrInsufficientEntropy)
+	assert.Equal(t, 32, plan.RandomBits)
+	assert.Negative(t, plan.Headroom())
+}
+
+func Test_PlanBits_InvalidInput(t *testing.T) {
+	_, err := id.PlanBits(0, 0.01, nil)
+	assert.Error(t, err)
+	_, err = id.PlanBits(10, 0, nil)
+	assert.Error(t, err)
+	_, err = id.PlanBits(10, 1, nil)
+	assert.Error(t, err)
+	_, err = id.PlanBits(10, 0.01, map[string]int{"node": 0})
+	assert.Error(t, err)
+	_, err = id.PlanBits(10, 0.01, map[string]int{"a": 60, "b": 21})
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, id.ErrInsufficientEntropy)
+}
+
+func Test_PlanBits_NoReservations(t *testing.T) {
+	// Act
+	plan, err := id.PlanBits(1, 0.5, nil)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, plan.Fields)
+	assert.Equal(t, 80, plan.RandomBits)
+	assert.Zero(t, plan.RequiredBits)
+}
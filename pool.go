@@ -0,0 +1,89 @@
+package id
+
+import (
+	"time"
+)
+
+// DefaultPoolSize is the capacity NewPool uses for non-positive sizes
+const DefaultPoolSize = 4096
+
+// PoolOptions configures a Pool. The zero value is usable.
+type PoolOptions struct {
+	// Size is how many IDs the pool holds when full; non-positive means
+	// DefaultPoolSize
+	Size int
+	// LowWatermark is the depth below which the pool refills back to Size;
+	// non-positive or too large means a quarter of Size
+	LowWatermark int
+	// MaxAge is the freshness tolerance: pooled IDs whose timestamps are
+	// older are discarded rather than handed out. Zero disables the limit.
+	MaxAge time.Duration
+}
+
+// PoolStats reports the state and activity of a Pool
+type PoolStats struct {
+	// Depth is the number of IDs ready in the pool
+	Depth int
+	// Capacity is the pool's Size
+	Capacity int
+	// Served counts IDs handed out from the pool
+	Served uint64
+	// Misses counts IDs generated inline because the pool was empty
+	Misses uint64
+	// Refills counts background refill passes
+	Refills uint64
+	// Refilled counts IDs added by background refills
+	Refilled uint64
+	// Stale counts pooled IDs discarded for exceeding MaxAge
+	Stale uint64
+}
+
+// Pool is a BufferedGenerator for latency-critical paths that refills in
+// batches once it falls below LowWatermark rather than after every ID,
+// discards IDs older than MaxAge so handed-out timestamps stay within
+// tolerance, and reports its depth and refill activity. Start, Close, Err,
+// and Drain behave as on BufferedGenerator. It is safe for concurrent use.
+type Pool struct {
+	*BufferedGenerator
+}
+
+// NewPool creates a pool that fills once started
+func NewPool(opts PoolOptions) *Pool {
+	return NewPoolFrom(NewGenerator(), opts)
+}
+
+// NewPoolFrom pools IDs produced by an existing generator
+func NewPoolFrom(base *generator, opts PoolOptions) *Pool {
+	size := opts.Size
+	if size <= 0 {
+		size = DefaultPoolSize
+	}
+	low := opts.LowWatermark
+	if low <= 0 || low >= size {
+		low = max(size/4, 1)
+	}
+	return &Pool{newBufferedGenerator(base, size, low, opts.MaxAge)}
+}
+
+// Get returns a pooled ID, or a freshly generated one if the pool is empty
+func (p *Pool) Get() string {
+	return p.Generate()
+}
+
+// GetN returns n IDs in ascending order, taking as many as it can from the
+// pool and generating the rest inline
+func (p *Pool) GetN(n int) []string {
+	if n <= 0 {
+		return []string{}
+	}
+	result, missing := p.take(n)
+	if missing > 0 {
+		result = append(result, p.generator.GenerateBatch(missing)...)
+	}
+	return result
+}
+
+// Stats returns a snapshot of the pool's depth and counters
+func (p *Pool) Stats() PoolStats {
+	return p.snapshot()
+}
@@ -0,0 +1,135 @@
+package id_test
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Pool(t *testing.T) {
+	pool := id.NewPool(id.PoolOptions{Size: 64, LowWatermark: 16})
+	require.NoError(t, pool.Start(context.Background()))
+	defer pool.Close()
+	require.Eventually(t, func() bool { return pool.Stats().Depth == 64 }, time.Second, time.Millisecond)
+
+	// Act
+	single := pool.Get()
+	many := pool.GetN(100)
+
+	// Assert
+	assert.True(t, pool.IsIdValid(single))
+	assert.Len(t, many, 100)
+	assert.True(t, slices.IsSorted(many))
+	assert.NotContains(t, many, single)
+	stats := pool.Stats()
+	assert.Equal(t, uint64(64), stats.Served)
+	assert.Equal(t, uint64(37), stats.Misses)
+	assert.Equal(t, 64, stats.Capacity)
+	require.Eventually(t, func() bool { return pool.Stats().Depth == 64 }, time.Second, time.Millisecond)
+	assert.GreaterOrEqual(t, pool.Stats().Refills, uint64(2))
+}
+
+func Test_Pool_RefillsBelowWatermark(t *testing.T) {
+	pool := id.NewPool(id.PoolOptions{Size: 32, LowWatermark: 8})
+	require.NoError(t, pool.Start(context.Background()))
+	defer pool.Close()
+	require.Eventually(t, func() bool { return pool.Stats().Depth == 32 }, time.Second, time.Millisecond)
+	refills := pool.Stats().Refills
+
+	// Act
+	pool.GetN(10)
+	time.Sleep(20 * time.Millisecond)
+	above := pool.Stats()
+	pool.GetN(20)
+
+	// Assert
+	assert.Equal(t, refills, above.Refills, "no refill while at or above the watermark")
+	assert.Equal(t, 22, above.Depth)
+	require.Eventually(t, func() bool { return pool.Stats().Depth == 32 }, time.Second, time.Millisecond)
+	assert.Equal(t, refills+1, pool.Stats().Refills)
+}
+
+func Test_Pool_DiscardsStaleIDs(t *testing.T) {
+	clock := id.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	pool := id.NewPoolFrom(id.NewGenerator(id.WithClock(clock)), id.PoolOptions{Size: 16, MaxAge: time.Hour})
+	require.NoError(t, pool.Start(context.Background()))
+	defer pool.Close()
+	require.Eventually(t, func() bool { return pool.Stats().Depth == 16 }, time.Second, time.Millisecond)
+
+	// Act
+	clock.Advance(2 * time.Hour)
+	got := pool.Get()
+
+	// Assert
+	ts, err := pool.ExtractTimestamp(got)
+	require.NoError(t, err)
+	assert.True(t, clock.Now().Equal(ts), "stale IDs are never handed out")
+	assert.Equal(t, uint64(16), pool.Stats().Stale)
+}
+
+func Test_Pool_Concurrent(t *testing.T) {
+	pool := id.NewPool(id.PoolOptions{Size: 128})
+	require.NoError(t, pool.Start(context.Background()))
+	defer pool.Close()
+
+	var mu sync.Mutex
+	seen := make(map[string]bool)
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 250; i++ {
+				ids := append(pool.GetN(2), pool.Generate())
+				mu.Lock()
+				for _, s := range ids {
+					seen[s] = true
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	assert.Len(t, seen, 6000)
+}
+
+func Test_Pool_Lifecycle(t *testing.T) {
+	pool := id.NewPool(id.PoolOptions{})
+
+	// Before Start, generation happens inline
+	assert.True(t, pool.IsIdValid(pool.Get()))
+	assert.Equal(t, id.PoolStats{Capacity: id.DefaultPoolSize, Misses: 1}, pool.Stats())
+	assert.Empty(t, pool.GetN(0))
+
+	require.NoError(t, pool.Start(context.Background()))
+	assert.ErrorIs(t, pool.Start(context.Background()), id.ErrAlreadyStarted)
+
+	// Act
+	require.NoError(t, pool.Close())
+	require.NoError(t, pool.Close())
+
+	// Assert
+	assert.True(t, pool.IsIdValid(pool.Get()))
+	assert.ErrorIs(t, pool.Start(context.Background()), id.ErrClosed)
+}
+
+func Test_Pool_StopsRefillingOnEntropyFailure(t *testing.T) {
+	pool := id.NewPoolFrom(id.NewGeneratorWithEntropy(failingReader{}), id.PoolOptions{Size: 8})
+
+	// Act
+	require.NoError(t, pool.Start(context.Background()))
+
+	// Assert
+	require.Eventually(t, func() bool { return pool.Err() != nil }, time.Second, time.Millisecond)
+	assert.ErrorContains(t, pool.Err(), "hardware RNG exhausted")
+	assert.Zero(t, pool.Stats().Refills)
+	assert.Error(t, pool.Close())
+}
@@ -0,0 +1,358 @@
+package id
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/oklog/ulid"
+)
+
+// PrefixSeparator joins a resource prefix to a ULID, as in "cus_01H..."
+const PrefixSeparator = '_'
+
+var (
+	// ErrUnknownPrefix is returned when a prefixed ID's prefix is not registered
+	ErrUnknownPrefix = errors.New("unknown ID prefix")
+	// ErrPrefixMismatch is returned when an ID carries a different prefix than expected
+	ErrPrefixMismatch = errors.New("ID prefix mismatch")
+)
+
+// DefaultPrefixRegistry is the registry used by RegisterPrefix and ParsePrefixed
+var DefaultPrefixRegistry = NewPrefixRegistry()
+
+// RegisterPrefix registers prefix for kind in DefaultPrefixRegistry
+func RegisterPrefix(prefix, kind string) error {
+	return DefaultPrefixRegistry.Register(prefix, kind)
+}
+
+// ParsePrefixed splits a prefixed ID using DefaultPrefixRegistry, returning
+// the resource kind and the canonical ULID
+func ParsePrefixed(id string) (kind, canonical string, err error) {
+	return DefaultPrefixRegistry.Parse(id)
+}
+
+// PrefixRegistry maps resource prefixes such as "cus" to kinds such as
+// "customer". It is safe for concurrent use.
+type PrefixRegistry struct {
+	mu    sync.RWMutex
+	kinds map[string]string
+}
+
+// NewPrefixRegistry creates an empty PrefixRegistry
+func NewPrefixRegistry() *PrefixRegistry {
+	return &PrefixRegistry{kinds: make(map[string]string)}
+}
+
+// Register maps prefix to kind. Each prefix may be registered once.
+func (r *PrefixRegistry) Register(prefix, kind string) error {
+	if err := validatePrefix(prefix); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.kinds[prefix]; ok {
+		return fmt.Errorf("prefix %q already registered for %q", prefix, existing)
+	}
+	r.kinds[prefix] = kind
+	return nil
+}
+
+// Parse splits a prefixed ID, returning the kind registered for its prefix
+// and the canonical ULID
+func (r *PrefixRegistry) Parse(id string) (kind, canonical string, err error) {
+	prefix, parsed, err := splitPrefixed(id)
+	if err != nil {
+		return "", "", err
+	}
+
+	r.mu.RLock()
+	kind, ok := r.kinds[prefix]
+	r.mu.RUnlock()
+	if !ok {
+		return "", "", fmt.Errorf("%w %q", ErrUnknownPrefix, prefix)
+	}
+	return kind, parsed.String(), nil
+}
+
+// SortChronologically sorts prefixed IDs by their ULID timestamps, keeping
+// their prefixes, so IDs of different registered kinds interleave in time.
+// IDs that are malformed or carry an unregistered prefix are placed last in
+// their original order.
+func (r *PrefixRegistry) SortChronologically(ids []string) []string {
+	if len(ids) <= 1 {
+		return ids
+	}
+	parsed, errs := r.parseBatch(ids)
+	return sortParsed(ids, parsed, errs, func(a, b sortEntry) int {
+		return Chronological(a.u, b.u)
+	})
+}
+
+// FilterByTimeRange returns the prefixed IDs whose ULID timestamps fall
+// within start and end inclusive, skipping IDs that are malformed or carry
+// an unregistered prefix
+func (r *PrefixRegistry) FilterByTimeRange(ids []string, start, end time.Time) []string {
+	parsed, errs := r.parseBatch(ids)
+	return filterParsed(ids, parsed, errs, start, end)
+}
+
+// AnalyzeIDs provides generation statistics for prefixed IDs as AnalyzeIDs
+// does for ULIDs, skipping IDs that are malformed or carry an unregistered
+// prefix. FirstID and LastID keep their prefixes.
+func (r *PrefixRegistry) AnalyzeIDs(ids []string) (Stats, error) {
+	if len(ids) == 0 {
+		return Stats{}, nil
+	}
+	parsed, errs := r.parseBatch(ids)
+	return analyzeParsed(ids, parsed, errs)
+}
+
+// parseBatch parses the ULID of each prefixed ID, recording an error for
+// IDs that are malformed or carry an unregistered prefix
+func (r *PrefixRegistry) parseBatch(ids []string) ([]ID, []error) {
+	parsed := make([]ID, len(ids))
+	errs := make([]error, len(ids))
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for i, id := range ids {
+		prefix, u, err := splitPrefixed(id)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		if _, ok := r.kinds[prefix]; !ok {
+			errs[i] = fmt.Errorf("%w %q", ErrUnknownPrefix, prefix)
+			continue
+		}
+		parsed[i] = ID(u)
+	}
+	return parsed, errs
+}
+
+// PrefixedGenerator issues Stripe-style IDs such as "cus_01H..." and accepts
+// only IDs with its prefix in every validation, timestamp, and comparison method
+type PrefixedGenerator struct {
+	prefix string
+	gen    *generator
+}
+
+// NewPrefixedGenerator creates a generator of IDs carrying prefix, using default entropy
+func NewPrefixedGenerator(prefix string) (*PrefixedGenerator, error) {
+	return NewPrefixedGeneratorFrom(NewGenerator(), prefix)
+}
+
+// NewPrefixedGeneratorFrom creates a generator of IDs carrying prefix that
+// draws ULIDs from base, which must not issue another ID scheme
+func NewPrefixedGeneratorFrom(base *generator, prefix string) (*PrefixedGenerator, error) {
+	if err := validatePrefix(prefix); err != nil {
+		return nil, err
+	}
+	if err := base.requireULID("prefixed IDs"); err != nil {
+		return nil, err
+	}
+	return &PrefixedGenerator{prefix: prefix, gen: base}, nil
+}
+
+// Prefix returns the resource prefix, without the separator
+func (p *PrefixedGenerator) Prefix() string {
+	return p.prefix
+}
+
+// Strip returns the canonical ULID inside a prefixed ID
+func (p *PrefixedGenerator) Strip(id string) (string, error) {
+	parsed, err := p.parse(id)
+	if err != nil {
+		return "", err
+	}
+	return parsed.String(), nil
+}
+
+// Generate creates a prefixed ID for the current time
+func (p *PrefixedGenerator) Generate() string {
+	return p.GenerateWithTime(p.gen.now())
+}
+
+// GenerateWithTime creates a prefixed ID with a specific timestamp
+func (p *PrefixedGenerator) GenerateWithTime(t time.Time) string {
+	return p.wrap(p.gen.GenerateWithTime(t))
+}
+
+// GenerateBatch creates multiple prefixed IDs
+func (p *PrefixedGenerator) GenerateBatch(count int) []string {
+	return p.wrapAll(p.gen.GenerateBatch(count))
+}
+
+// GenerateRange creates prefixed IDs spread across a time range
+func (p *PrefixedGenerator) GenerateRange(start, end time.Time, count int) []string {
+	return p.wrapAll(p.gen.GenerateRange(start, end, count))
+}
+
+// IsIdValid reports whether id is a valid ULID carrying this generator's prefix
+func (p *PrefixedGenerator) IsIdValid(id string) bool {
+	_, err := p.parse(id)
+	return err == nil
+}
+
+// ValidateAndNormalize checks a prefixed ID and returns it with a normalized ULID
+func (p *PrefixedGenerator) ValidateAndNormalize(id string) (string, error) {
+	parsed, err := p.parse(id)
+	if err != nil {
+		return "", err
+	}
+	return p.wrap(p.gen.encode(parsed)), nil
+}
+
+// ExtractTimestamp returns the timestamp component of a prefixed ID
+func (p *PrefixedGenerator) ExtractTimestamp(id string) (time.Time, error) {
+	parsed, err := p.parse(id)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return ulid.Time(parsed.Time()), nil
+}
+
+// Age returns how old a prefixed ID is
+func (p *PrefixedGenerator) Age(id string) (time.Duration, error) {
+	timestamp, err := p.ExtractTimestamp(id)
+	if err != nil {
+		return 0, err
+	}
+	return p.gen.now().Sub(timestamp), nil
+}
+
+// IsExpired checks if a prefixed ID is older than maxAge
+func (p *PrefixedGenerator) IsExpired(id string, maxAge time.Duration) (bool, error) {
+	age, err := p.Age(id)
+	if err != nil {
+		return false, err
+	}
+	return age > maxAge, nil
+}
+
+// Compare returns -1, 0, or 1 for chronological ordering of two prefixed IDs
+func (p *PrefixedGenerator) Compare(id1, id2 string) (int, error) {
+	ulid1, err := p.parse(id1)
+	if err != nil {
+		return 0, fmt.Errorf("invalid first ID: %w", err)
+	}
+	ulid2, err := p.parse(id2)
+	if err != nil {
+		return 0, fmt.Errorf("invalid second ID: %w", err)
+	}
+	return ulid1.Compare(ulid2), nil
+}
+
+// IsBefore checks if id1 was generated before id2
+func (p *PrefixedGenerator) IsBefore(id1, id2 string) (bool, error) {
+	cmp, err := p.Compare(id1, id2)
+	if err != nil {
+		return false, err
+	}
+	return cmp < 0, nil
+}
+
+// IsAfter checks if id1 was generated after id2
+func (p *PrefixedGenerator) IsAfter(id1, id2 string) (bool, error) {
+	cmp, err := p.Compare(id1, id2)
+	if err != nil {
+		return false, err
+	}
+	return cmp > 0, nil
+}
+
+// ToBytes returns the binary representation of the ULID inside a prefixed ID
+func (p *PrefixedGenerator) ToBytes(id string) ([16]byte, error) {
+	parsed, err := p.parse(id)
+	if err != nil {
+		return [16]byte{}, err
+	}
+	return parsed, nil
+}
+
+// FromBytes creates a prefixed ID from a binary ULID
+func (p *PrefixedGenerator) FromBytes(data [16]byte) string {
+	return p.wrap(p.gen.FromBytes(data))
+}
+
+// ToUUID converts the ULID inside a prefixed ID to UUID format
+func (p *PrefixedGenerator) ToUUID(id string) (string, error) {
+	raw, err := p.ToBytes(id)
+	if err != nil {
+		return "", err
+	}
+	return formatUUID(raw), nil
+}
+
+// wrap prepends the prefix to a ULID
+func (p *PrefixedGenerator) wrap(id string) string {
+	return p.prefix + string(PrefixSeparator) + id
+}
+
+// wrapAll prepends the prefix to every ULID in ids
+func (p *PrefixedGenerator) wrapAll(ids []string) []string {
+	for i, id := range ids {
+		ids[i] = p.wrap(id)
+	}
+	return ids
+}
+
+// parse checks the prefix of id and decodes its ULID in the base generator's format
+func (p *PrefixedGenerator) parse(id string) (ulid.ULID, error) {
+	prefix, rest, ok := cutPrefixed(id)
+	if !ok {
+		return ulid.ULID{}, fmt.Errorf("invalid prefixed ID %q: missing %q separator", id, PrefixSeparator)
+	}
+	if prefix != p.prefix {
+		return ulid.ULID{}, fmt.Errorf("%w: got %q, want %q", ErrPrefixMismatch, prefix, p.prefix)
+	}
+	parsed, err := p.gen.parse(rest)
+	if err != nil {
+		return ulid.ULID{}, fmt.Errorf("invalid ULID: %w", err)
+	}
+	return parsed, nil
+}
+
+// splitPrefixed splits a prefixed ID into its prefix and canonical-form ULID
+func splitPrefixed(id string) (string, ulid.ULID, error) {
+	prefix, rest, ok := cutPrefixed(id)
+	if !ok {
+		return "", ulid.ULID{}, fmt.Errorf("invalid prefixed ID %q: missing %q separator", id, PrefixSeparator)
+	}
+	parsed, err := parseCanonical(rest)
+	if err != nil {
+		return "", ulid.ULID{}, fmt.Errorf("invalid ULID: %w", err)
+	}
+	return prefix, parsed, nil
+}
+
+// cutPrefixed splits id at its last separator, since prefixes may contain
+// separators but ULIDs never do
+func cutPrefixed(id string) (prefix, rest string, ok bool) {
+	i := strings.LastIndexByte(id, PrefixSeparator)
+	if i <= 0 {
+		return "", "", false
+	}
+	return id[:i], id[i+1:], true
+}
+
+// validatePrefix requires lowercase letters, digits, and inner separators, starting with a letter
+func validatePrefix(prefix string) error {
+	if prefix == "" {
+		return errors.New("invalid ID prefix: empty")
+	}
+	for i, c := range prefix {
+		switch {
+		case c >= 'a' && c <= 'z':
+		case (c >= '0' && c <= '9') && i > 0:
+		case c == PrefixSeparator && i > 0 && i < len(prefix)-1:
+		default:
+			return fmt.Errorf("invalid ID prefix %q: use lowercase letters, digits, and inner %q, starting with a letter", prefix, PrefixSeparator)
+		}
+	}
+	return nil
+}
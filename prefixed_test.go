@@ -0,0 +1,151 @@
+package id_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ id.Provider = (*id.PrefixedGenerator)(nil)
+
+func Test_PrefixedGenerator_RoundTrip(t *testing.T) {
+	gen, err := id.NewPrefixedGenerator("cus")
+	require.NoError(t, err)
+	ts := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	first := gen.GenerateWithTime(ts)
+	second := gen.GenerateWithTime(ts.Add(time.Second))
+
+	// Assert
+	assert.True(t, strings.HasPrefix(first, "cus_"))
+	assert.Len(t, first, 30)
+	assert.True(t, gen.IsIdValid(first))
+
+	extracted, err := gen.ExtractTimestamp(first)
+	require.NoError(t, err)
+	assert.True(t, extracted.Equal(ts))
+
+	before, err := gen.IsBefore(first, second)
+	require.NoError(t, err)
+	assert.True(t, before)
+
+	stripped, err := gen.Strip(first)
+	require.NoError(t, err)
+	assert.Equal(t, first[4:], stripped)
+
+	normalized, err := gen.ValidateAndNormalize("cus_" + strings.ToLower(stripped))
+	require.NoError(t, err)
+	assert.Equal(t, first, normalized)
+
+	raw, err := gen.ToBytes(first)
+	require.NoError(t, err)
+	assert.Equal(t, first, gen.FromBytes(raw))
+
+	for _, s := range gen.GenerateBatch(3) {
+		assert.True(t, gen.IsIdValid(s))
+	}
+}
+
+func Test_PrefixedGenerator_RejectsOtherPrefixes(t *testing.T) {
+	customers, err := id.NewPrefixedGenerator("cus")
+	require.NoError(t, err)
+	orders, err := id.NewPrefixedGenerator("ord")
+	require.NoError(t, err)
+	order := orders.Generate()
+
+	// Act
+	_, err = customers.ExtractTimestamp(order)
+
+	// Assert
+	require.ErrorIs(t, err, id.ErrPrefixMismatch)
+	assert.False(t, customers.IsIdValid(order))
+	assert.False(t, customers.IsIdValid(order[4:]))
+	_, err = customers.Compare(customers.Generate(), order)
+	assert.Error(t, err)
+}
+
+func Test_PrefixRegistry(t *testing.T) {
+	registry := id.NewPrefixRegistry()
+	require.NoError(t, registry.Register("cus", "customer"))
+	require.NoError(t, registry.Register("sub_sched", "subscription_schedule"))
+	assert.Error(t, registry.Register("cus", "other"))
+	schedules, err := id.NewPrefixedGenerator("sub_sched")
+	require.NoError(t, err)
+	generated := schedules.Generate()
+
+	// Act
+	kind, ulid, err := registry.Parse(generated)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "subscription_schedule", kind)
+	assert.Equal(t, generated[len("sub_sched_"):], ulid)
+
+	_, _, err = registry.Parse("ord_" + ulid)
+	assert.ErrorIs(t, err, id.ErrUnknownPrefix)
+	_, _, err = registry.Parse(ulid)
+	assert.Error(t, err)
+	_, _, err = registry.Parse("cus_bogus")
+	assert.Error(t, err)
+	_, _, err = registry.Parse("sub_sched_" + ulid[:24] + "!!")
+	var charErr id.ErrInvalidCharacter
+	assert.ErrorAs(t, err, &charErr)
+}
+
+func Test_PrefixRegistry_Analysis(t *testing.T) {
+	registry := id.NewPrefixRegistry()
+	require.NoError(t, registry.Register("cus", "customer"))
+	require.NoError(t, registry.Register("ord", "order"))
+	customers, err := id.NewPrefixedGenerator("cus")
+	require.NoError(t, err)
+	orders, err := id.NewPrefixedGenerator("ord")
+	require.NoError(t, err)
+	base := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	first := customers.GenerateWithTime(base)
+	second := orders.GenerateWithTime(base.Add(time.Minute))
+	third := customers.GenerateWithTime(base.Add(2 * time.Minute))
+	unknown := "inv_" + first[len("cus_"):]
+	ids := []string{third, unknown, first, "cus_bogus", second}
+
+	// Act
+	sorted := registry.SortChronologically(ids)
+	filtered := registry.FilterByTimeRange(ids, base.Add(time.Minute), base.Add(2*time.Minute))
+	stats, err := registry.AnalyzeIDs(ids)
+
+	// Assert
+	assert.Equal(t, []string{first, second, third, unknown, "cus_bogus"}, sorted)
+	assert.Equal(t, []string{third, second}, filtered)
+	require.NoError(t, err)
+	assert.Equal(t, 3, stats.Count)
+	assert.Equal(t, first, stats.FirstID)
+	assert.Equal(t, third, stats.LastID)
+	assert.Equal(t, 2*time.Minute, stats.TimeSpan)
+
+	_, err = registry.AnalyzeIDs([]string{unknown})
+	assert.Error(t, err)
+}
+
+func Test_ParsePrefixed_Default(t *testing.T) {
+	require.NoError(t, id.RegisterPrefix("prefixtest", "test"))
+	gen, err := id.NewPrefixedGenerator("prefixtest")
+	require.NoError(t, err)
+
+	// Act
+	kind, _, err := id.ParsePrefixed(gen.Generate())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "test", kind)
+}
+
+func Test_NewPrefixedGenerator_InvalidPrefix(t *testing.T) {
+	for _, prefix := range []string{"", "Cus", "1cus", "cus_", "_cus", "cu-s"} {
+		_, err := id.NewPrefixedGenerator(prefix)
+		assert.Error(t, err, prefix)
+	}
+}
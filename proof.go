@@ -0,0 +1,250 @@
+package id
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Proof links one issued ULID into a generator's hash chain.
+// Hash is SHA-256 over Prev followed by the ULID's 16 binary bytes.
+type Proof struct {
+	Index uint64
+	ID    string
+	Prev  [32]byte
+	Hash  [32]byte
+}
+
+// Checkpoint is a position in a hash chain: the Index the next proof carries
+// and the Hash it must name as Prev. The zero Checkpoint is the start of a
+// chain.
+type Checkpoint struct {
+	Index uint64
+	Hash  [32]byte
+}
+
+// ProvingGenerator wraps a Batcher and records every ULID it issues in an
+// append-only SHA-256 hash chain. An auditor holding the proofs can detect
+// any ULID inserted into, removed from, or reordered within the issuance log.
+// The generator retains every proof until Prune or SetMaxProofs drops the
+// oldest, which an auditor should have archived first. Generation holds the
+// chain's lock, so concurrent calls are chained in the order they issue IDs.
+// The wrapped generator must issue ULIDs in canonical form; generation
+// panics on any other ID rather than record a proof that cannot be checked.
+type ProvingGenerator struct {
+	Batcher
+	mu        sync.Mutex
+	base      Checkpoint
+	proofs    []Proof
+	index     map[string]uint64
+	maxProofs int
+}
+
+// NewProvingGenerator wraps gen so that every generated ULID is chained
+func NewProvingGenerator(gen Batcher) *ProvingGenerator {
+	return &ProvingGenerator{
+		Batcher: gen,
+		index:   make(map[string]uint64),
+	}
+}
+
+// Generate issues a new ULID and appends it to the chain
+func (p *ProvingGenerator) Generate() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	id := p.Batcher.Generate()
+	p.record(id)
+	return id
+}
+
+// GenerateWithTime issues a ULID with a specific timestamp and appends it to the chain
+func (p *ProvingGenerator) GenerateWithTime(t time.Time) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	id := p.Batcher.GenerateWithTime(t)
+	p.record(id)
+	return id
+}
+
+// GenerateBatch issues multiple ULIDs and appends them to the chain in order
+func (p *ProvingGenerator) GenerateBatch(count int) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ids := p.Batcher.GenerateBatch(count)
+	p.record(ids...)
+	return ids
+}
+
+// GenerateRange issues ULIDs across a time range and appends them to the chain in order
+func (p *ProvingGenerator) GenerateRange(start, end time.Time, count int) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ids := p.Batcher.GenerateRange(start, end, count)
+	p.record(ids...)
+	return ids
+}
+
+// ProofFor returns the chain entry recorded for a ULID that has not been pruned
+func (p *ProvingGenerator) ProofFor(id string) (Proof, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	i, ok := p.index[id]
+	if !ok {
+		return Proof{}, fmt.Errorf("no proof recorded for %q", id)
+	}
+	return p.proofs[i-p.base.Index], nil
+}
+
+// Proofs returns a copy of the retained chain in issuance order, which
+// VerifySegment checks from Base
+func (p *ProvingGenerator) Proofs() []Proof {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	result := make([]Proof, len(p.proofs))
+	copy(result, p.proofs)
+	return result
+}
+
+// Head returns the hash of the most recent chain entry, or the zero hash if
+// nothing has been issued. Publishing the head periodically pins the chain.
+func (p *ProvingGenerator) Head() [32]byte {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.next().Hash
+}
+
+// Base returns the checkpoint the retained proofs continue from, which is
+// the zero Checkpoint until proofs are pruned
+func (p *ProvingGenerator) Base() Checkpoint {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.base
+}
+
+// Prune drops all but the newest keep proofs and returns the new Base. The
+// head and the indexes of later proofs are unchanged, so the chain continues
+// across the cut.
+func (p *ProvingGenerator) Prune(keep int) Checkpoint {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.prune(keep)
+	return p.base
+}
+
+// SetMaxProofs bounds the retained chain to the newest n proofs, pruning
+// older ones as new IDs are issued. A non-positive n retains every proof.
+func (p *ProvingGenerator) SetMaxProofs(n int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.maxProofs = max(n, 0)
+	if p.maxProofs > 0 {
+		p.prune(p.maxProofs)
+	}
+}
+
+// next returns the checkpoint after the newest proof; callers must hold p.mu
+func (p *ProvingGenerator) next() Checkpoint {
+	if n := len(p.proofs); n > 0 {
+		last := p.proofs[n-1]
+		return Checkpoint{Index: last.Index + 1, Hash: last.Hash}
+	}
+	return p.base
+}
+
+// prune drops all but the newest keep proofs; callers must hold p.mu
+func (p *ProvingGenerator) prune(keep int) {
+	drop := len(p.proofs) - max(keep, 0)
+	if drop <= 0 {
+		return
+	}
+	for _, proof := range p.proofs[:drop] {
+		delete(p.index, proof.ID)
+	}
+	last := p.proofs[drop-1]
+	p.base = Checkpoint{Index: last.Index + 1, Hash: last.Hash}
+	// Copying lets the dropped proofs be collected
+	p.proofs = append([]Proof(nil), p.proofs[drop:]...)
+}
+
+// record appends ids to the chain in order; callers must hold p.mu, so the
+// chain follows issuance order. It panics without appending anything if an
+// ID is not a ULID in canonical form, such as one from a generator with
+// another scheme or a grouped format, since its proof could not be verified.
+func (p *ProvingGenerator) record(ids ...string) {
+	next := p.next()
+	proofs := make([]Proof, len(ids))
+	for i, id := range ids {
+		hash, err := chainHash(next.Hash, id)
+		if err != nil {
+			panic(fmt.Sprintf("id: cannot chain %q: %v", id, err))
+		}
+		proofs[i] = Proof{Index: next.Index, ID: id, Prev: next.Hash, Hash: hash}
+		next = Checkpoint{Index: next.Index + 1, Hash: hash}
+	}
+
+	for _, proof := range proofs {
+		p.index[proof.ID] = proof.Index
+	}
+	p.proofs = append(p.proofs, proofs...)
+	if p.maxProofs > 0 {
+		p.prune(p.maxProofs)
+	}
+}
+
+// VerifyChain checks that proofs form an unbroken hash chain starting at
+// index 0: every index is sequential, every Prev matches the preceding Hash,
+// and every Hash is recomputed correctly from its ULID
+func VerifyChain(proofs []Proof) error {
+	return VerifySegment(Checkpoint{}, proofs)
+}
+
+// VerifySegment checks that proofs continue the chain from checkpoint from,
+// such as the Base of a pruned ProvingGenerator or the head an auditor
+// recorded at the end of the last segment it verified
+func VerifySegment(from Checkpoint, proofs []Proof) error {
+	prev := from.Hash
+	for i, proof := range proofs {
+		want := from.Index + uint64(i) //nolint:gosec // G115: i is non-negative
+		if proof.Index != want {
+			return fmt.Errorf("proof %d: index is %d", want, proof.Index)
+		}
+		if proof.Prev != prev {
+			return fmt.Errorf("proof %d: previous hash does not match", want)
+		}
+		hash, err := chainHash(prev, proof.ID)
+		if err != nil {
+			return fmt.Errorf("proof %d: %w", want, err)
+		}
+		if proof.Hash != hash {
+			return fmt.Errorf("proof %d: hash does not match", want)
+		}
+		prev = proof.Hash
+	}
+	return nil
+}
+
+// chainHash computes SHA-256(prev || binary ULID)
+func chainHash(prev [32]byte, id string) ([32]byte, error) {
+	parsed, err := parseCanonical(id)
+	if err != nil {
+		return [32]byte{}, fmt.Errorf("invalid ULID: %w", err)
+	}
+
+	h := sha256.New()
+	h.Write(prev[:])
+	h.Write(parsed[:])
+	var sum [32]byte
+	copy(sum[:], h.Sum(nil))
+	return sum, nil
+}
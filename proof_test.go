@@ -0,0 +1,163 @@
+package id_test
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ProvingGenerator(t *testing.T) {
+	gen := id.NewProvingGenerator(id.NewGenerator())
+
+	// Act
+	first := gen.Generate()
+	second := gen.GenerateWithTime(time.Now())
+	third := gen.Generate()
+	batch := gen.GenerateBatch(2)
+
+	// Assert
+	proof, err := gen.ProofFor(second)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), proof.Index)
+	assert.Equal(t, second, proof.ID)
+
+	proofs := gen.Proofs()
+	require.Len(t, proofs, 5)
+	issued := make([]string, len(proofs))
+	for i, p := range proofs {
+		issued[i] = p.ID
+	}
+	assert.Equal(t, []string{first, second, third, batch[0], batch[1]}, issued)
+	assert.Equal(t, proofs[4].Hash, gen.Head())
+	assert.NoError(t, id.VerifyChain(proofs))
+
+	_, err = gen.ProofFor("01ARZ3NDEKTSV4RRFFQ69G5FAV")
+	assert.Error(t, err)
+}
+
+func Test_ProvingGenerator_ChainsInIssuanceOrder(t *testing.T) {
+	gen := id.NewProvingGenerator(id.NewGenerator(id.WithMonotonic(true)))
+	var wg sync.WaitGroup
+
+	// Act
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				gen.Generate()
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	proofs := gen.Proofs()
+	require.Len(t, proofs, 800)
+	require.NoError(t, id.VerifyChain(proofs))
+	for i := 1; i < len(proofs); i++ {
+		require.Less(t, proofs[i-1].ID, proofs[i].ID, "chained out of issuance order at %d", i)
+	}
+}
+
+func Test_ProvingGenerator_RejectsNonULIDs(t *testing.T) {
+	gen := id.NewProvingGenerator(id.NewGenerator(id.WithScheme(id.SchemeKSUID)))
+
+	// Act & Assert
+	assert.Panics(t, func() { gen.Generate() })
+	assert.Panics(t, func() { gen.GenerateBatch(2) })
+	assert.Empty(t, gen.Proofs())
+	assert.NotPanics(t, func() { gen.Head() }, "the lock is released after a panic")
+}
+
+func Test_VerifyChain_DetectsTampering(t *testing.T) {
+	gen := id.NewProvingGenerator(id.NewGenerator())
+	for i := 0; i < 5; i++ {
+		gen.Generate()
+	}
+	proofs := gen.Proofs()
+
+	// Removed entry
+	removed := append(append([]id.Proof{}, proofs[:2]...), proofs[3:]...)
+	assert.Error(t, id.VerifyChain(removed))
+
+	// Replaced ID
+	replaced := append([]id.Proof{}, proofs...)
+	replaced[2].ID = id.NewGenerator().Generate()
+	assert.Error(t, id.VerifyChain(replaced))
+
+	// Reordered entries
+	reordered := append([]id.Proof{}, proofs...)
+	reordered[1], reordered[2] = reordered[2], reordered[1]
+	assert.Error(t, id.VerifyChain(reordered))
+
+	// Invalid characters that a lenient parse would decode to the same bytes
+	saturated := id.NewProvingGenerator(id.NewGenerator(id.WithEntropy(constReader(0xFF))))
+	issued := saturated.Generate()
+	require.True(t, strings.HasSuffix(issued, "ZZ"))
+	mangled := saturated.Proofs()
+	mangled[0].ID = issued[:24] + "!!"
+	assert.Error(t, id.VerifyChain(mangled))
+
+	// Empty chain is trivially valid
+	assert.NoError(t, id.VerifyChain(nil))
+}
+
+func Test_ProvingGenerator_Prune(t *testing.T) {
+	gen := id.NewProvingGenerator(id.NewGenerator())
+	issued := gen.GenerateBatch(10)
+	archived := gen.Proofs()
+	head := gen.Head()
+
+	// Act
+	base := gen.Prune(3)
+	gen.Generate()
+
+	// Assert
+	retained := gen.Proofs()
+	require.Len(t, retained, 4)
+	assert.Equal(t, id.Checkpoint{Index: 7, Hash: archived[6].Hash}, base)
+	assert.Equal(t, base, gen.Base())
+	assert.Equal(t, head, retained[3].Prev, "the chain continues across the cut")
+	assert.NoError(t, id.VerifySegment(base, retained))
+	assert.Error(t, id.VerifyChain(retained), "a pruned segment does not start at index 0")
+	assert.NoError(t, id.VerifyChain(append(archived, retained[3])))
+	_, err := gen.ProofFor(issued[0])
+	assert.Error(t, err)
+	proof, err := gen.ProofFor(issued[9])
+	require.NoError(t, err)
+	assert.Equal(t, uint64(9), proof.Index)
+}
+
+func Test_ProvingGenerator_SetMaxProofs(t *testing.T) {
+	gen := id.NewProvingGenerator(id.NewGenerator())
+	gen.SetMaxProofs(5)
+
+	// Act
+	gen.GenerateBatch(12)
+
+	// Assert
+	proofs := gen.Proofs()
+	require.Len(t, proofs, 5)
+	assert.Equal(t, uint64(7), proofs[0].Index)
+	assert.NoError(t, id.VerifySegment(gen.Base(), proofs))
+	assert.Equal(t, proofs[4].Hash, gen.Head())
+}
+
+func Test_VerifySegment_FromRecordedHead(t *testing.T) {
+	gen := id.NewProvingGenerator(id.NewGenerator())
+	gen.GenerateBatch(4)
+	checkpoint := id.Checkpoint{Index: 4, Hash: gen.Head()}
+	gen.GenerateBatch(3)
+	segment := gen.Proofs()[4:]
+
+	// Act & Assert
+	assert.NoError(t, id.VerifySegment(checkpoint, segment))
+	assert.Error(t, id.VerifySegment(id.Checkpoint{Index: 4}, segment))
+	assert.Error(t, id.VerifySegment(checkpoint, segment[1:]))
+}
@@ -0,0 +1,188 @@
+package id
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrQuotaExceeded is returned when a label has used its full quota for the current window
+var ErrQuotaExceeded = errors.New("quota exceeded")
+
+// QuotaStore persists issuance events per label.
+// Implementations must be safe for concurrent use.
+type QuotaStore interface {
+	// Record notes that one ID was issued for label at the given time
+	Record(label string, at time.Time) error
+	// Count returns how many IDs were issued for label at or after since
+	Count(label string, since time.Time) (int, error)
+}
+
+// QuotaPruner is implemented by a QuotaStore that can discard old events.
+// After recording an event Quota prunes the label's events that have left
+// its window, as measured on its Clock.
+type QuotaPruner interface {
+	// Prune discards the events recorded for label before cutoff
+	Prune(label string, before time.Time) error
+}
+
+// MemoryQuotaStore is an in-process QuotaStore. Count only reads; Quota
+// keeps the store bounded through Prune, so a store should back a single
+// Quota.
+type MemoryQuotaStore struct {
+	mu     sync.Mutex
+	events map[string][]time.Time
+}
+
+// NewMemoryQuotaStore creates an empty in-process QuotaStore
+func NewMemoryQuotaStore() *MemoryQuotaStore {
+	return &MemoryQuotaStore{
+		events: make(map[string][]time.Time),
+	}
+}
+
+// Record notes that one ID was issued for label
+func (s *MemoryQuotaStore) Record(label string, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.events[label] = append(s.events[label], at)
+	return nil
+}
+
+// Count returns how many IDs were issued for label at or after since
+func (s *MemoryQuotaStore) Count(label string, since time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	events := s.events[label]
+	return len(events) - firstAtOrAfter(events, since), nil
+}
+
+// Prune discards the events recorded for label before cutoff
+func (s *MemoryQuotaStore) Prune(label string, before time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	events := s.events[label]
+	cut := firstAtOrAfter(events, before)
+	if cut == len(events) {
+		delete(s.events, label)
+		return nil
+	}
+	s.events[label] = events[cut:]
+	return nil
+}
+
+// firstAtOrAfter returns the position of the first event at or after t in
+// events, which are recorded in time order
+func firstAtOrAfter(events []time.Time, t time.Time) int {
+	return sort.Search(len(events), func(i int) bool {
+		return !events[i].Before(t)
+	})
+}
+
+// Quota meters ID issuance per label (tenant, API key, ...) over a sliding
+// window and refuses to generate once a label's limit is reached
+type Quota struct {
+	gen    Generator
+	store  QuotaStore
+	limit  int
+	window time.Duration
+	clock  Clock
+
+	mu     sync.Mutex
+	limits map[string]int
+}
+
+// NewQuota creates a Quota allowing limit IDs per label within each sliding window
+func NewQuota(gen Generator, store QuotaStore, limit int, window time.Duration) *Quota {
+	return &Quota{
+		gen:    gen,
+		store:  store,
+		limit:  limit,
+		window: window,
+		clock:  SystemClock,
+		limits: make(map[string]int),
+	}
+}
+
+// SetLimit overrides the default limit for one label
+func (q *Quota) SetLimit(label string, limit int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.limits[label] = limit
+}
+
+// SetClock makes the quota measure its window on clock, or SystemClock if
+// clock is nil
+func (q *Quota) SetClock(clock Clock) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.clock = clockOrSystem(clock)
+}
+
+// Generate issues an ID attributed to label, or returns ErrQuotaExceeded
+func (q *Quota) Generate(label string) (string, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := q.clock.Now()
+	since := now.Add(-q.window)
+	used, err := q.store.Count(label, since)
+	if err != nil {
+		return "", fmt.Errorf("quota store: %w", err)
+	}
+	if used >= q.limitFor(label) {
+		return "", fmt.Errorf("%w for %q", ErrQuotaExceeded, label)
+	}
+
+	id := q.gen.Generate()
+	if err := q.store.Record(label, now); err != nil {
+		return "", fmt.Errorf("quota store: %w", err)
+	}
+	if pruner, ok := q.store.(QuotaPruner); ok {
+		if err := pruner.Prune(label, since); err != nil {
+			return "", fmt.Errorf("quota store: %w", err)
+		}
+	}
+	return id, nil
+}
+
+// Usage returns how many IDs label has been issued within the current window
+func (q *Quota) Usage(label string) (int, error) {
+	q.mu.Lock()
+	since := q.clock.Now().Add(-q.window)
+	q.mu.Unlock()
+
+	return q.store.Count(label, since)
+}
+
+// Remaining returns how many more IDs label may be issued within the current window
+func (q *Quota) Remaining(label string) (int, error) {
+	used, err := q.Usage(label)
+	if err != nil {
+		return 0, err
+	}
+
+	q.mu.Lock()
+	limit := q.limitFor(label)
+	q.mu.Unlock()
+
+	if used >= limit {
+		return 0, nil
+	}
+	return limit - used, nil
+}
+
+// limitFor returns the effective limit for label; callers must hold q.mu
+func (q *Quota) limitFor(label string) int {
+	if limit, ok := q.limits[label]; ok {
+		return limit
+	}
+	return q.limit
+}
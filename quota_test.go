@@ -0,0 +1,118 @@
+package id_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Quota_Generate(t *testing.T) {
+	gen := id.NewGenerator()
+	q := id.NewQuota(gen, id.NewMemoryQuotaStore(), 3, time.Hour)
+	q.SetLimit("premium", 5)
+
+	// Act
+	for i := 0; i < 3; i++ {
+		ulid, err := q.Generate("tenant-a")
+		require.NoError(t, err)
+		assert.True(t, gen.IsIdValid(ulid))
+	}
+	_, err := q.Generate("tenant-a")
+
+	// Assert
+	assert.ErrorIs(t, err, id.ErrQuotaExceeded)
+
+	used, err := q.Usage("tenant-a")
+	require.NoError(t, err)
+	assert.Equal(t, 3, used)
+
+	remaining, err := q.Remaining("tenant-b")
+	require.NoError(t, err)
+	assert.Equal(t, 3, remaining)
+
+	remaining, err = q.Remaining("premium")
+	require.NoError(t, err)
+	assert.Equal(t, 5, remaining)
+}
+
+func Test_Quota_WindowSlides(t *testing.T) {
+	clock := id.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	q := id.NewQuota(id.NewGenerator(), id.NewMemoryQuotaStore(), 1, time.Hour)
+	q.SetClock(clock)
+
+	_, err := q.Generate("tenant")
+	require.NoError(t, err)
+	clock.Advance(59 * time.Minute)
+	_, err = q.Generate("tenant")
+	require.ErrorIs(t, err, id.ErrQuotaExceeded)
+
+	// Act
+	clock.Advance(2 * time.Minute)
+	_, err = q.Generate("tenant")
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+type failingQuotaStore struct{}
+
+func (failingQuotaStore) Record(string, time.Time) error       { return errors.New("down") }
+func (failingQuotaStore) Count(string, time.Time) (int, error) { return 0, errors.New("down") }
+
+func Test_Quota_StoreErrors(t *testing.T) {
+	q := id.NewQuota(id.NewGenerator(), failingQuotaStore{}, 10, time.Hour)
+
+	// Act
+	_, err := q.Generate("tenant")
+
+	// Assert
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, id.ErrQuotaExceeded)
+}
+
+func Test_MemoryQuotaStore_CountOnlyReads(t *testing.T) {
+	store := id.NewMemoryQuotaStore()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := range 3 {
+		require.NoError(t, store.Record("tenant", start.Add(time.Duration(i)*time.Hour)))
+	}
+
+	// Act
+	recent, err := store.Count("tenant", start.Add(2*time.Hour))
+	require.NoError(t, err)
+	all, err := store.Count("tenant", start)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, 1, recent)
+	assert.Equal(t, 3, all, "a narrow count leaves older events in place")
+
+	require.NoError(t, store.Prune("tenant", start.Add(time.Hour)))
+	remaining, err := store.Count("tenant", start)
+	require.NoError(t, err)
+	assert.Equal(t, 2, remaining)
+}
+
+func Test_Quota_PrunesOnItsClock(t *testing.T) {
+	clock := id.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	store := id.NewMemoryQuotaStore()
+	q := id.NewQuota(id.NewGenerator(), store, 10, time.Hour)
+	q.SetClock(clock)
+	_, err := q.Generate("tenant")
+	require.NoError(t, err)
+	early := clock.Now()
+
+	// Act
+	clock.Advance(2 * time.Hour)
+	_, err = q.Generate("tenant")
+	require.NoError(t, err)
+
+	// Assert
+	count, err := store.Count("tenant", early)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count, "events outside the window are pruned when recording")
+}
@@ -0,0 +1,161 @@
+package id_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/oklog/ulid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RegionMap(t *testing.T) {
+	regions, err := id.NewRegionMap(4, map[string]uint16{"us-east": 1, "eu-west": 2, "ap-south": 15})
+	require.NoError(t, err)
+
+	for _, region := range []string{"us-east", "eu-west", "ap-south"} {
+		gen, err := regions.NewGenerator(region)
+		require.NoError(t, err)
+		assert.Equal(t, region, gen.Region())
+
+		// Act
+		ids := append(gen.GenerateBatch(10), gen.GenerateRange(time.Now().Add(-time.Hour), time.Now(), 5)...)
+
+		// Assert
+		for _, ulid := range ids {
+			assert.True(t, gen.IsIdValid(ulid))
+			extracted, err := regions.ExtractRegion(ulid)
+			require.NoError(t, err)
+			assert.Equal(t, region, extracted)
+			assert.NoError(t, regions.ValidateRegion(ulid))
+		}
+	}
+}
+
+func Test_WithRegion_StaysOrderedWithinMillisecond(t *testing.T) {
+	regions, err := id.NewRegionMap(id.MaxRegionBits, map[string]uint16{"us-east": 0x1234})
+	require.NoError(t, err)
+	clock := id.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	// Increments near the width of the bits below the region carry into it
+	// every other ID
+	gen := id.NewGenerator(
+		id.WithClock(clock),
+		id.WithEntropy(ulid.Monotonic(constReader(0x11), 1<<45)),
+		id.WithRegion(regions, "us-east"),
+		id.WithNode(7),
+	)
+
+	// Act
+	ids := gen.GenerateBatch(5000)
+	for streamed := range gen.StreamIDs(context.Background()) {
+		ids = append(ids, streamed.String())
+		if len(ids) == 10000 {
+			break
+		}
+	}
+
+	// Assert
+	for i := 1; i < len(ids); i++ {
+		require.Less(t, ids[i-1], ids[i], "out of order at %d", i)
+	}
+	for _, generated := range ids {
+		extracted, err := regions.ExtractRegion(generated)
+		require.NoError(t, err)
+		require.Equal(t, "us-east", extracted)
+	}
+}
+
+func Test_RegionMap_Unknown(t *testing.T) {
+	regions, err := id.NewRegionMap(4, map[string]uint16{"us-east": 1})
+	require.NoError(t, err)
+
+	// Act & Assert
+	_, err = regions.NewGenerator("mars")
+	assert.ErrorIs(t, err, id.ErrUnknownRegion)
+
+	assert.ErrorIs(t, regions.ValidateRegion("00000000000000000000000000"), id.ErrUnknownRegion)
+	assert.Error(t, regions.ValidateRegion("invalid"))
+}
+
+func Test_NewRegionMap_Errors(t *testing.T) {
+	_, err := id.NewRegionMap(0, nil)
+	assert.Error(t, err)
+	_, err = id.NewRegionMap(17, nil)
+	assert.Error(t, err)
+	_, err = id.NewRegionMap(2, map[string]uint16{"too-big": 4})
+	assert.Error(t, err)
+	_, err = id.NewRegionMap(2, map[string]uint16{"a": 1, "b": 1})
+	assert.Error(t, err)
+}
+
+func Test_RegionGenerator_EveryPathStamps(t *testing.T) {
+	regions, err := id.NewRegionMap(4, map[string]uint16{"us-east": 9})
+	require.NoError(t, err)
+	gen, err := regions.NewGenerator("us-east")
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var ids []string
+	ids = append(ids, gen.Generate(), gen.GenerateID().String(), gen.GenerateLabeled(ctx, nil))
+	tried, err := gen.TryGenerate()
+	require.NoError(t, err)
+	ids = append(ids, tried)
+	withContext, err := gen.GenerateContext(ctx)
+	require.NoError(t, err)
+	ids = append(ids, withContext)
+	batch, err := gen.GenerateBatchContext(ctx, 3)
+	require.NoError(t, err)
+	ids = append(ids, batch...)
+	ids = append(ids, <-gen.Stream(ctx))
+	for streamed := range gen.StreamIDs(ctx) {
+		ids = append(ids, streamed.String())
+		break
+	}
+	var buf bytes.Buffer
+	require.NoError(t, gen.GenerateBatchTo(&buf, 2, '\n'))
+	ids = append(ids, strings.Fields(buf.String())...)
+	reversed, err := id.ReverseID(gen.GenerateReverse())
+	require.NoError(t, err)
+	ids = append(ids, reversed)
+	fromUUID, err := gen.FromUUID(gen.GenerateUUIDv7())
+	require.NoError(t, err)
+	ids = append(ids, fromUUID)
+	lower := gen.WithFormatProfile(id.FormatProfile{Case: id.CaseLower})
+	ids = append(ids, lower.Generate())
+
+	for _, ulid := range ids {
+		// Act
+		region, err := regions.ExtractRegion(ulid)
+
+		// Assert
+		require.NoError(t, err, ulid)
+		assert.Equal(t, "us-east", region, ulid)
+	}
+}
+
+func Test_WithRegion_Unknown(t *testing.T) {
+	regions, err := id.NewRegionMap(4, map[string]uint16{"us-east": 1})
+	require.NoError(t, err)
+
+	// Act & Assert
+	assert.Panics(t, func() { id.WithRegion(regions, "mars") })
+}
+
+func Test_RegionMap_NewGeneratorWithBase_LeavesBaseUnstamped(t *testing.T) {
+	regions, err := id.NewRegionMap(4, map[string]uint16{"us-east": 1})
+	require.NoError(t, err)
+	base := id.NewGeneratorWithEntropy(zeroReader{})
+	_, err = regions.NewGeneratorWithBase("us-east", base)
+	require.NoError(t, err)
+
+	// Act
+	_, err = regions.ExtractRegion(base.Generate())
+
+	// Assert
+	assert.ErrorIs(t, err, id.ErrUnknownRegion)
+}
@@ -0,0 +1,59 @@
+package id
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TimeFormat controls how Stats and Histogram render times, so reports can
+// show business-local times rather than UTC or the server's zone
+type TimeFormat struct {
+	// Location is the zone times are shown in; nil means UTC
+	Location *time.Location
+	// Layout is a time.Time.Format layout; empty means time.RFC3339
+	Layout string
+}
+
+// Format renders t in the format's location and layout
+func (f TimeFormat) Format(t time.Time) string {
+	loc := f.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	layout := f.Layout
+	if layout == "" {
+		layout = time.RFC3339
+	}
+	return t.In(loc).Format(layout)
+}
+
+// Format renders the stats on one line with times in f
+func (s Stats) Format(f TimeFormat) string {
+	if s.Count == 0 {
+		return "0 IDs"
+	}
+	return fmt.Sprintf("%d IDs from %s to %s (%s, %g/s)",
+		s.Count, f.Format(s.FirstTime), f.Format(s.LastTime), s.TimeSpan, s.Rate())
+}
+
+// String renders the stats with times in UTC
+func (s Stats) String() string {
+	return s.Format(TimeFormat{})
+}
+
+// Format renders one line per bucket, "[start, end) count", with bucket
+// boundaries in f. Buckets stay aligned to Interval in UTC; only their
+// rendering changes.
+func (h Histogram) Format(f TimeFormat) string {
+	var b strings.Builder
+	for i, count := range h.Counts {
+		fmt.Fprintf(&b, "[%s, %s) %d\n", f.Format(h.BucketStart(i)), f.Format(h.BucketStart(i+1)), count)
+	}
+	return b.String()
+}
+
+// String renders the histogram with bucket boundaries in UTC
+func (h Histogram) String() string {
+	return h.Format(TimeFormat{})
+}
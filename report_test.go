@@ -0,0 +1,53 @@
+package id_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TimeFormat_Format(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Act & Assert
+	assert.Equal(t, "2024-01-01T00:00:00Z", id.TimeFormat{}.Format(at))
+	assert.Equal(t, "2024-01-01T09:00:00+09:00", id.TimeFormat{Location: tokyo}.Format(at))
+	assert.Equal(t, "Jan  1 09:00 JST", id.TimeFormat{Location: tokyo, Layout: "Jan _2 15:04 MST"}.Format(at))
+}
+
+func Test_Stats_Format(t *testing.T) {
+	gen := id.NewGenerator()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	stats, err := id.AnalyzeIDs([]string{gen.GenerateWithTime(base), gen.GenerateWithTime(base.Add(100 * time.Second))})
+	require.NoError(t, err)
+	newYork := time.FixedZone("EST", -5*60*60)
+
+	// Act
+	report := stats.Format(id.TimeFormat{Location: newYork, Layout: "2006-01-02 15:04:05 MST"})
+
+	// Assert
+	assert.Equal(t, "2 IDs from 2023-12-31 19:00:00 EST to 2023-12-31 19:01:40 EST (1m40s, 0.02/s)", report)
+	assert.Equal(t, "2 IDs from 2024-01-01T00:00:00Z to 2024-01-01T00:01:40Z (1m40s, 0.02/s)", stats.String())
+	assert.Equal(t, "0 IDs", id.Stats{}.String())
+}
+
+func Test_Histogram_Format(t *testing.T) {
+	h := id.Histogram{
+		Start:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Interval: time.Hour,
+		Counts:   []int{2, 0},
+	}
+	kolkata := time.FixedZone("IST", 5*60*60+30*60)
+
+	// Act
+	report := h.Format(id.TimeFormat{Location: kolkata, Layout: "15:04"})
+
+	// Assert
+	assert.Equal(t, "[05:30, 06:30) 2\n[06:30, 07:30) 0\n", report)
+	assert.Equal(t, "[2024-01-01T00:00:00Z, 2024-01-01T01:00:00Z) 2\n[2024-01-01T01:00:00Z, 2024-01-01T02:00:00Z) 0\n", h.String())
+	assert.Empty(t, id.Histogram{}.String())
+}
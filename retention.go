@@ -0,0 +1,54 @@
+package id
+
+import (
+	"time"
+
+	"github.com/oklog/ulid"
+)
+
+// RetentionPolicy describes the age thresholds RetentionPlan applies
+type RetentionPolicy struct {
+	// KeepFor is how long records stay in primary storage
+	KeepFor time.Duration
+	// DeleteAfter is the age at which records are deleted; records between
+	// KeepFor and DeleteAfter go to cold storage. 0 means never delete.
+	DeleteAfter time.Duration
+	// Clock supplies the reference time; nil uses SystemClock
+	Clock Clock
+}
+
+// Retention is the outcome of RetentionPlan: every input ID in exactly one tier
+type Retention struct {
+	Keep    []string
+	Cold    []string
+	Delete  []string
+	Invalid []string
+}
+
+// RetentionPlan assigns each ID to a keep, cold-store, or delete tier based on
+// the age embedded in its timestamp. Boundaries are inclusive on the older
+// side: an ID exactly KeepFor old goes to cold storage. IDs that cannot be
+// parsed are returned separately so they are never silently deleted.
+func RetentionPlan(ids []string, policy RetentionPolicy) Retention {
+	now := clockOrSystem(policy.Clock).Now()
+
+	var plan Retention
+	for _, id := range ids {
+		parsed, err := parseCanonical(id)
+		if err != nil {
+			plan.Invalid = append(plan.Invalid, id)
+			continue
+		}
+
+		age := now.Sub(ulid.Time(parsed.Time()))
+		switch {
+		case policy.DeleteAfter > 0 && age >= policy.DeleteAfter:
+			plan.Delete = append(plan.Delete, id)
+		case age >= policy.KeepFor:
+			plan.Cold = append(plan.Cold, id)
+		default:
+			plan.Keep = append(plan.Keep, id)
+		}
+	}
+	return plan
+}
@@ -0,0 +1,46 @@
+package id_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RetentionPlan(t *testing.T) {
+	gen := id.NewGenerator()
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	fresh := gen.GenerateWithTime(now.Add(-day))
+	boundary := gen.GenerateWithTime(now.Add(-30 * day))
+	cold := gen.GenerateWithTime(now.Add(-90 * day))
+	expired := gen.GenerateWithTime(now.Add(-400 * day))
+	policy := id.RetentionPolicy{
+		KeepFor:     30 * day,
+		DeleteAfter: 365 * day,
+		Clock:       id.NewFakeClock(now),
+	}
+
+	// Act
+	mangled := expired[:24] + "!!"
+	plan := id.RetentionPlan([]string{fresh, boundary, cold, expired, "invalid", mangled}, policy)
+
+	// Assert
+	assert.Equal(t, []string{fresh}, plan.Keep)
+	assert.Equal(t, []string{boundary, cold}, plan.Cold)
+	assert.Equal(t, []string{expired}, plan.Delete)
+	assert.Equal(t, []string{"invalid", mangled}, plan.Invalid)
+}
+
+func Test_RetentionPlan_NeverDelete(t *testing.T) {
+	gen := id.NewGenerator()
+	ancient := gen.GenerateWithTime(time.Now().Add(-10 * 365 * 24 * time.Hour))
+
+	// Act
+	plan := id.RetentionPlan([]string{ancient}, id.RetentionPolicy{KeepFor: time.Hour})
+
+	// Assert
+	assert.Equal(t, []string{ancient}, plan.Cold)
+	assert.Empty(t, plan.Delete)
+}
@@ -0,0 +1,144 @@
+package id
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/oklog/ulid"
+)
+
+// Variant tells forward ULIDs from reverse ones made by GenerateReverse
+type Variant int
+
+const (
+	// VariantAuto detects the variant of each ID with DetectVariant
+	VariantAuto Variant = iota
+	// VariantForward is a standard ULID, which sorts oldest first
+	VariantForward
+	// VariantReverse is a ULID with every bit inverted, which sorts newest first
+	VariantReverse
+)
+
+// reverseThresholdMs is the smallest timestamp DetectVariant reads as
+// reverse: forward IDs reach it in the year 6429, and reverse IDs for
+// earlier times always exceed it
+const reverseThresholdMs = 1 << 47
+
+// String returns the name of the variant
+func (v Variant) String() string {
+	switch v {
+	case VariantAuto:
+		return "auto"
+	case VariantForward:
+		return "forward"
+	case VariantReverse:
+		return "reverse"
+	default:
+		return fmt.Sprintf("Variant(%d)", int(v))
+	}
+}
+
+// GenerateReverse provides a new reverse-sortable ID, whose lexicographic
+// order is newest first, for stores that only scan forward. Every bit of
+// the ULID is inverted, so even IDs sharing a millisecond sort newest
+// first. Reverse IDs are always ULIDs, so it panics with
+// ErrSchemeUnsupported for generators issuing another ID scheme.
+func (g *generator) GenerateReverse() string {
+	return g.GenerateReverseWithTime(g.now())
+}
+
+// GenerateReverseWithTime generates a reverse-sortable ID with a specific timestamp
+func (g *generator) GenerateReverseWithTime(t time.Time) string {
+	if err := g.requireULID("reverse IDs"); err != nil {
+		panic(err)
+	}
+	return g.encode(invertULID(g.newULID(t)))
+}
+
+// MinReverseIDForTime returns the smallest reverse ID with t's millisecond
+// timestamp, so a forward scan starting there lists IDs from t backwards.
+// Times outside the ULID range are clamped.
+func MinReverseIDForTime(t time.Time) string {
+	return invertULID(boundULID(t, 0xFF)).String()
+}
+
+// DetectVariant reports whether id, in either case, is a forward or reverse
+// ID. Reverse IDs are recognized by timestamps past the year 6429, so the
+// result is exact for every ID generated before then.
+func DetectVariant(id string) (Variant, error) {
+	u, err := parseCanonical(id)
+	if err != nil {
+		return VariantAuto, fmt.Errorf("invalid ULID: %w", err)
+	}
+	return detectVariant(u), nil
+}
+
+// ReverseID converts between the variants: a forward ID becomes the reverse
+// ID with the same timestamp and entropy, and vice versa
+func ReverseID(id string) (string, error) {
+	u, err := parseCanonical(id)
+	if err != nil {
+		return "", fmt.Errorf("invalid ULID: %w", err)
+	}
+	return invertULID(u).String(), nil
+}
+
+// ExtractTimestampVariant returns the timestamp of an ID of variant v, or of
+// the detected variant for VariantAuto
+func ExtractTimestampVariant(id string, v Variant) (time.Time, error) {
+	u, err := parseVariant(id, v)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return ulid.Time(u.Time()), nil
+}
+
+// CompareVariant returns -1, 0, or 1 for the chronological order of two IDs
+// of variant v, or of their detected variants for VariantAuto, so forward
+// and reverse IDs can be compared with each other
+func CompareVariant(id1, id2 string, v Variant) (int, error) {
+	u1, err := parseVariant(id1, v)
+	if err != nil {
+		return 0, err
+	}
+	u2, err := parseVariant(id2, v)
+	if err != nil {
+		return 0, err
+	}
+	return u1.Compare(u2), nil
+}
+
+// parseVariant parses id and returns it in forward form
+func parseVariant(id string, v Variant) (ulid.ULID, error) {
+	u, err := parseCanonical(id)
+	if err != nil {
+		return ulid.ULID{}, fmt.Errorf("invalid ULID: %w", err)
+	}
+	if v == VariantAuto {
+		v = detectVariant(u)
+	}
+	switch v {
+	case VariantForward:
+		return u, nil
+	case VariantReverse:
+		return invertULID(u), nil
+	default:
+		return ulid.ULID{}, fmt.Errorf("unknown variant: %s", v)
+	}
+}
+
+// detectVariant classifies a parsed ULID by its timestamp
+func detectVariant(u ulid.ULID) Variant {
+	if u.Time() >= reverseThresholdMs {
+		return VariantReverse
+	}
+	return VariantForward
+}
+
+// invertULID flips every bit of u
+func invertULID(u ulid.ULID) ulid.ULID {
+	for i := range u {
+		u[i] = ^u[i]
+	}
+	return u
+}
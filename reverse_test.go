@@ -0,0 +1,142 @@
+package id_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GenerateReverse_SortsNewestFirst(t *testing.T) {
+	clock := id.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	gen := id.NewGenerator(id.WithClock(clock))
+	var ids []string
+	for i := range 50 {
+		if i%5 == 0 {
+			clock.Advance(time.Millisecond)
+		}
+		ids = append(ids, gen.GenerateReverse())
+	}
+
+	// Act
+	sorted := slices.Sorted(slices.Values(ids))
+
+	// Assert
+	slices.Reverse(ids)
+	assert.Equal(t, ids, sorted, "lexicographic order is newest first, even within a millisecond")
+}
+
+func Test_GenerateReverseWithTime_RoundTrips(t *testing.T) {
+	gen := id.NewGenerator()
+	ts := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	// Act
+	rev := gen.GenerateReverseWithTime(ts)
+	fwd, err := id.ReverseID(rev)
+	require.NoError(t, err)
+	back, err := id.ReverseID(fwd)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, rev, back)
+	assert.True(t, gen.IsIdValid(rev))
+	got, err := gen.ExtractTimestamp(fwd)
+	require.NoError(t, err)
+	assert.True(t, got.Equal(ts))
+	got, err = id.ExtractTimestampVariant(rev, id.VariantReverse)
+	require.NoError(t, err)
+	assert.True(t, got.Equal(ts))
+}
+
+func Test_DetectVariant(t *testing.T) {
+	gen := id.NewGenerator()
+	ts := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	// Act
+	fwd, err := id.DetectVariant(gen.GenerateWithTime(ts))
+	require.NoError(t, err)
+	rev, err := id.DetectVariant(strings.ToLower(gen.GenerateReverseWithTime(ts)))
+	require.NoError(t, err)
+	_, err = id.DetectVariant("not-a-ulid")
+
+	// Assert
+	assert.Equal(t, id.VariantForward, fwd)
+	assert.Equal(t, id.VariantReverse, rev)
+	assert.Error(t, err)
+	for _, mangled := range []string{"01ARZ3NDEKTSV4RRFFQ69G5F!!", "7ZZZZZZZZZZZZZZZZZZZZZZZ!!"} {
+		_, err = id.DetectVariant(mangled)
+		assert.Error(t, err, mangled)
+		_, err = id.ReverseID(mangled)
+		assert.Error(t, err, mangled)
+		_, err = id.ExtractTimestampVariant(mangled, id.VariantAuto)
+		assert.Error(t, err, mangled)
+	}
+}
+
+func Test_ExtractTimestampVariant_Auto(t *testing.T) {
+	gen := id.NewGenerator()
+	ts := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	for _, s := range []string{gen.GenerateWithTime(ts), gen.GenerateReverseWithTime(ts)} {
+		// Act
+		got, err := id.ExtractTimestampVariant(s, id.VariantAuto)
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, got.Equal(ts), s)
+	}
+}
+
+func Test_ExtractTimestampVariant_UnknownVariant(t *testing.T) {
+	// Act
+	_, err := id.ExtractTimestampVariant(id.NewGenerator().Generate(), id.Variant(9))
+
+	// Assert
+	assert.ErrorContains(t, err, "Variant(9)")
+}
+
+func Test_CompareVariant_IsChronological(t *testing.T) {
+	gen := id.NewGenerator()
+	ts := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	older, newer := gen.GenerateReverseWithTime(ts), gen.GenerateReverseWithTime(ts.Add(time.Second))
+
+	// Act
+	rev, err := id.CompareVariant(older, newer, id.VariantReverse)
+	require.NoError(t, err)
+	mixed, err := id.CompareVariant(gen.GenerateWithTime(ts), newer, id.VariantAuto)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, 1, strings.Compare(older, newer), "reverse IDs sort newest first")
+	assert.Equal(t, -1, rev)
+	assert.Equal(t, -1, mixed)
+}
+
+func Test_MinReverseIDForTime_StartsNewestFirstScan(t *testing.T) {
+	gen := id.NewGenerator()
+	ts := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	ids := []string{
+		gen.GenerateReverseWithTime(ts.Add(-time.Second)),
+		gen.GenerateReverseWithTime(ts),
+		gen.GenerateReverseWithTime(ts.Add(time.Second)),
+	}
+
+	// Act
+	from := id.MinReverseIDForTime(ts)
+
+	// Assert
+	assert.Less(t, ids[2], from)
+	assert.LessOrEqual(t, from, ids[1])
+	assert.LessOrEqual(t, from, ids[0])
+}
+
+func Test_Variant_String(t *testing.T) {
+	// Assert
+	assert.Equal(t, "auto", id.VariantAuto.String())
+	assert.Equal(t, "forward", id.VariantForward.String())
+	assert.Equal(t, "reverse", id.VariantReverse.String())
+}
@@ -0,0 +1,43 @@
+package id
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/oklog/ulid"
+)
+
+// MaxLogInputLength is how many bytes of an invalid input SanitizeForLog keeps
+const MaxLogInputLength = 64
+
+// SanitizeForLog renders untrusted ID input safe to log. Valid ULIDs are
+// returned unchanged. Anything else is truncated to MaxLogInputLength bytes,
+// quoted with every non-printable or non-ASCII character escaped so it cannot
+// forge log lines or fields, and annotated with its original length:
+//
+//	invalid ID "abc\n{\"level\":\"error\"}" (len 22)
+func SanitizeForLog(input string) string {
+	if len(input) == ulid.EncodedSize {
+		if _, err := ulid.ParseStrict(strings.ToUpper(input)); err == nil {
+			return input
+		}
+	}
+
+	// Cut on a rune boundary so a split character is not escaped as stray bytes
+	end := 0
+	for end < len(input) {
+		_, size := utf8.DecodeRuneInString(input[end:])
+		if end+size > MaxLogInputLength {
+			break
+		}
+		end += size
+	}
+	kept, truncated := input[:end], end < len(input)
+
+	if truncated {
+		return fmt.Sprintf("invalid ID %s (len %d, truncated)", strconv.QuoteToASCII(kept), len(input))
+	}
+	return fmt.Sprintf("invalid ID %s (len %d)", strconv.QuoteToASCII(kept), len(input))
+}
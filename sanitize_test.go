@@ -0,0 +1,46 @@
+package id_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SanitizeForLog_Valid(t *testing.T) {
+	ulid := id.NewGenerator().Generate()
+
+	// Assert
+	assert.Equal(t, ulid, id.SanitizeForLog(ulid))
+	assert.Equal(t, strings.ToLower(ulid), id.SanitizeForLog(strings.ToLower(ulid)))
+}
+
+func Test_SanitizeForLog_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"empty", "", `invalid ID "" (len 0)`},
+		{"newline injection", "abc\n{\"level\":\"error\"}", `invalid ID "abc\n{\"level\":\"error\"}" (len 21)`},
+		{"control characters", "a\x1b[31mb\x00", `invalid ID "a\x1b[31mb\x00" (len 8)`},
+		{"unicode", "01Ｈ", `invalid ID "01\uff28" (len 5)`},
+		{"invalid characters", "!!!!!!!!!!!!!!!!!!!!!!!!!!", `invalid ID "!!!!!!!!!!!!!!!!!!!!!!!!!!" (len 26)`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, id.SanitizeForLog(tt.input))
+		})
+	}
+}
+
+func Test_SanitizeForLog_Truncates(t *testing.T) {
+	// Act
+	long := id.SanitizeForLog(strings.Repeat("x", 1000))
+	split := id.SanitizeForLog(strings.Repeat("x", id.MaxLogInputLength-1) + "é")
+
+	// Assert
+	assert.Equal(t, `invalid ID "`+strings.Repeat("x", id.MaxLogInputLength)+`" (len 1000, truncated)`, long)
+	assert.Equal(t, `invalid ID "`+strings.Repeat("x", id.MaxLogInputLength-1)+`" (len 65, truncated)`, split)
+}
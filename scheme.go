@@ -0,0 +1,95 @@
+package id
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/oklog/ulid"
+)
+
+// Scheme names an ID format a SchemePolicy can accept
+type Scheme string
+
+const (
+	// SchemeULID accepts ULIDs in either case
+	SchemeULID Scheme = "ulid"
+	// SchemeUUIDv4 accepts random RFC 4122 version 4 UUIDs
+	SchemeUUIDv4 Scheme = "uuidv4"
+	// SchemeUUIDv7 accepts time-ordered RFC 9562 version 7 UUIDs
+	SchemeUUIDv7 Scheme = "uuidv7"
+)
+
+// ErrSchemeNotAccepted is returned when an ID is well-formed but its scheme is not accepted by the policy
+var ErrSchemeNotAccepted = errors.New("ID scheme not accepted")
+
+// SchemePolicy validates IDs arriving in any of several accepted schemes,
+// for gateways fronting services at different stages of a migration
+type SchemePolicy struct {
+	// Accept lists the schemes the policy allows
+	Accept []Scheme
+	// ConvertToULID renders every accepted ID as a ULID over the same 16
+	// bytes instead of in its own scheme's canonical form
+	ConvertToULID bool
+}
+
+// SchemeMatch reports which scheme an ID matched and its canonical form
+type SchemeMatch struct {
+	Scheme    Scheme
+	Canonical string
+}
+
+// Validate detects an ID's scheme, checks it is accepted, and normalizes it.
+// ULIDs normalize to uppercase and UUIDs to lowercase hyphenated form.
+func (p SchemePolicy) Validate(id string) (SchemeMatch, error) {
+	scheme, raw, err := detectScheme(id)
+	if err != nil {
+		return SchemeMatch{}, err
+	}
+	if !slices.Contains(p.Accept, scheme) {
+		return SchemeMatch{}, fmt.Errorf("%w: %s", ErrSchemeNotAccepted, scheme)
+	}
+
+	match := SchemeMatch{Scheme: scheme}
+	switch {
+	case p.ConvertToULID || scheme == SchemeULID:
+		match.Canonical = ulid.ULID(raw).String()
+	default:
+		match.Canonical = formatUUID(raw)
+	}
+	return match, nil
+}
+
+// IsIdValid reports whether an ID is well-formed in an accepted scheme
+func (p SchemePolicy) IsIdValid(id string) bool {
+	_, err := p.Validate(id)
+	return err == nil
+}
+
+// detectScheme identifies whether id is a ULID or an RFC 4122 UUID of a supported version
+func detectScheme(id string) (Scheme, [16]byte, error) {
+	if len(id) == ulid.EncodedSize {
+		parsed, err := ulid.ParseStrict(strings.ToUpper(id))
+		if err != nil {
+			return "", [16]byte{}, fmt.Errorf("invalid ULID: %w", err)
+		}
+		return SchemeULID, parsed, nil
+	}
+
+	raw, err := parseUUID(id)
+	if err != nil {
+		return "", raw, fmt.Errorf("unrecognized ID %q: not a ULID or UUID", id)
+	}
+	if raw[8]&0xC0 != 0x80 {
+		return "", raw, fmt.Errorf("%w: unsupported variant", errInvalidUUID)
+	}
+	switch raw[6] >> 4 {
+	case 4:
+		return SchemeUUIDv4, raw, nil
+	case 7:
+		return SchemeUUIDv7, raw, nil
+	default:
+		return "", raw, fmt.Errorf("%w: unsupported version %d", errInvalidUUID, raw[6]>>4)
+	}
+}
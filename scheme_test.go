@@ -0,0 +1,66 @@
+package id_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	uuidV4 = "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+	uuidV7 = "01890a5d-ac96-774b-bcce-b302099a8057"
+)
+
+func Test_SchemePolicy_Validate(t *testing.T) {
+	policy := id.SchemePolicy{Accept: []id.Scheme{id.SchemeULID, id.SchemeUUIDv4, id.SchemeUUIDv7}}
+	ulid := id.NewGenerator().Generate()
+
+	cases := map[string]id.SchemeMatch{
+		strings.ToLower(ulid):               {Scheme: id.SchemeULID, Canonical: ulid},
+		strings.ToUpper(uuidV4):             {Scheme: id.SchemeUUIDv4, Canonical: uuidV4},
+		strings.ReplaceAll(uuidV7, "-", ""): {Scheme: id.SchemeUUIDv7, Canonical: uuidV7},
+	}
+
+	for input, want := range cases {
+		// Act
+		got, err := policy.Validate(input)
+
+		// Assert
+		require.NoError(t, err, input)
+		assert.Equal(t, want, got, input)
+		assert.True(t, policy.IsIdValid(input))
+	}
+}
+
+func Test_SchemePolicy_Rejects(t *testing.T) {
+	policy := id.SchemePolicy{Accept: []id.Scheme{id.SchemeULID}}
+
+	// Act & Assert
+	_, err := policy.Validate(uuidV4)
+	assert.ErrorIs(t, err, id.ErrSchemeNotAccepted)
+	_, err = policy.Validate("6ba7b810-9dad-11d1-80b4-00c04fd430c8") // UUIDv1
+	assert.Error(t, err)
+	_, err = policy.Validate("f47ac10b-58cc-4372-c567-0e02b2c3d479") // Wrong variant
+	assert.Error(t, err)
+	_, err = policy.Validate("not an id")
+	assert.Error(t, err)
+	assert.False(t, policy.IsIdValid("01ARZ3NDEKTSV4RRFFQ69G5FA!"))
+}
+
+func Test_SchemePolicy_ConvertToULID(t *testing.T) {
+	policy := id.SchemePolicy{Accept: []id.Scheme{id.SchemeUUIDv7}, ConvertToULID: true}
+
+	// Act
+	match, err := policy.Validate(uuidV7)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, id.SchemeUUIDv7, match.Scheme)
+	assert.True(t, id.NewGenerator().IsIdValid(match.Canonical))
+	uuid, err := id.NewGenerator().ToUUID(match.Canonical)
+	require.NoError(t, err)
+	assert.Equal(t, uuidV7, uuid)
+}
@@ -0,0 +1,44 @@
+package id
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrPrefixNotFound is returned when no ID starts with the given prefix
+	ErrPrefixNotFound = errors.New("no ID matches prefix")
+	// ErrAmbiguousPrefix is returned when more than one distinct ID starts with the given prefix
+	ErrAmbiguousPrefix = errors.New("prefix matches multiple IDs")
+)
+
+// MatchPrefix returns the IDs that start with prefix, ignoring case, in input order
+func MatchPrefix(ids []string, prefix string) []string {
+	upper := strings.ToUpper(prefix)
+	result := []string{}
+	for _, id := range ids {
+		if len(id) >= len(upper) && strings.EqualFold(id[:len(upper)], upper) {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// ResolveUniquePrefix resolves a git-style short ID to the single ID in ids
+// that starts with it, ignoring case. Repeated copies of the same ID are not
+// ambiguous.
+func ResolveUniquePrefix(ids []string, prefix string) (string, error) {
+	matches := MatchPrefix(ids, prefix)
+	if len(matches) == 0 {
+		return "", fmt.Errorf("%w %q", ErrPrefixNotFound, prefix)
+	}
+
+	match := matches[0]
+	for _, m := range matches[1:] {
+		if !strings.EqualFold(m, match) {
+			return "", fmt.Errorf("%w: %q matches %d IDs", ErrAmbiguousPrefix, prefix, len(matches))
+		}
+	}
+	return match, nil
+}
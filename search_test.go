@@ -0,0 +1,45 @@
+package id_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_MatchPrefix(t *testing.T) {
+	ids := []string{
+		"01ARZ3NDEKTSV4RRFFQ69G5FAV",
+		"01ARZ3NDEKTSV4RRFFQ69G5FAW",
+		"01BX5ZZKBKACTAV9WEVGEMMVRZ",
+	}
+
+	// Act & Assert
+	assert.Equal(t, ids[:2], id.MatchPrefix(ids, "01arz3nd"))
+	assert.Equal(t, ids[2:], id.MatchPrefix(ids, "01BX"))
+	assert.Empty(t, id.MatchPrefix(ids, "7ZZZ"))
+	assert.Equal(t, ids, id.MatchPrefix(ids, ""))
+}
+
+func Test_ResolveUniquePrefix(t *testing.T) {
+	ids := []string{
+		"01ARZ3NDEKTSV4RRFFQ69G5FAV",
+		"01ARZ3NDEKTSV4RRFFQ69G5FAW",
+		"01BX5ZZKBKACTAV9WEVGEMMVRZ",
+		"01bx5zzkbkactav9wevgemmvrz",
+	}
+
+	// Act
+	resolved, err := id.ResolveUniquePrefix(ids, strings.ToLower("01BX5ZZK"))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, ids[2], resolved)
+
+	_, err = id.ResolveUniquePrefix(ids, "01ARZ3ND")
+	assert.ErrorIs(t, err, id.ErrAmbiguousPrefix)
+	_, err = id.ResolveUniquePrefix(ids, "7ZZZ")
+	assert.ErrorIs(t, err, id.ErrPrefixNotFound)
+}
@@ -0,0 +1,77 @@
+package id
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/oklog/ulid"
+)
+
+// selfTestBurst is the number of IDs a self-test generates
+const selfTestBurst = 1024
+
+// ErrSelfTest is wrapped by every error SelfTest returns
+var ErrSelfTest = errors.New("generator self-test failed")
+
+// WithSelfTest runs SelfTest once every other option is applied, so a
+// misconfigured custom entropy source is caught before it reaches production
+// traffic. TryNewGenerator and TryNew return a failure as an error:
+//
+//	gen, err := id.TryNewGenerator(id.WithEntropy(hwrng), id.WithSelfTest())
+//
+// NewGenerator and New panic on it, like on an invalid option.
+func WithSelfTest() Option {
+	return func(g *generator) {
+		g.selfTest = true
+	}
+}
+
+// SelfTest generates a burst of IDs and checks they are valid, unique,
+// time-ordered, and drawn from entropy that is not degenerate
+func (g *generator) SelfTest() (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: entropy source failed: %v", ErrSelfTest, r)
+		}
+	}()
+
+	seen := make(map[ulid.ULID]struct{}, selfTestBurst)
+	var byteSeen [256]bool
+	distinctBytes := 0
+	var prevMs uint64
+
+	for i := 0; i < selfTestBurst; i++ {
+		u := g.newULID(g.now())
+
+		s := g.encode(u)
+		if _, err := g.parse(s); err != nil {
+			return fmt.Errorf("%w: generated invalid ID %q", ErrSelfTest, s)
+		}
+		if _, dup := seen[u]; dup {
+			return fmt.Errorf("%w: duplicate ID after %d generations", ErrSelfTest, i)
+		}
+		seen[u] = struct{}{}
+
+		if u.Time() < prevMs {
+			return fmt.Errorf("%w: timestamp went backwards", ErrSelfTest)
+		}
+		prevMs = u.Time()
+
+		if allBytes(u[6:], 0x00) || allBytes(u[6:], 0xFF) {
+			return fmt.Errorf("%w: degenerate entropy %x", ErrSelfTest, u[6:])
+		}
+		for _, b := range u[6:] {
+			if !byteSeen[b] {
+				byteSeen[b] = true
+				distinctBytes++
+			}
+		}
+	}
+
+	// ~10k random bytes cover nearly all 256 values; a healthy source never
+	// comes close to this floor
+	if distinctBytes < 128 {
+		return fmt.Errorf("%w: entropy used only %d distinct byte values", ErrSelfTest, distinctBytes)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package id_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type cyclingReader struct{ b byte }
+
+func (r *cyclingReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = r.b
+		r.b = (r.b + 1) % 4
+	}
+	return len(p), nil
+}
+
+func Test_WithSelfTest(t *testing.T) {
+	// Act
+	gen := id.NewGenerator(id.WithSelfTest())
+	secure := id.NewGenerator(id.WithSelfTest(), id.WithSecureEntropy())
+	ksuid := id.New(id.WithScheme(id.SchemeKSUID), id.WithSelfTest())
+
+	// Assert
+	assert.True(t, gen.IsIdValid(gen.Generate()))
+	assert.True(t, secure.IsIdValid(secure.Generate()))
+	assert.True(t, ksuid.IsIdValid(ksuid.Generate()))
+	assert.Panics(t, func() { id.NewGenerator(id.WithSelfTest(), id.WithEntropy(&cyclingReader{})) },
+		"the self-test runs after every option, whatever the order")
+}
+
+func Test_TryNewGenerator_ReturnsSelfTestFailure(t *testing.T) {
+	// Act
+	gen, err := id.TryNewGenerator(id.WithSelfTest(), id.WithEntropy(&cyclingReader{}))
+	provider, newErr := id.TryNew(id.WithEntropy(&cyclingReader{}), id.WithSelfTest())
+
+	// Assert
+	assert.ErrorIs(t, err, id.ErrSelfTest)
+	assert.Nil(t, gen)
+	assert.ErrorIs(t, newErr, id.ErrSelfTest)
+	assert.Nil(t, provider)
+
+	_, err = id.TryNew(id.WithPrefix("Cus"))
+	assert.Error(t, err)
+	_, err = id.TryNew(id.WithPrefix("cus"), id.WithScheme(id.SchemeKSUID))
+	assert.Error(t, err)
+	ok, err := id.TryNewGenerator(id.WithSelfTest())
+	require.NoError(t, err)
+	assert.True(t, ok.IsIdValid(ok.Generate()))
+}
+
+func Test_SelfTest_Failures(t *testing.T) {
+	// Low-diversity entropy
+	err := id.NewGeneratorWithEntropy(&cyclingReader{}).SelfTest()
+	assert.ErrorIs(t, err, id.ErrSelfTest)
+
+	// Constant entropy produces duplicates
+	err = id.NewGeneratorWithEntropy(bytes.NewReader(bytes.Repeat([]byte{7}, 1<<20))).SelfTest()
+	assert.ErrorIs(t, err, id.ErrSelfTest)
+
+	// Exhausted entropy panics inside generation and is reported as an error
+	err = id.NewGeneratorWithEntropy(bytes.NewReader(make([]byte, 5))).SelfTest()
+	assert.ErrorIs(t, err, id.ErrSelfTest)
+
+	// Custom but healthy entropy passes
+	err = id.NewGeneratorWithEntropy(rand.Reader).SelfTest()
+	assert.NoError(t, err)
+}
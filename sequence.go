@@ -0,0 +1,141 @@
+package id
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/oklog/ulid"
+)
+
+// MaxSequence is the largest logical sequence a token can carry (48 bits)
+const MaxSequence = 1<<48 - 1
+
+// ErrSequenceOverflow is returned when a sequence does not fit in 48 bits
+var ErrSequenceOverflow = errors.New("sequence exceeds 48 bits")
+
+// SequenceGenerator issues ordering tokens: ULIDs whose time field holds a
+// caller-supplied logical sequence instead of wall time, so replaying the
+// same sequence with the same entropy reproduces the same order. Tokens are
+// valid ULIDs and sort by sequence; tokens sharing a sequence sort in
+// issue order. It is safe for concurrent use.
+type SequenceGenerator struct {
+	mu      sync.Mutex
+	entropy io.Reader
+	next    uint64
+}
+
+// NewSequenceGenerator creates a generator of ordering tokens. A nil entropy
+// uses the default fast source; pass a seeded source for deterministic replay.
+func NewSequenceGenerator(entropy io.Reader) *SequenceGenerator {
+	if entropy == nil {
+		entropy = NewSourceReader(newDefaultSource())
+	}
+	return &SequenceGenerator{entropy: ulid.Monotonic(entropy, 0)}
+}
+
+// Next issues a token for the generator's counter, starting at 0, and advances it
+func (g *SequenceGenerator) Next() (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	token, err := g.generate(g.next)
+	if err != nil {
+		return "", err
+	}
+	g.next++
+	return token, nil
+}
+
+// GenerateAt issues a token for seq
+func (g *SequenceGenerator) GenerateAt(seq uint64) (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.generate(seq)
+}
+
+// generate issues a token for seq; callers hold g.mu
+func (g *SequenceGenerator) generate(seq uint64) (string, error) {
+	if seq > MaxSequence {
+		return "", fmt.Errorf("%w: %d", ErrSequenceOverflow, seq)
+	}
+	u, err := ulid.New(seq, g.entropy)
+	if err != nil {
+		return "", fmt.Errorf("entropy source failed: %w", err)
+	}
+	return u.String(), nil
+}
+
+// SequenceOf returns the logical sequence carried by a token
+func SequenceOf(token string) (uint64, error) {
+	parsed, err := parseCanonical(token)
+	if err != nil {
+		return 0, fmt.Errorf("invalid ULID: %w", err)
+	}
+	return parsed.Time(), nil
+}
+
+// SequenceTimeline maps logical sequences to wall time: sequence n
+// corresponds to Epoch plus n Ticks. It converts between ordering tokens and
+// timestamped ULIDs, keeping the entropy so conversions round-trip.
+type SequenceTimeline struct {
+	Epoch time.Time
+	// Tick is the time between sequences; it must be a positive multiple of a millisecond
+	Tick time.Duration
+}
+
+// ToTimestamped converts an ordering token to a ULID stamped with its sequence's time
+func (tl SequenceTimeline) ToTimestamped(token string) (string, error) {
+	if err := tl.validate(); err != nil {
+		return "", err
+	}
+	parsed, err := parseCanonical(token)
+	if err != nil {
+		return "", fmt.Errorf("invalid ULID: %w", err)
+	}
+
+	// Check the range by division so large sequences cannot overflow the product
+	epoch := tl.Epoch.UnixMilli()
+	tick := tl.Tick.Milliseconds()
+	seq := int64(parsed.Time())    //nolint:gosec // G115: sequences are 48 bits
+	maxMs := int64(ulid.MaxTime()) //nolint:gosec // G115: ulid.MaxTime is 48 bits
+	if epoch < 0 || seq > (maxMs-epoch)/tick {
+		return "", fmt.Errorf("sequence %d falls outside the ULID time range", seq)
+	}
+	ms := epoch + seq*tick
+	if err := parsed.SetTime(uint64(ms)); err != nil {
+		return "", err
+	}
+	return parsed.String(), nil
+}
+
+// FromTimestamped converts a timestamped ULID back to an ordering token.
+// The timestamp must fall on a tick at or after Epoch.
+func (tl SequenceTimeline) FromTimestamped(id string) (string, error) {
+	if err := tl.validate(); err != nil {
+		return "", err
+	}
+	parsed, err := parseCanonical(id)
+	if err != nil {
+		return "", fmt.Errorf("invalid ULID: %w", err)
+	}
+
+	offset := int64(parsed.Time()) - tl.Epoch.UnixMilli() //nolint:gosec // G115: ULID timestamps are 48 bits
+	tick := tl.Tick.Milliseconds()
+	if offset < 0 || offset%tick != 0 {
+		return "", fmt.Errorf("timestamp %s is not on the timeline", ulid.Time(parsed.Time()).UTC().Format(time.RFC3339Nano))
+	}
+	if err := parsed.SetTime(uint64(offset / tick)); err != nil {
+		return "", err
+	}
+	return parsed.String(), nil
+}
+
+// validate checks that the tick is a positive whole number of milliseconds
+func (tl SequenceTimeline) validate() error {
+	if tl.Tick < time.Millisecond || tl.Tick%time.Millisecond != 0 {
+		return fmt.Errorf("timeline tick must be a positive multiple of a millisecond, got %s", tl.Tick)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package id_test
+
+import (
+	"math/rand/v2"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SequenceGenerator_Ordering(t *testing.T) {
+	gen := id.NewSequenceGenerator(nil)
+
+	// Act
+	var tokens []string
+	for i := 0; i < 100; i++ {
+		token, err := gen.Next()
+		require.NoError(t, err)
+		tokens = append(tokens, token)
+	}
+	sameSeqA, err := gen.GenerateAt(500)
+	require.NoError(t, err)
+	sameSeqB, err := gen.GenerateAt(500)
+	require.NoError(t, err)
+
+	// Assert
+	assert.True(t, sort.StringsAreSorted(tokens))
+	assert.Less(t, sameSeqA, sameSeqB)
+	for i, token := range tokens {
+		seq, err := id.SequenceOf(token)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(i), seq)
+		assert.NoError(t, id.Validate(token))
+	}
+}
+
+func Test_SequenceGenerator_Deterministic(t *testing.T) {
+	replay := func() []string {
+		gen := id.NewSequenceGenerator(id.NewSourceReader(rand.NewPCG(1, 2)))
+		var tokens []string
+		for i := 0; i < 10; i++ {
+			token, err := gen.Next()
+			require.NoError(t, err)
+			tokens = append(tokens, token)
+		}
+		return tokens
+	}
+
+	// Assert
+	assert.Equal(t, replay(), replay())
+}
+
+func Test_SequenceGenerator_Overflow(t *testing.T) {
+	_, err := id.NewSequenceGenerator(nil).GenerateAt(id.MaxSequence + 1)
+	assert.ErrorIs(t, err, id.ErrSequenceOverflow)
+}
+
+func Test_SequenceTimeline_RoundTrip(t *testing.T) {
+	timeline := id.SequenceTimeline{Epoch: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Tick: time.Second}
+	gen := id.NewSequenceGenerator(nil)
+	token, err := gen.GenerateAt(90)
+	require.NoError(t, err)
+
+	// Act
+	stamped, err := timeline.ToTimestamped(token)
+	require.NoError(t, err)
+	back, err := timeline.FromTimestamped(stamped)
+	require.NoError(t, err)
+
+	// Assert
+	ts, err := id.NewGenerator().ExtractTimestamp(stamped)
+	require.NoError(t, err)
+	assert.True(t, ts.Equal(timeline.Epoch.Add(90*time.Second)))
+	assert.Equal(t, token, back)
+	assert.Equal(t, token[10:], stamped[10:])
+}
+
+func Test_SequenceTimeline_Errors(t *testing.T) {
+	epoch := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	timeline := id.SequenceTimeline{Epoch: epoch, Tick: time.Second}
+	gen := id.NewGenerator()
+
+	_, err := timeline.FromTimestamped(gen.GenerateWithTime(epoch.Add(-time.Second)))
+	assert.Error(t, err)
+	_, err = timeline.FromTimestamped(gen.GenerateWithTime(epoch.Add(1500 * time.Millisecond)))
+	assert.Error(t, err)
+	_, err = id.SequenceTimeline{Epoch: epoch}.ToTimestamped(gen.Generate())
+	assert.Error(t, err)
+	_, err = id.SequenceTimeline{Epoch: epoch, Tick: 1500 * time.Microsecond}.ToTimestamped(gen.Generate())
+	assert.Error(t, err)
+	_, err = timeline.ToTimestamped("bad")
+	assert.Error(t, err)
+}
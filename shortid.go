@@ -0,0 +1,131 @@
+package id
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
+	"strings"
+	"sync"
+)
+
+const (
+	// URLAlphabet is the 64-character URL-safe alphabet NanoID uses by default
+	URLAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_"
+	// DefaultShortIDLength is NanoID's default length, giving 126 random bits over URLAlphabet
+	DefaultShortIDLength = 21
+)
+
+// ShortIDGenerator produces NanoID-style random IDs: length characters drawn
+// uniformly from an alphabet. Unlike ULIDs they carry no timestamp and do not
+// sort, which suits invite codes and URL slugs where brevity matters. Use
+// CollisionProbability to size the length for the number of IDs you expect.
+type ShortIDGenerator struct {
+	alphabet string
+	length   int
+	// mask and step size each batch of random bytes, as in NanoID
+	mask byte
+	step int
+
+	mu      sync.Mutex
+	entropy io.Reader
+}
+
+// NewShortIDGenerator creates a generator of length-character IDs over
+// alphabet using crypto/rand
+func NewShortIDGenerator(alphabet string, length int) (*ShortIDGenerator, error) {
+	return NewShortIDGeneratorWithEntropy(alphabet, length, rand.Reader)
+}
+
+// NewShortIDGeneratorWithEntropy creates a generator of length-character IDs
+// over alphabet using a custom entropy source. The alphabet must hold 2 to
+// 256 distinct bytes.
+func NewShortIDGeneratorWithEntropy(alphabet string, length int, entropy io.Reader) (*ShortIDGenerator, error) {
+	if len(alphabet) < 2 || len(alphabet) > 256 {
+		return nil, fmt.Errorf("short ID alphabet must have between 2 and 256 characters, got %d", len(alphabet))
+	}
+	for i := 1; i < len(alphabet); i++ {
+		if strings.IndexByte(alphabet[:i], alphabet[i]) >= 0 {
+			return nil, fmt.Errorf("short ID alphabet repeats %q", alphabet[i])
+		}
+	}
+	if length < 1 {
+		return nil, fmt.Errorf("short ID length must be positive, got %d", length)
+	}
+
+	// Draw the fewest bits covering the alphabet and reject values past its
+	// end, so every character is equally likely
+	mask := byte(1<<bits.Len(uint(len(alphabet)-1)) - 1) //nolint:gosec // G115: at most 255
+	step := int(math.Ceil(1.6 * float64(mask) * float64(length) / float64(len(alphabet))))
+	return &ShortIDGenerator{
+		alphabet: alphabet,
+		length:   length,
+		mask:     mask,
+		step:     max(step, 1),
+		entropy:  entropy,
+	}, nil
+}
+
+// Alphabet returns the characters IDs are drawn from
+func (g *ShortIDGenerator) Alphabet() string {
+	return g.alphabet
+}
+
+// Length returns the number of characters in each ID
+func (g *ShortIDGenerator) Length() int {
+	return g.length
+}
+
+// EntropyBits returns the number of random bits in each ID
+func (g *ShortIDGenerator) EntropyBits() float64 {
+	return float64(g.length) * math.Log2(float64(len(g.alphabet)))
+}
+
+// Generate provides a new random ID. It panics if the entropy source fails.
+func (g *ShortIDGenerator) Generate() string {
+	result := make([]byte, 0, g.length)
+	random := make([]byte, g.step)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for {
+		if _, err := io.ReadFull(g.entropy, random); err != nil {
+			panic(fmt.Errorf("reading short ID entropy: %w", err))
+		}
+		for _, b := range random {
+			if i := int(b & g.mask); i < len(g.alphabet) {
+				result = append(result, g.alphabet[i])
+				if len(result) == g.length {
+					return string(result)
+				}
+			}
+		}
+	}
+}
+
+// IsIdValid reports whether s has the generator's length and only characters from its alphabet
+func (g *ShortIDGenerator) IsIdValid(s string) bool {
+	if len(s) != g.length {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte(g.alphabet, s[i]) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// CollisionProbability returns the chance that at least two of count IDs
+// from this generator collide
+func (g *ShortIDGenerator) CollisionProbability(count int) float64 {
+	return CollisionOdds(len(g.alphabet), g.length, count)
+}
+
+// CollisionOdds returns the birthday-bound chance that at least two of count
+// random IDs of length characters over an alphabet of alphabetSize collide
+func CollisionOdds(alphabetSize, length, count int) float64 {
+	return collisionProbability(float64(length)*math.Log2(float64(alphabetSize)), count)
+}
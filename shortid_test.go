@@ -0,0 +1,94 @@
+package id_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ShortIDGenerator_Generate(t *testing.T) {
+	gen, err := id.NewShortIDGenerator(id.URLAlphabet, id.DefaultShortIDLength)
+	require.NoError(t, err)
+	seen := map[string]bool{}
+
+	// Act
+	for i := 0; i < 1000; i++ {
+		s := gen.Generate()
+
+		// Assert
+		require.Len(t, s, id.DefaultShortIDLength)
+		require.True(t, gen.IsIdValid(s), s)
+		require.False(t, seen[s], "duplicate %q", s)
+		seen[s] = true
+	}
+	assert.InDelta(t, 126, gen.EntropyBits(), 1e-9)
+}
+
+func Test_ShortIDGenerator_Uniform(t *testing.T) {
+	// An alphabet of 5 draws 3-bit values and must reject 5, 6, and 7
+	gen, err := id.NewShortIDGenerator("abcde", 50_000)
+	require.NoError(t, err)
+
+	// Act
+	s := gen.Generate()
+
+	// Assert
+	for _, c := range "abcde" {
+		assert.InDelta(t, 10_000, strings.Count(s, string(c)), 500, "count of %q", c)
+	}
+}
+
+func Test_ShortIDGenerator_Entropy(t *testing.T) {
+	gen, err := id.NewShortIDGeneratorWithEntropy("0123456789", 4, bytes.NewReader([]byte{0, 15, 9, 12, 3, 10, 1, 0, 0, 0}))
+	require.NoError(t, err)
+
+	// Act
+	s := gen.Generate()
+
+	// Assert
+	assert.Equal(t, "0931", s, "values past the alphabet are skipped")
+	assert.Equal(t, "0123456789", gen.Alphabet())
+	assert.Equal(t, 4, gen.Length())
+}
+
+func Test_ShortIDGenerator_EntropyFailure(t *testing.T) {
+	gen, err := id.NewShortIDGeneratorWithEntropy(id.URLAlphabet, 8, failingReader{})
+	require.NoError(t, err)
+
+	assert.Panics(t, func() { gen.Generate() })
+}
+
+func Test_ShortIDGenerator_Invalid(t *testing.T) {
+	for _, tc := range []struct {
+		alphabet string
+		length   int
+	}{
+		{"a", 8},
+		{strings.Repeat("ab", 129), 8},
+		{"abca", 8},
+		{"abc", 0},
+	} {
+		_, err := id.NewShortIDGenerator(tc.alphabet, tc.length)
+		assert.Error(t, err, "%q/%d", tc.alphabet, tc.length)
+	}
+
+	gen, err := id.NewShortIDGenerator("abc", 3)
+	require.NoError(t, err)
+	assert.False(t, gen.IsIdValid("ab"))
+	assert.False(t, gen.IsIdValid("abd"))
+}
+
+func Test_CollisionOdds(t *testing.T) {
+	// Act & Assert
+	assert.Zero(t, id.CollisionOdds(64, 21, 1))
+	assert.InDelta(t, id.CollisionProbability(126, 1_000_000_000), id.CollisionOdds(64, 21, 1_000_000_000), 1e-30)
+	assert.InDelta(t, 0.5, id.CollisionOdds(36, 8, 1_971_000), 0.01)
+
+	gen, err := id.NewShortIDGenerator("0123456789", 6)
+	require.NoError(t, err)
+	assert.Equal(t, id.CollisionOdds(10, 6, 1000), gen.CollisionProbability(1000))
+}
@@ -0,0 +1,193 @@
+package id
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/oklog/ulid"
+)
+
+const (
+	// SignatureSeparator joins a ULID to its signature in a signed token
+	SignatureSeparator = '.'
+	// MinSigningKeySize is the shortest key a SignedGenerator accepts
+	MinSigningKeySize = 16
+
+	// signatureSize is how many bytes of the HMAC-SHA256 a token carries
+	signatureSize = 16
+)
+
+// ErrInvalidSignature is returned when a signed token is malformed or no verification key matches
+var ErrInvalidSignature = errors.New("invalid ID signature")
+
+// signatureEncoding renders signatures in the ULID alphabet
+var signatureEncoding = base32.NewEncoding(ulid.Encoding).WithPadding(base32.NoPadding)
+
+// SignedGenerator issues ULIDs as tamper-evident tokens of the form
+// "{ulid}.{signature}", where the signature is a truncated HMAC-SHA256 of
+// the ULID's 16 bytes in Crockford Base32. Tokens are signed with the newest
+// key and verified against every key, so keys can be rotated without
+// invalidating tokens already handed out. It is safe for concurrent use.
+type SignedGenerator struct {
+	gen  *generator
+	keys [][]byte
+}
+
+// NewSignedGenerator creates a signing generator with default entropy. key
+// signs new tokens; previousKeys are still accepted when verifying.
+func NewSignedGenerator(key []byte, previousKeys ...[]byte) (*SignedGenerator, error) {
+	return NewSignedGeneratorFrom(NewGenerator(), key, previousKeys...)
+}
+
+// NewSignedGeneratorFrom signs ULIDs produced by an existing generator,
+// which must not issue another ID scheme
+func NewSignedGeneratorFrom(base *generator, key []byte, previousKeys ...[]byte) (*SignedGenerator, error) {
+	if err := base.requireULID("signed IDs"); err != nil {
+		return nil, err
+	}
+	keys, err := signingKeys(key, previousKeys)
+	if err != nil {
+		return nil, err
+	}
+	return &SignedGenerator{gen: base, keys: keys}, nil
+}
+
+// signingKeys checks and copies key followed by previousKeys
+func signingKeys(key []byte, previousKeys [][]byte) ([][]byte, error) {
+	keys := make([][]byte, 0, 1+len(previousKeys))
+	for i, k := range append([][]byte{key}, previousKeys...) {
+		if len(k) < MinSigningKeySize {
+			return nil, fmt.Errorf("signing key %d is %d bytes, want at least %d", i, len(k), MinSigningKeySize)
+		}
+		keys = append(keys, append([]byte(nil), k...))
+	}
+	return keys, nil
+}
+
+// Generate issues a new signed token
+func (s *SignedGenerator) Generate() string {
+	return s.sign(s.gen.newULID(s.gen.now()))
+}
+
+// GenerateWithTime issues a signed token with a specific timestamp
+func (s *SignedGenerator) GenerateWithTime(t time.Time) string {
+	return s.sign(s.gen.newULID(t))
+}
+
+// Sign signs an existing ULID with the current key
+func (s *SignedGenerator) Sign(id string) (string, error) {
+	parsed, err := s.gen.parse(id)
+	if err != nil {
+		return "", fmt.Errorf("invalid ULID: %w", err)
+	}
+	return s.sign(parsed), nil
+}
+
+// VerifySigned checks a token's signature against every key and returns the
+// inner ULID in the generator's format. Tokens are accepted in either case.
+func (s *SignedGenerator) VerifySigned(token string) (string, error) {
+	parsed, err := s.verify(token)
+	if err != nil {
+		return "", err
+	}
+	return s.gen.encode(parsed), nil
+}
+
+// IsIdValid reports whether token carries a valid signature
+func (s *SignedGenerator) IsIdValid(token string) bool {
+	_, err := s.verify(token)
+	return err == nil
+}
+
+// ExtractTimestamp returns the timestamp of a verified token
+func (s *SignedGenerator) ExtractTimestamp(token string) (time.Time, error) {
+	parsed, err := s.verify(token)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return ulid.Time(parsed.Time()), nil
+}
+
+// Age returns how old a verified token is
+func (s *SignedGenerator) Age(token string) (time.Duration, error) {
+	timestamp, err := s.ExtractTimestamp(token)
+	if err != nil {
+		return 0, err
+	}
+	return s.gen.now().Sub(timestamp), nil
+}
+
+// IsExpired checks whether a verified token is older than maxAge. Forged or
+// altered tokens return an error rather than a verdict, so the check is safe
+// on client-supplied input.
+func (s *SignedGenerator) IsExpired(token string, maxAge time.Duration) (bool, error) {
+	age, err := s.Age(token)
+	if err != nil {
+		return false, err
+	}
+	return age > maxAge, nil
+}
+
+// sign renders u followed by its signature under the current key
+func (s *SignedGenerator) sign(u ulid.ULID) string {
+	return signToken(s.keys, s.gen.encode(u), u)
+}
+
+// verify splits a token and checks its signature against every key
+func (s *SignedGenerator) verify(token string) (ulid.ULID, error) {
+	_, raw, err := verifyToken(s.keys, token, func(inner string) ([16]byte, error) {
+		parsed, err := s.gen.parse(inner)
+		if err != nil {
+			return parsed, fmt.Errorf("invalid ULID: %w", err)
+		}
+		return parsed, nil
+	})
+	return raw, err
+}
+
+// signToken appends to id the signature of its binary form raw under the
+// first key
+func signToken(keys [][]byte, id string, raw [16]byte) string {
+	return id + string(SignatureSeparator) + signatureEncoding.EncodeToString(mac(keys[0], raw))
+}
+
+// verifyToken splits a token, converts the inner ID to its binary form with
+// toBytes, and checks the signature against every key. It returns the inner
+// ID as written in the token along with its binary form.
+func verifyToken(keys [][]byte, token string, toBytes func(string) ([16]byte, error)) (string, [16]byte, error) {
+	i := strings.LastIndexByte(token, SignatureSeparator)
+	if i < 0 {
+		return "", [16]byte{}, fmt.Errorf("%w: missing %q separator", ErrInvalidSignature, SignatureSeparator)
+	}
+	inner := token[:i]
+	raw, err := toBytes(inner)
+	if err != nil {
+		return "", [16]byte{}, err
+	}
+	// Re-encoding rejects stray low bits in the last character, which
+	// decoding ignores, so each ID has exactly one valid token per key
+	encoded := strings.ToUpper(token[i+1:])
+	signature, err := signatureEncoding.DecodeString(encoded)
+	if err != nil || len(signature) != signatureSize || signatureEncoding.EncodeToString(signature) != encoded {
+		return "", [16]byte{}, fmt.Errorf("%w: malformed signature", ErrInvalidSignature)
+	}
+
+	for _, key := range keys {
+		if hmac.Equal(signature, mac(key, raw)) {
+			return inner, raw, nil
+		}
+	}
+	return "", [16]byte{}, ErrInvalidSignature
+}
+
+// mac returns the truncated HMAC-SHA256 of u under key
+func mac(key []byte, u ulid.ULID) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write(u[:])
+	return h.Sum(nil)[:signatureSize]
+}
@@ -0,0 +1,132 @@
+package id_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	signingKey   = []byte("0123456789abcdef0123456789abcdef")
+	rotatedKey   = []byte("fedcba9876543210fedcba9876543210")
+	unrelatedKey = []byte("an unrelated key of enough bytes")
+)
+
+func Test_SignedGenerator_RoundTrip(t *testing.T) {
+	gen, err := id.NewSignedGenerator(signingKey)
+	require.NoError(t, err)
+
+	// Act
+	token := gen.Generate()
+	inner, err := gen.VerifySigned(token)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, token, 53)
+	assert.Equal(t, token[:26], inner)
+	assert.NoError(t, id.Validate(inner))
+	assert.True(t, gen.IsIdValid(token))
+	lower, err := gen.VerifySigned(strings.ToLower(token))
+	require.NoError(t, err)
+	assert.Equal(t, inner, lower)
+}
+
+func Test_SignedGenerator_Tampering(t *testing.T) {
+	gen, err := id.NewSignedGenerator(signingKey)
+	require.NoError(t, err)
+	token := gen.Generate()
+	other := gen.Generate()
+	flip := func(i int) string {
+		c := byte('0')
+		if token[i] == c {
+			c = '1'
+		}
+		return token[:i] + string(c) + token[i+1:]
+	}
+
+	// Act & Assert
+	for _, forged := range []string{
+		other[:27] + token[27:],
+		token[:27] + other[27:],
+		token[:26],
+		flip(27),
+		flip(len(token) - 1),
+		flip(0),
+		token + "0",
+		"",
+	} {
+		_, err := gen.VerifySigned(forged)
+		assert.Error(t, err, forged)
+	}
+
+	forger, err := id.NewSignedGenerator(unrelatedKey)
+	require.NoError(t, err)
+	_, err = gen.VerifySigned(forger.Generate())
+	assert.ErrorIs(t, err, id.ErrInvalidSignature)
+}
+
+func Test_SignedGenerator_Rotation(t *testing.T) {
+	old, err := id.NewSignedGenerator(signingKey)
+	require.NoError(t, err)
+	issued := old.Generate()
+
+	// Act
+	rotated, err := id.NewSignedGenerator(rotatedKey, signingKey)
+	require.NoError(t, err)
+
+	// Assert
+	_, err = rotated.VerifySigned(issued)
+	assert.NoError(t, err, "tokens from the previous key still verify")
+	_, err = old.VerifySigned(rotated.Generate())
+	assert.ErrorIs(t, err, id.ErrInvalidSignature, "new tokens use the new key")
+}
+
+func Test_SignedGenerator_Expiry(t *testing.T) {
+	clock := id.NewFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	gen, err := id.NewSignedGeneratorFrom(id.NewGenerator(id.WithClock(clock)), signingKey)
+	require.NoError(t, err)
+	token := gen.GenerateWithTime(clock.Now().Add(-2 * time.Hour))
+
+	// Act
+	expired, err := gen.IsExpired(token, time.Hour)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, expired)
+	ts, err := gen.ExtractTimestamp(token)
+	require.NoError(t, err)
+	assert.True(t, ts.Equal(clock.Now().Add(-2*time.Hour)))
+
+	// A client cannot extend its token by editing the timestamp
+	forged := id.NewGenerator().GenerateWithTime(clock.Now()) + token[26:]
+	_, err = gen.IsExpired(forged, time.Hour)
+	assert.ErrorIs(t, err, id.ErrInvalidSignature)
+}
+
+func Test_SignedGenerator_Sign(t *testing.T) {
+	gen, err := id.NewSignedGenerator(signingKey)
+	require.NoError(t, err)
+	ulid := id.NewGenerator().Generate()
+
+	// Act
+	token, err := gen.Sign(strings.ToLower(ulid))
+
+	// Assert
+	require.NoError(t, err)
+	inner, err := gen.VerifySigned(token)
+	require.NoError(t, err)
+	assert.Equal(t, ulid, inner)
+	_, err = gen.Sign("invalid")
+	assert.Error(t, err)
+}
+
+func Test_NewSignedGenerator_ShortKey(t *testing.T) {
+	_, err := id.NewSignedGenerator([]byte("short"))
+	assert.Error(t, err)
+	_, err = id.NewSignedGenerator(signingKey, []byte("short"))
+	assert.Error(t, err)
+}
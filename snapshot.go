@@ -0,0 +1,195 @@
+package id
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/oklog/ulid"
+)
+
+// Snapshot is an immutable, chronologically sorted view of a collection of
+// IDs. Each ID is parsed once when the snapshot is built, so the analytics,
+// filter, and search methods can run repeatedly without re-parsing, and
+// time-range and prefix queries are binary searches. A Snapshot is safe for
+// concurrent use without locking; methods returning slices return copies.
+type Snapshot struct {
+	// timed holds the valid IDs as given, with their timestamps, in ID order
+	timed []timedID
+	// parsed is aligned with timed
+	parsed  []ID
+	invalid int
+}
+
+// NewSnapshot parses ids and builds a snapshot of the valid ones, sorted by
+// ID so that IDs sharing a millisecond stay in generation order. Invalid IDs
+// are counted and dropped.
+func NewSnapshot(ids []string) *Snapshot {
+	parsed, errs := ParseBatch(ids)
+
+	order := make([]int, 0, len(ids))
+	for i, err := range errs {
+		if err == nil {
+			order = append(order, i)
+		}
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return parsed[a].Compare(parsed[b])
+	})
+
+	s := &Snapshot{
+		timed:   make([]timedID, len(order)),
+		parsed:  make([]ID, len(order)),
+		invalid: len(ids) - len(order),
+	}
+	for i, j := range order {
+		s.timed[i] = timedID{id: ids[j], ms: parsed[j].Time()}
+		s.parsed[i] = parsed[j]
+	}
+	return s
+}
+
+// Len returns the number of valid IDs in the snapshot
+func (s *Snapshot) Len() int {
+	return len(s.timed)
+}
+
+// Invalid returns the number of invalid IDs dropped when the snapshot was built
+func (s *Snapshot) Invalid() int {
+	return s.invalid
+}
+
+// IDs returns the valid IDs in chronological order, as they were given
+func (s *Snapshot) IDs() []string {
+	return s.slice(0, len(s.timed))
+}
+
+// Stats summarizes the snapshot like AnalyzeIDs, without a pass over the IDs
+func (s *Snapshot) Stats() (Stats, error) {
+	if len(s.timed) == 0 {
+		return Stats{}, errors.New("no valid ULIDs found")
+	}
+
+	first, last := 0, len(s.timed)-1
+	firstTime := s.parsed[first].Timestamp()
+	lastTime := s.parsed[last].Timestamp()
+	return Stats{
+		Count:     len(s.timed),
+		TimeSpan:  lastTime.Sub(firstTime),
+		FirstID:   s.timed[first].id,
+		LastID:    s.timed[last].id,
+		FirstTime: firstTime,
+		LastTime:  lastTime,
+	}, nil
+}
+
+// FilterByTimeRange returns the IDs between start and end inclusive, like
+// FilterByTimeRange, in chronological order
+func (s *Snapshot) FilterByTimeRange(start, end time.Time) []string {
+	return s.slice(s.timeBounds(start, end))
+}
+
+// CountInRange returns how many IDs fall between start and end inclusive
+func (s *Snapshot) CountInRange(start, end time.Time) int {
+	lo, hi := s.timeBounds(start, end)
+	return hi - lo
+}
+
+// timeBounds returns the index range of IDs between start and end inclusive
+func (s *Snapshot) timeBounds(start, end time.Time) (int, int) {
+	lo := sort.Search(len(s.timed), func(i int) bool {
+		return !s.parsed[i].Timestamp().Before(start)
+	})
+	hi := sort.Search(len(s.timed), func(i int) bool {
+		return s.parsed[i].Timestamp().After(end)
+	})
+	return lo, max(lo, hi)
+}
+
+// Contains reports whether the snapshot holds id, in either case
+func (s *Snapshot) Contains(id string) bool {
+	parsed, err := parseCanonical(id)
+	if err != nil {
+		return false
+	}
+	_, found := slices.BinarySearchFunc(s.parsed, ID(parsed), ID.Compare)
+	return found
+}
+
+// MatchPrefix returns the IDs starting with prefix, ignoring case, like
+// MatchPrefix but in chronological order
+func (s *Snapshot) MatchPrefix(prefix string) []string {
+	return s.slice(s.prefixBounds(prefix))
+}
+
+// ResolveUniquePrefix resolves a git-style short ID like ResolveUniquePrefix.
+// Copies of the same ID in different cases are not ambiguous.
+func (s *Snapshot) ResolveUniquePrefix(prefix string) (string, error) {
+	lo, hi := s.prefixBounds(prefix)
+	if lo == hi {
+		return "", fmt.Errorf("%w %q", ErrPrefixNotFound, prefix)
+	}
+	if s.parsed[lo] != s.parsed[hi-1] {
+		return "", fmt.Errorf("%w: %q matches %d IDs", ErrAmbiguousPrefix, prefix, hi-lo)
+	}
+	return s.timed[lo].id, nil
+}
+
+// prefixBounds returns the index range of IDs whose canonical text starts
+// with prefix. Canonical text sorts like the binary IDs, so matches are
+// contiguous.
+func (s *Snapshot) prefixBounds(prefix string) (int, int) {
+	upper := strings.ToUpper(prefix)
+	if len(upper) > ulid.EncodedSize {
+		return 0, 0
+	}
+
+	var text [ulid.EncodedSize]byte
+	head := func(i int) []byte {
+		_ = ulid.ULID(s.parsed[i]).MarshalTextTo(text[:])
+		return text[:len(upper)]
+	}
+	lo := sort.Search(len(s.parsed), func(i int) bool { return string(head(i)) >= upper })
+	hi := sort.Search(len(s.parsed), func(i int) bool { return string(head(i)) > upper })
+	return lo, max(lo, hi)
+}
+
+// SampleByTime returns up to n IDs spread evenly across the snapshot's time
+// span, as SampleByTime does
+func (s *Snapshot) SampleByTime(n int) []string {
+	if n <= 0 {
+		return []string{}
+	}
+	return sampleSorted(s.timed, n)
+}
+
+// Histogram counts the snapshot's IDs per interval, as HistogramOf does
+func (s *Snapshot) Histogram(interval time.Duration) (Histogram, error) {
+	h := Histogram{Interval: interval}
+	if interval <= 0 || len(s.parsed) == 0 {
+		return h, nil
+	}
+
+	start, n, err := bucketRange(s.parsed[0].Timestamp(), s.parsed[len(s.parsed)-1].Timestamp(), interval)
+	if err != nil {
+		return h, err
+	}
+	h.Start = start
+	h.Counts = make([]int, n)
+	for _, u := range s.parsed {
+		h.Counts[int(u.Timestamp().Sub(h.Start)/interval)]++
+	}
+	return h, nil
+}
+
+// slice copies the IDs in the index range [lo, hi)
+func (s *Snapshot) slice(lo, hi int) []string {
+	result := make([]string, hi-lo)
+	for i := range result {
+		result[i] = s.timed[lo+i].id
+	}
+	return result
+}
@@ -0,0 +1,109 @@
+package id_test
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Snapshot(t *testing.T) {
+	gen := id.NewGenerator()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	early := gen.GenerateWithTime(base)
+	middle := gen.GenerateWithTime(base.Add(time.Hour))
+	late := gen.GenerateWithTime(base.Add(2 * time.Hour))
+	ids := []string{late, "invalid", strings.ToLower(early), middle}
+
+	// Act
+	snap := id.NewSnapshot(ids)
+
+	// Assert
+	assert.Equal(t, 3, snap.Len())
+	assert.Equal(t, 1, snap.Invalid())
+	assert.Equal(t, []string{strings.ToLower(early), middle, late}, snap.IDs())
+
+	stats, err := snap.Stats()
+	require.NoError(t, err)
+	want, err := id.AnalyzeIDs(ids)
+	require.NoError(t, err)
+	assert.Equal(t, want, stats)
+
+	assert.Equal(t, []string{middle, late}, snap.FilterByTimeRange(base.Add(time.Hour), base.Add(2*time.Hour)))
+	assert.Equal(t, 1, snap.CountInRange(base.Add(time.Minute), base.Add(90*time.Minute)))
+	assert.Equal(t, 0, snap.CountInRange(base.Add(time.Hour), base))
+
+	assert.True(t, snap.Contains(early))
+	assert.True(t, snap.Contains(strings.ToLower(late)))
+	assert.False(t, snap.Contains(gen.Generate()))
+	assert.False(t, snap.Contains("invalid"))
+
+	hourly, err := snap.Histogram(time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 1, 1}, hourly.Counts)
+	fromIDs, err := id.HistogramOf(ids, 30*time.Minute)
+	require.NoError(t, err)
+	fromSnap, err := snap.Histogram(30 * time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, fromIDs, fromSnap)
+	assert.Equal(t, id.SampleByTime(ids, 2), snap.SampleByTime(2))
+	assert.Empty(t, snap.SampleByTime(0))
+}
+
+func Test_Snapshot_Prefix(t *testing.T) {
+	ids := []string{
+		"01BX5ZZKBKACTAV9WEVGEMMVRZ",
+		"01ARZ3NDEKTSV4RRFFQ69G5FAW",
+		"01ARZ3NDEKTSV4RRFFQ69G5FAV",
+		"01bx5zzkbkactav9wevgemmvrz",
+	}
+	snap := id.NewSnapshot(ids)
+
+	// Act & Assert
+	assert.Equal(t, []string{ids[2], ids[1]}, snap.MatchPrefix("01arz3nd"))
+	assert.Len(t, snap.MatchPrefix(""), 4)
+	assert.Empty(t, snap.MatchPrefix("7ZZZ"))
+	assert.Empty(t, snap.MatchPrefix(ids[0]+"0"))
+
+	resolved, err := snap.ResolveUniquePrefix("01bx")
+	require.NoError(t, err)
+	assert.Equal(t, ids[0], resolved)
+	_, err = snap.ResolveUniquePrefix("01AR")
+	assert.ErrorIs(t, err, id.ErrAmbiguousPrefix)
+	_, err = snap.ResolveUniquePrefix("02")
+	assert.ErrorIs(t, err, id.ErrPrefixNotFound)
+}
+
+func Test_Snapshot_Empty(t *testing.T) {
+	snap := id.NewSnapshot([]string{"invalid"})
+
+	_, err := snap.Stats()
+	assert.Error(t, err)
+	assert.Empty(t, snap.IDs())
+	hist, err := snap.Histogram(time.Hour)
+	require.NoError(t, err)
+	assert.Empty(t, hist.Counts)
+	assert.Empty(t, snap.FilterByTimeRange(time.Time{}, time.Now()))
+}
+
+func Test_Snapshot_Concurrent(t *testing.T) {
+	snap := id.NewSnapshot(id.NewGenerator().GenerateBatch(1000))
+	var wg sync.WaitGroup
+
+	// Act & Assert
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				assert.Equal(t, 1000, snap.CountInRange(time.Time{}, time.Now().Add(time.Hour)))
+				_ = snap.MatchPrefix("0")
+			}
+		}()
+	}
+	wg.Wait()
+}
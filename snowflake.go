@@ -0,0 +1,168 @@
+package id
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// SchemeSnowflake selects 64-bit Snowflake IDs
+	SchemeSnowflake SchemeName = "snowflake"
+
+	// MaxSnowflakeNode is the largest node number a Snowflake ID can carry
+	MaxSnowflakeNode = 1<<snowflakeNodeBits - 1
+
+	snowflakeNodeBits     = 10
+	snowflakeSequenceBits = 12
+	snowflakeTimeBits     = 41
+	snowflakeMaxSequence  = 1<<snowflakeSequenceBits - 1
+	// snowflakeBackfillSequence is the first sequence number of IDs for times
+	// behind the clamp window; live IDs use the numbers below it
+	snowflakeBackfillSequence = 1 << (snowflakeSequenceBits - 1)
+	// snowflakeClampWindow is how far behind the last ID a time may fall and
+	// still continue its sequence
+	snowflakeClampWindow = int64(time.Second / time.Millisecond)
+)
+
+// TwitterEpoch is the epoch of Twitter's Snowflake IDs and the default for SchemeSnowflake
+var TwitterEpoch = time.UnixMilli(1_288_834_974_657)
+
+// ErrInvalidNode is returned for a node number above MaxSnowflakeNode or MaxNode
+var ErrInvalidNode = errors.New("invalid node")
+
+// ErrSequenceExhausted is returned when a Snowflake millisecond behind the
+// clamp window has no sequence numbers left
+var ErrSequenceExhausted = errors.New("snowflake sequence exhausted")
+
+// SnowflakeScheme issues Snowflake IDs: 41 bits of milliseconds since an
+// epoch, a 10-bit node number, and a 12-bit sequence, rendered as a decimal
+// integer. IDs in the same millisecond take successive sequence numbers, and
+// an ID for a time at most a second behind the latest one continues its
+// sequence, so sequence overflow and small clock steps backward never repeat
+// an ID. Live IDs use the lower half of the sequence, moving to the next
+// millisecond when it runs out. IDs for times further behind are backfills:
+// they take the upper half, counted per millisecond, so they never repeat a
+// live ID or each other. Nodes sharing an epoch must use distinct node
+// numbers. It is safe for concurrent use.
+type SnowflakeScheme struct {
+	epochMs int64
+	node    uint64
+
+	mu         sync.Mutex
+	issued     bool
+	lastMs     int64
+	seq        uint64
+	backfilled map[int64]uint64
+}
+
+// NewSnowflakeScheme creates a Snowflake scheme counting from epoch that
+// stamps node into every ID it issues
+func NewSnowflakeScheme(epoch time.Time, node uint16) (*SnowflakeScheme, error) {
+	if node > MaxSnowflakeNode {
+		return nil, fmt.Errorf("%w: %d exceeds %d", ErrInvalidNode, node, MaxSnowflakeNode)
+	}
+	return &SnowflakeScheme{epochMs: epoch.UnixMilli(), node: uint64(node)}, nil
+}
+
+// Name returns SchemeSnowflake
+func (s *SnowflakeScheme) Name() SchemeName {
+	return SchemeSnowflake
+}
+
+// Size returns 8
+func (s *SnowflakeScheme) Size() int {
+	return 8
+}
+
+// New encodes a Snowflake ID for t. Snowflake IDs carry no randomness, so
+// entropy is unused.
+func (s *SnowflakeScheme) New(t time.Time, _ io.Reader) (string, error) {
+	ms := t.UnixMilli() - s.epochMs
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if ms < 0 || ms >= 1<<snowflakeTimeBits {
+		return "", fmt.Errorf("time %s outside the Snowflake range of its epoch", t.UTC().Format(time.RFC3339))
+	}
+	if s.issued && s.lastMs-ms > snowflakeClampWindow {
+		return s.backfill(ms)
+	}
+
+	if s.issued && ms <= s.lastMs {
+		ms = s.lastMs
+		s.seq++
+		if s.seq >= snowflakeBackfillSequence {
+			ms++
+			s.seq = 0
+		}
+	} else {
+		s.seq = 0
+	}
+	s.issued, s.lastMs = true, ms
+	return s.format(ms, s.seq), nil
+}
+
+// backfill issues an ID for a millisecond behind the clamp window without
+// moving the live sequence back; callers must hold s.mu
+func (s *SnowflakeScheme) backfill(ms int64) (string, error) {
+	n := s.backfilled[ms]
+	if n > snowflakeMaxSequence-snowflakeBackfillSequence {
+		return "", fmt.Errorf("%w at %s", ErrSequenceExhausted, time.UnixMilli(s.epochMs+ms).UTC().Format(time.RFC3339Nano))
+	}
+	if s.backfilled == nil {
+		s.backfilled = make(map[int64]uint64)
+	}
+	s.backfilled[ms] = n + 1
+	return s.format(ms, snowflakeBackfillSequence+n), nil
+}
+
+// format renders the Snowflake ID for a millisecond and sequence number
+func (s *SnowflakeScheme) format(ms int64, seq uint64) string {
+	value := uint64(ms)<<(snowflakeNodeBits+snowflakeSequenceBits) | s.node<<snowflakeSequenceBits | seq
+	return strconv.FormatUint(value, 10)
+}
+
+// Decode parses a non-negative decimal Snowflake ID below 2^63
+func (s *SnowflakeScheme) Decode(id string) ([]byte, error) {
+	value, err := strconv.ParseUint(id, 10, 63)
+	if err != nil {
+		return nil, err
+	}
+	return binary.BigEndian.AppendUint64(nil, value), nil
+}
+
+// Encode renders an 8-byte big-endian Snowflake ID in decimal
+func (s *SnowflakeScheme) Encode(raw []byte) (string, error) {
+	if len(raw) != 8 {
+		return "", fmt.Errorf("snowflake ID is 8 bytes, got %d", len(raw))
+	}
+	value := binary.BigEndian.Uint64(raw)
+	if value>>63 != 0 {
+		return "", fmt.Errorf("snowflake ID %d overflows 63 bits", value)
+	}
+	return strconv.FormatUint(value, 10), nil
+}
+
+// Time returns the Snowflake ID's timestamp, which has millisecond precision
+func (s *SnowflakeScheme) Time(raw []byte) time.Time {
+	if len(raw) != 8 {
+		return time.Time{}
+	}
+	ms := binary.BigEndian.Uint64(raw) >> (snowflakeNodeBits + snowflakeSequenceBits)
+	return time.UnixMilli(s.epochMs + int64(ms)) //nolint:gosec // G115: at most 41 bits
+}
+
+// Node returns the node number stamped into a Snowflake ID
+func (s *SnowflakeScheme) Node(id string) (uint16, error) {
+	raw, err := s.Decode(id)
+	if err != nil {
+		return 0, fmt.Errorf("invalid snowflake: %w", err)
+	}
+	return uint16(binary.BigEndian.Uint64(raw) >> snowflakeSequenceBits & MaxSnowflakeNode), nil //nolint:gosec // G115: masked to 10 bits
+}
@@ -0,0 +1,144 @@
+package id_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/bold-minds/id/idtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// discordEpoch is the epoch of Discord's Snowflake IDs
+var discordEpoch = time.UnixMilli(1_420_070_400_000)
+
+func Test_SnowflakeScheme_Vector(t *testing.T) {
+	scheme, err := id.NewSnowflakeScheme(discordEpoch, 0)
+	require.NoError(t, err)
+
+	// Act
+	raw, err := scheme.Decode("175928847299117063")
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, scheme.Time(raw).Equal(time.Date(2016, 4, 30, 11, 18, 25, 796_000_000, time.UTC)))
+	node, err := scheme.Node("175928847299117063")
+	require.NoError(t, err)
+	assert.Equal(t, uint16(32), node)
+}
+
+func Test_SnowflakeScheme_New(t *testing.T) {
+	scheme, err := id.NewSnowflakeScheme(id.TwitterEpoch, 7)
+	require.NoError(t, err)
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	first, err := scheme.New(at, nil)
+	require.NoError(t, err)
+	second, err := scheme.New(at, nil)
+	require.NoError(t, err)
+	regressed, err := scheme.New(at.Add(-time.Millisecond), nil)
+	require.NoError(t, err)
+
+	// Assert
+	gen := id.NewGenerator(id.WithIDScheme(scheme))
+	for _, pair := range [][2]string{{first, second}, {second, regressed}} {
+		before, err := gen.IsBefore(pair[0], pair[1])
+		require.NoError(t, err)
+		assert.True(t, before, "%s before %s", pair[0], pair[1])
+	}
+	node, err := scheme.Node(first)
+	require.NoError(t, err)
+	assert.Equal(t, uint16(7), node)
+}
+
+func Test_SnowflakeScheme_SequenceOverflow(t *testing.T) {
+	scheme, err := id.NewSnowflakeScheme(id.TwitterEpoch, 0)
+	require.NoError(t, err)
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	seen := map[string]bool{}
+
+	// Act
+	var last string
+	for i := 0; i < 5000; i++ {
+		last, err = scheme.New(at, nil)
+		require.NoError(t, err)
+		seen[last] = true
+	}
+
+	// Assert
+	assert.Len(t, seen, 5000)
+	raw, err := scheme.Decode(last)
+	require.NoError(t, err)
+	assert.True(t, scheme.Time(raw).Equal(at.Add(2*time.Millisecond)), "overflow borrows the next millisecond")
+}
+
+func Test_SnowflakeScheme_Backfill(t *testing.T) {
+	clock := id.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	gen := id.NewGenerator(id.WithClock(clock), id.WithScheme(id.SchemeSnowflake))
+	seen := map[string]bool{}
+
+	// Act
+	first := gen.Generate()
+	backfilled := gen.GenerateWithTime(clock.Now().Add(-time.Hour))
+	again := gen.GenerateWithTime(clock.Now().Add(-time.Hour))
+	third := gen.Generate()
+
+	// Assert
+	for _, generated := range []string{first, backfilled, again, third} {
+		assert.False(t, seen[generated], "duplicate %s", generated)
+		seen[generated] = true
+	}
+	before, err := gen.IsBefore(first, third)
+	require.NoError(t, err)
+	assert.True(t, before)
+	before, err = gen.IsBefore(backfilled, again)
+	require.NoError(t, err)
+	assert.True(t, before)
+	extracted, err := gen.ExtractTimestamp(backfilled)
+	require.NoError(t, err)
+	assert.True(t, extracted.Equal(clock.Now().Add(-time.Hour)))
+}
+
+func Test_SnowflakeScheme_BackfillExhausted(t *testing.T) {
+	scheme, err := id.NewSnowflakeScheme(id.TwitterEpoch, 0)
+	require.NoError(t, err)
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	_, err = scheme.New(at, nil)
+	require.NoError(t, err)
+	for i := 0; i < 2048; i++ {
+		_, err = scheme.New(at.Add(-time.Hour), nil)
+		require.NoError(t, err)
+	}
+
+	// Act
+	_, err = scheme.New(at.Add(-time.Hour), nil)
+
+	// Assert
+	assert.ErrorIs(t, err, id.ErrSequenceExhausted)
+}
+
+func Test_SnowflakeScheme_Invalid(t *testing.T) {
+	_, err := id.NewSnowflakeScheme(id.TwitterEpoch, id.MaxSnowflakeNode+1)
+	assert.ErrorIs(t, err, id.ErrInvalidNode)
+
+	scheme, err := id.NewSnowflakeScheme(id.TwitterEpoch, 0)
+	require.NoError(t, err)
+	for _, bad := range []string{"-1", "+1", "1_000", "9223372036854775808", "abc"} {
+		_, err := scheme.Decode(bad)
+		assert.Error(t, err, bad)
+	}
+	_, err = scheme.New(id.TwitterEpoch.Add(-time.Hour), nil)
+	assert.Error(t, err, "before the epoch")
+}
+
+func Test_Generator_WithScheme_Snowflake(t *testing.T) {
+	gen := id.NewGenerator(id.WithScheme(id.SchemeSnowflake))
+
+	idtest.TestProvider(t, gen)
+
+	normalized, err := gen.ValidateAndNormalize("000175928847299117063")
+	require.NoError(t, err)
+	assert.Equal(t, "175928847299117063", normalized)
+}
@@ -0,0 +1,211 @@
+package id
+
+import (
+	"bytes"
+	"cmp"
+	"slices"
+
+	"github.com/oklog/ulid"
+)
+
+// Order compares two IDs, returning a negative number, zero, or a positive
+// number. Orders are passed to SortChronologicallyBy to replace the default
+// chronological ordering without reimplementing the sort; IDs an order
+// considers equal keep their original relative order.
+type Order func(a, b ID) int
+
+var (
+	// Chronological orders IDs by timestamp, then entropy, which is also
+	// the lexicographic order of canonical ULIDs
+	Chronological Order = ID.Compare
+	// ReverseChronological orders IDs newest first
+	ReverseChronological = Reverse(Chronological)
+	// ByTime orders IDs by their millisecond timestamp alone
+	ByTime Order = func(a, b ID) int {
+		return cmp.Compare(a.Time(), b.Time())
+	}
+	// ByEntropy orders IDs by their entropy alone, ignoring the timestamp
+	ByEntropy Order = func(a, b ID) int {
+		return bytes.Compare(a[6:], b[6:])
+	}
+)
+
+var (
+	// TieBreakEntropy orders same-millisecond IDs by their entropy, matching
+	// the lexicographic order of canonical ULIDs and SortChronologically
+	TieBreakEntropy = ByEntropy
+	// TieBreakStable keeps same-millisecond IDs in their original order, for
+	// reproducible output from merge jobs
+	TieBreakStable Order = func(ID, ID) int { return 0 }
+)
+
+// Reverse inverts an order
+func Reverse(order Order) Order {
+	return func(a, b ID) int {
+		return order(b, a)
+	}
+}
+
+// Then combines orders so each one breaks ties left by the orders before it
+func Then(orders ...Order) Order {
+	return func(a, b ID) int {
+		for _, order := range orders {
+			if c := order(a, b); c != 0 {
+				return c
+			}
+		}
+		return 0
+	}
+}
+
+// ByEntropyBits orders IDs by the width-bit field starting offset bits into
+// the entropy, such as a node or region code reserved with PlanBits
+func ByEntropyBits(offset, width int) Order {
+	return func(a, b ID) int {
+		return cmp.Compare(entropyBits(ulid.ULID(a), offset, width), entropyBits(ulid.ULID(b), offset, width))
+	}
+}
+
+// sortEntry is an ID parsed once ahead of sorting
+type sortEntry struct {
+	id  string
+	u   ID
+	pos int
+}
+
+// SortChronologicallyBy sorts IDs by orders, each breaking ties left by the
+// ones before it, and with no orders by Chronological. Invalid IDs are placed
+// after all valid ones in their original order. Each ID is parsed once.
+func SortChronologicallyBy(ids []string, orders ...Order) []string {
+	if len(ids) <= 1 {
+		return ids
+	}
+
+	order := Chronological
+	if len(orders) > 0 {
+		order = Then(orders...)
+	}
+	return sortEntries(ids, func(a, b sortEntry) int {
+		return order(a.u, b.u)
+	})
+}
+
+// SortChronologicallyWith sorts IDs by timestamp, using tie to order IDs that
+// share a millisecond. A nil tie behaves like TieBreakStable. Invalid IDs are
+// placed after all valid ones in their original order.
+func SortChronologicallyWith(ids []string, tie Order) []string {
+	if tie == nil {
+		tie = TieBreakStable
+	}
+	return SortChronologicallyBy(ids, ByTime, tie)
+}
+
+// sortEntries parses each ID once and sorts the valid ones with compare,
+// keeping the input order of entries compare considers equal and placing
+// invalid IDs after them in their original order
+func sortEntries(ids []string, compare func(a, b sortEntry) int) []string {
+	parsed, errs := ParseBatch(ids)
+	return sortParsed(ids, parsed, errs, compare)
+}
+
+// sortParsed sorts ids by their parsed forms as sortEntries does, placing
+// those with errors last
+func sortParsed(ids []string, parsed []ID, errs []error, compare func(a, b sortEntry) int) []string {
+	entries := make([]sortEntry, 0, len(ids))
+	var invalid []string
+	for i, id := range ids {
+		if errs[i] != nil {
+			invalid = append(invalid, id)
+			continue
+		}
+		entries = append(entries, sortEntry{id: id, u: parsed[i], pos: i})
+	}
+
+	// Breaking ties by position gives a stable result from the faster unstable sort
+	slices.SortFunc(entries, func(a, b sortEntry) int {
+		if c := compare(a, b); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.pos, b.pos)
+	})
+
+	result := make([]string, 0, len(ids))
+	for _, e := range entries {
+		result = append(result, e.id)
+	}
+	return append(result, invalid...)
+}
+
+// CompareStrings compares two IDs lexicographically, ignoring ASCII case,
+// which orders canonical and lowercase ULIDs chronologically. It never
+// panics or allocates and gives every string a consistent position, so it
+// can be passed directly to slices.SortFunc and slices.BinarySearchFunc.
+func CompareStrings(a, b string) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		ca, cb := upperASCII(a[i]), upperASCII(b[i])
+		if ca != cb {
+			return cmp.Compare(ca, cb)
+		}
+	}
+	return cmp.Compare(len(a), len(b))
+}
+
+// upperASCII converts an ASCII lowercase letter to uppercase
+func upperASCII(c byte) byte {
+	if 'a' <= c && c <= 'z' {
+		return c - ('a' - 'A')
+	}
+	return c
+}
+
+// Character classes for sortLexicographic
+const (
+	classInvalid = iota
+	classDigit
+	classUpper
+	classLower
+)
+
+// charClasses classifies every byte against the Crockford Base32 alphabet
+var charClasses = func() [256]uint8 {
+	var classes [256]uint8
+	for i := 0; i < len(ulid.Encoding); i++ {
+		c := ulid.Encoding[i]
+		if c <= '9' {
+			classes[c] = classDigit
+			continue
+		}
+		classes[c] = classUpper
+		classes[c+'a'-'A'] = classLower
+	}
+	return classes
+}()
+
+// sortLexicographic sorts a copy of ids as plain strings when that matches
+// chronological order: every ID is a valid ULID and letters do not mix
+// cases. It reports false, without sorting, otherwise.
+func sortLexicographic(ids []string) ([]string, bool) {
+	var upper, lower bool
+	for _, id := range ids {
+		if len(id) != ulid.EncodedSize || id[0] > '7' {
+			return nil, false
+		}
+		for i := 0; i < len(id); i++ {
+			switch charClasses[id[i]] {
+			case classInvalid:
+				return nil, false
+			case classUpper:
+				upper = true
+			case classLower:
+				lower = true
+			}
+		}
+		if upper && lower {
+			return nil, false
+		}
+	}
+
+	sorted := slices.Clone(ids)
+	slices.Sort(sorted)
+	return sorted, true
+}
@@ -0,0 +1,155 @@
+package id_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SortChronologicallyWith(t *testing.T) {
+	base := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	gen := id.NewGenerator()
+	earlier := gen.GenerateWithTime(base)
+	later := gen.GenerateWithTime(base.Add(time.Second))
+
+	// Two same-millisecond IDs with known entropy ordering
+	tieLow := later[:10] + "0000000000000001"
+	tieHigh := later[:10] + "ZZZZZZZZZZZZZZZZ"
+	input := []string{"invalid", tieHigh, later, earlier, tieLow}
+
+	// Act
+	stable := id.SortChronologicallyWith(input, id.TieBreakStable)
+	byEntropy := id.SortChronologicallyWith(input, id.TieBreakEntropy)
+	reversed := id.SortChronologicallyWith(input, id.Reverse(id.ByEntropy))
+
+	// Assert
+	assert.Equal(t, []string{earlier, tieHigh, later, tieLow, "invalid"}, stable)
+	assert.Equal(t, []string{earlier, tieLow, later, tieHigh, "invalid"}, byEntropy)
+	assert.Equal(t, []string{earlier, tieHigh, later, tieLow, "invalid"}, reversed)
+	assert.Equal(t, stable, id.SortChronologicallyWith(input, nil))
+	assert.Equal(t, []string{"invalid", tieHigh, later, earlier, tieLow}, input, "input must not be modified")
+}
+
+func Test_SortChronologicallyBy(t *testing.T) {
+	base := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	gen := id.NewGenerator()
+	ids := []string{
+		gen.GenerateWithTime(base.Add(2 * time.Second)),
+		gen.GenerateWithTime(base),
+		"invalid",
+		gen.GenerateWithTime(base.Add(time.Second)),
+	}
+
+	// Act
+	forward := id.SortChronologicallyBy(ids)
+	reverse := id.SortChronologicallyBy(ids, id.ReverseChronological)
+
+	// Assert
+	assert.Equal(t, []string{ids[1], ids[3], ids[0], "invalid"}, forward)
+	assert.Equal(t, []string{ids[0], ids[3], ids[1], "invalid"}, reverse)
+	assert.Equal(t, forward, id.SortChronologically(ids))
+	var sorter func([]string) []string = id.SortChronologically
+	assert.Equal(t, forward, sorter(ids), "SortChronologically keeps its original signature")
+}
+
+func Test_SortChronologically_ByEntropyBits(t *testing.T) {
+	regions, err := id.NewRegionMap(4, map[string]uint16{"us": 2, "eu": 1})
+	require.NoError(t, err)
+	us, err := regions.NewGenerator("us")
+	require.NoError(t, err)
+	eu, err := regions.NewGenerator("eu")
+	require.NoError(t, err)
+
+	base := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	usLate := us.GenerateWithTime(base.Add(time.Minute))
+	usEarly := us.GenerateWithTime(base)
+	euLate := eu.GenerateWithTime(base.Add(time.Minute))
+	euEarly := eu.GenerateWithTime(base)
+
+	// Act
+	sorted := id.SortChronologicallyBy([]string{usLate, euLate, usEarly, euEarly},
+		id.ByEntropyBits(id.RegionOffset, regions.Bits()), id.Chronological)
+
+	// Assert
+	assert.Equal(t, []string{euEarly, euLate, usEarly, usLate}, sorted)
+}
+
+func Test_Orders(t *testing.T) {
+	gen := id.NewGenerator()
+	a := gen.GenerateIDWithTime(time.UnixMilli(1000))
+	b := gen.GenerateIDWithTime(time.UnixMilli(2000))
+
+	// Assert
+	assert.Negative(t, id.Chronological(a, b))
+	assert.Negative(t, id.ByTime(a, b))
+	assert.Zero(t, id.ByTime(a, a))
+	assert.Positive(t, id.ReverseChronological(a, b))
+	assert.Equal(t, -id.ByEntropy(a, b), id.Reverse(id.ByEntropy)(a, b))
+	assert.Zero(t, id.Then()(a, b))
+	assert.Negative(t, id.Then(id.ByEntropyBits(0, 0), id.Chronological)(a, b))
+}
+
+func Test_CompareStrings(t *testing.T) {
+	gen := id.NewGenerator()
+	base := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	ids := gen.GenerateRange(base, base.Add(time.Hour), 50)
+	mixed := make([]string, len(ids))
+	for i, s := range ids {
+		if i%2 == 0 {
+			s = strings.ToLower(s)
+		}
+		mixed[i] = s
+	}
+	shuffled := append([]string(nil), mixed...)
+	slices.Reverse(shuffled)
+
+	// Act
+	slices.SortFunc(shuffled, id.CompareStrings)
+	pos, found := slices.BinarySearchFunc(shuffled, ids[20], id.CompareStrings)
+
+	// Assert
+	assert.Equal(t, mixed, shuffled)
+	assert.True(t, found)
+	assert.Equal(t, 20, pos)
+	assert.Zero(t, id.CompareStrings(ids[0], strings.ToLower(ids[0])))
+	assert.Negative(t, id.CompareStrings("", ids[0]))
+	assert.Negative(t, id.CompareStrings("01", "01A"))
+	assert.Zero(t, testing.AllocsPerRun(100, func() { id.CompareStrings(ids[0], mixed[1]) }))
+}
+
+func Test_SortChronologically_FastAndFallbackAgree(t *testing.T) {
+	gen := id.NewGenerator()
+	base := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	ids := gen.GenerateRange(base, base.Add(time.Hour), 200)
+	reversed := slices.Clone(ids)
+	slices.Reverse(reversed)
+
+	lower := make([]string, len(reversed))
+	mixed := make([]string, len(reversed))
+	for i, s := range reversed {
+		lower[i] = strings.ToLower(s)
+		mixed[i] = s
+		if i%3 == 0 {
+			mixed[i] = strings.ToLower(s)
+		}
+	}
+
+	// Act
+	upperSorted := id.SortChronologically(reversed)
+	lowerSorted := id.SortChronologically(lower)
+	mixedSorted := id.SortChronologically(append(slices.Clone(mixed), "invalid"))
+
+	// Assert
+	assert.Equal(t, ids, upperSorted)
+	assert.Equal(t, ids[len(ids)-1], reversed[0], "input must not be modified")
+	for i := range ids {
+		assert.Equal(t, strings.ToLower(ids[i]), lowerSorted[i])
+		assert.Equal(t, ids[i], strings.ToUpper(mixedSorted[i]))
+	}
+	assert.Equal(t, "invalid", mixedSorted[len(mixedSorted)-1])
+}
@@ -0,0 +1,214 @@
+package id
+
+import (
+	"bufio"
+	"container/heap"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+const (
+	// DefaultSortMemory is the memory SortFile uses for non-positive limits
+	DefaultSortMemory = 64 << 20
+
+	// sortLineOverhead approximates the bytes a buffered line costs beyond
+	// its text: a string header in the chunk slice
+	sortLineOverhead = 16
+	// sortFanIn caps how many runs are merged at once, keeping open files
+	// well below common descriptor limits
+	sortFanIn = 64
+	// sortMaxLine is the longest line SortFile accepts
+	sortMaxLine = 1 << 20
+)
+
+// SortFile sorts a file of newline-delimited IDs into out, using at most
+// about memLimit bytes for buffered lines, or DefaultSortMemory if memLimit
+// is not positive. Input larger than the limit is sorted in chunks spilled
+// to temporary files, which are merged and removed, so inputs of billions of
+// IDs sort in bounded memory. Lines are ordered like CompareStrings, which
+// is chronological for ULIDs in either case; equal lines keep their input
+// order. Blank lines are dropped and a trailing carriage return is removed.
+func SortFile(in, out string, memLimit int) error {
+	if memLimit <= 0 {
+		memLimit = DefaultSortMemory
+	}
+
+	dir, err := os.MkdirTemp("", "idsort-")
+	if err != nil {
+		return fmt.Errorf("creating spill directory: %w", err)
+	}
+	defer os.RemoveAll(dir) //nolint:errcheck // best-effort cleanup
+
+	runs, err := spillRuns(in, out, dir, memLimit)
+	if err != nil || len(runs) == 0 {
+		return err
+	}
+
+	// Merge in passes until one pass can write the output
+	for pass := 0; len(runs) > sortFanIn; pass++ {
+		var merged []string
+		for i := 0; i < len(runs); i += sortFanIn {
+			run := filepath.Join(dir, fmt.Sprintf("merge-%d-%d", pass, len(merged)))
+			if err = mergeRuns(runs[i:min(i+sortFanIn, len(runs))], run); err != nil {
+				return err
+			}
+			merged = append(merged, run)
+		}
+		runs = merged
+	}
+	return mergeRuns(runs, out)
+}
+
+// spillRuns reads in, writing each full chunk of lines to a sorted run file
+// in dir. When all lines fit in one chunk it writes them straight to out and
+// returns no runs.
+func spillRuns(in, out, dir string, memLimit int) ([]string, error) {
+	src, err := os.Open(in) //nolint:gosec // G304: reading the caller's file is the point
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close() //nolint:errcheck // read-only
+
+	var runs []string
+	var chunk []string
+	size := 0
+	flush := func(path string) error {
+		slices.SortStableFunc(chunk, CompareStrings)
+		writeErr := writeLines(path, chunk)
+		chunk, size = chunk[:0], 0
+		return writeErr
+	}
+
+	scanner := bufio.NewScanner(src)
+	scanner.Buffer(make([]byte, 0, 64<<10), sortMaxLine)
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+		if size+len(line)+sortLineOverhead > memLimit && len(chunk) > 0 {
+			run := filepath.Join(dir, fmt.Sprintf("run-%d", len(runs)))
+			if err = flush(run); err != nil {
+				return nil, err
+			}
+			runs = append(runs, run)
+		}
+		chunk = append(chunk, line)
+		size += len(line) + sortLineOverhead
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading IDs: %w", err)
+	}
+
+	if len(runs) == 0 {
+		return nil, flush(out)
+	}
+	run := filepath.Join(dir, fmt.Sprintf("run-%d", len(runs)))
+	if err = flush(run); err != nil {
+		return nil, err
+	}
+	return append(runs, run), nil
+}
+
+// writeLines writes lines to path, one per line
+func writeLines(path string, lines []string) (err error) {
+	f, err := os.Create(path) //nolint:gosec // G304: path is the caller's output or a spill file
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, f.Close())
+	}()
+
+	w := bufio.NewWriter(f)
+	for _, line := range lines {
+		_, _ = w.WriteString(line)
+		_ = w.WriteByte('\n')
+	}
+	return w.Flush()
+}
+
+// mergeRuns merges sorted run files into path. Equal lines come from the
+// earliest run first, which keeps the sort stable.
+func mergeRuns(runs []string, path string) (err error) {
+	h := make(runHeap, 0, len(runs))
+	for i, run := range runs {
+		src, openErr := os.Open(run) //nolint:gosec // G304: spill file created by SortFile
+		if openErr != nil {
+			return openErr
+		}
+		defer src.Close() //nolint:errcheck // read-only
+
+		scanner := bufio.NewScanner(src)
+		scanner.Buffer(make([]byte, 0, 64<<10), sortMaxLine)
+		if scanner.Scan() {
+			h = append(h, &runCursor{line: scanner.Text(), run: i, scanner: scanner})
+		} else if scanErr := scanner.Err(); scanErr != nil {
+			return scanErr
+		}
+	}
+	heap.Init(&h)
+
+	f, err := os.Create(path) //nolint:gosec // G304: path is the caller's output or a spill file
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, f.Close())
+	}()
+
+	w := bufio.NewWriter(f)
+	for len(h) > 0 {
+		cursor := h[0]
+		_, _ = w.WriteString(cursor.line)
+		_ = w.WriteByte('\n')
+		if cursor.scanner.Scan() {
+			cursor.line = cursor.scanner.Text()
+			heap.Fix(&h, 0)
+			continue
+		}
+		if err = cursor.scanner.Err(); err != nil {
+			return err
+		}
+		heap.Pop(&h)
+	}
+	return w.Flush()
+}
+
+// runCursor is the next unmerged line of a run
+type runCursor struct {
+	line    string
+	run     int
+	scanner *bufio.Scanner
+}
+
+// runHeap orders run cursors by line, then run, for mergeRuns
+type runHeap []*runCursor
+
+func (h runHeap) Len() int { return len(h) }
+
+func (h runHeap) Less(i, j int) bool {
+	if c := CompareStrings(h[i].line, h[j].line); c != 0 {
+		return c < 0
+	}
+	return h[i].run < h[j].run
+}
+
+func (h runHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *runHeap) Push(x any) {
+	if cursor, ok := x.(*runCursor); ok {
+		*h = append(*h, cursor)
+	}
+}
+
+func (h *runHeap) Pop() any {
+	old := *h
+	cursor := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return cursor
+}
@@ -0,0 +1,78 @@
+package id_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sortFileCase writes ids to a temporary file, sorts it with memLimit, and
+// returns the sorted lines
+func sortFileCase(t *testing.T, input string, memLimit int) []string {
+	t.Helper()
+	dir := t.TempDir()
+	in, out := filepath.Join(dir, "in.txt"), filepath.Join(dir, "out.txt")
+	require.NoError(t, os.WriteFile(in, []byte(input), 0o600))
+
+	require.NoError(t, id.SortFile(in, out, memLimit))
+
+	data, err := os.ReadFile(out)
+	require.NoError(t, err)
+	if len(data) == 0 {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+}
+
+func Test_SortFile(t *testing.T) {
+	ids := shuffledIDs(500)
+	input := strings.Join(ids, "\n") + "\n"
+
+	for name, memLimit := range map[string]int{
+		"InMemory":  0,
+		"Spilled":   2000,
+		"MultiPass": 1, // one line per run, forcing several merge passes
+		"FewRuns":   430,
+	} {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			sorted := sortFileCase(t, input, memLimit)
+
+			// Assert
+			assert.Equal(t, id.SortChronologically(ids), sorted)
+		})
+	}
+}
+
+func Test_SortFile_StableAndClean(t *testing.T) {
+	gen := id.NewGenerator()
+	a, b := gen.Generate(), gen.Generate()
+	input := b + "\r\n\n" + strings.ToLower(a) + "\n" + a + "\n" + strings.ToLower(b)
+
+	for _, memLimit := range []int{0, 1} {
+		// Act
+		sorted := sortFileCase(t, input, memLimit)
+
+		// Assert
+		assert.Equal(t, []string{strings.ToLower(a), a, b, strings.ToLower(b)}, sorted)
+	}
+}
+
+func Test_SortFile_Empty(t *testing.T) {
+	assert.Empty(t, sortFileCase(t, "", 0))
+}
+
+func Test_SortFile_MissingInput(t *testing.T) {
+	dir := t.TempDir()
+
+	// Act
+	err := id.SortFile(filepath.Join(dir, "missing.txt"), filepath.Join(dir, "out.txt"), 0)
+
+	// Assert
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
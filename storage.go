@@ -0,0 +1,226 @@
+package id
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// StorageProfile selects how an ID is represented. Two independent settings
+// use it: the database/sql and encoding/gob integrations consult
+// CurrentStorageProfile, and the encoding/json and encoding.Text ones consult
+// CurrentTextProfile, so moving a database column to bytes does not change
+// an API's JSON.
+type StorageProfile int
+
+const (
+	// StorageText stores the canonical 26-character ULID string
+	StorageText StorageProfile = iota
+	// StorageBinary stores the 16 raw bytes
+	StorageBinary
+	// StorageUUID stores the 16 bytes in a native UUID column or as UUID text
+	StorageUUID
+	// StorageBase64 stores the 22-character unpadded base64url string. It is
+	// the most compact text form but does not sort in time order.
+	StorageBase64
+	// StorageOrderedBinary stores the 16 bytes rearranged so that SQL
+	// Server's UNIQUEIDENTIFIER comparison, which weighs the last 6 bytes
+	// first, follows ULID time order
+	StorageOrderedBinary
+)
+
+// String returns the name of the storage profile
+func (s StorageProfile) String() string {
+	switch s {
+	case StorageText:
+		return "text"
+	case StorageBinary:
+		return "binary"
+	case StorageUUID:
+		return "uuid"
+	case StorageBase64:
+		return "base64"
+	case StorageOrderedBinary:
+		return "ordered-binary"
+	default:
+		return fmt.Sprintf("StorageProfile(%d)", int(s))
+	}
+}
+
+// storageProfileNames maps configuration names, including the descriptive
+// aliases, to profiles
+var storageProfileNames = map[string]StorageProfile{
+	"text":           StorageText,
+	"text-26":        StorageText,
+	"binary":         StorageBinary,
+	"binary-16":      StorageBinary,
+	"uuid":           StorageUUID,
+	"uuid-text":      StorageUUID,
+	"base64":         StorageBase64,
+	"base64-22":      StorageBase64,
+	"ordered-binary": StorageOrderedBinary,
+}
+
+// ParseStorageProfile returns the profile named by a configuration value such
+// as "text", "binary-16", or "ordered-binary", ignoring case
+func ParseStorageProfile(name string) (StorageProfile, error) {
+	if p, ok := storageProfileNames[strings.ToLower(strings.TrimSpace(name))]; ok {
+		return p, nil
+	}
+	return 0, fmt.Errorf("unknown storage profile: %q", name)
+}
+
+// IsBinary reports whether the profile stores raw bytes rather than text
+func (s StorageProfile) IsBinary() bool {
+	return s == StorageBinary || s == StorageOrderedBinary
+}
+
+// Encode returns id in the profile's stored form: raw bytes for binary
+// profiles and UTF-8 text otherwise
+func (s StorageProfile) Encode(id ID) ([]byte, error) {
+	switch s {
+	case StorageText:
+		return []byte(id.String()), nil
+	case StorageBinary:
+		return id.Bytes(), nil
+	case StorageUUID:
+		return []byte(formatUUID(id)), nil
+	case StorageBase64:
+		return []byte(Base64URL.Encode(id)), nil
+	case StorageOrderedBinary:
+		return orderBytes(id), nil
+	default:
+		return nil, fmt.Errorf("unsupported storage profile: %s", s)
+	}
+}
+
+// Decode parses data written under the profile. Sixteen bytes are read as
+// this profile's binary layout; text in any supported form is accepted so
+// that rows written before a profile change still load.
+func (s StorageProfile) Decode(data []byte) (ID, error) {
+	var id ID
+	if len(data) == ulidSize {
+		if s == StorageOrderedBinary {
+			return unorderBytes(data), nil
+		}
+		copy(id[:], data)
+		return id, nil
+	}
+	err := id.UnmarshalText(data)
+	return id, err
+}
+
+// sqlServerOrder lists byte positions in the order SQL Server compares
+// UNIQUEIDENTIFIER values, most significant first
+var sqlServerOrder = [ulidSize]int{10, 11, 12, 13, 14, 15, 8, 9, 6, 7, 4, 5, 0, 1, 2, 3}
+
+// orderBytes places the ULID's most significant bytes where SQL Server
+// compares first, so the 48-bit timestamp lands in the last 6 bytes
+func orderBytes(id ID) []byte {
+	out := make([]byte, ulidSize)
+	for i, pos := range sqlServerOrder {
+		out[pos] = id[i]
+	}
+	return out
+}
+
+// unorderBytes reverses orderBytes
+func unorderBytes(data []byte) ID {
+	var id ID
+	for i, pos := range sqlServerOrder {
+		id[i] = data[pos]
+	}
+	return id
+}
+
+// storageProfile is the form in which IDs are written to databases and gob
+var storageProfile atomic.Int32
+
+// textProfileSetting is the form in which IDs are written to JSON and text
+var textProfileSetting atomic.Int32
+
+// SetStorageProfile selects the form in which ID values are written to
+// databases and gob. Set it once at startup to match the backend; decoding
+// accepts every text form regardless of the setting, but reads 16-byte
+// database values in the current layout. The setting is process-wide, so
+// libraries and columns with a layout of their own should use ProfiledID
+// instead. JSON and text follow SetTextProfile.
+func SetStorageProfile(p StorageProfile) {
+	storageProfile.Store(int32(p)) //nolint:gosec // G115: profiles are small constants
+}
+
+// CurrentStorageProfile returns the form in which ID values are written to
+// databases and gob
+func CurrentStorageProfile() StorageProfile {
+	return StorageProfile(storageProfile.Load())
+}
+
+// SetTextProfile selects the form in which ID values are written to JSON and
+// encoding.Text, such as StorageUUID for clients that expect UUIDs. Binary
+// profiles, which have no text form, select StorageText. Decoding accepts
+// every text form regardless of the setting. The setting is process-wide;
+// ProfiledID fixes the form for a single value.
+func SetTextProfile(p StorageProfile) {
+	textProfileSetting.Store(int32(p)) //nolint:gosec // G115: profiles are small constants
+}
+
+// CurrentTextProfile returns the form in which ID values are written to JSON
+// and encoding.Text
+func CurrentTextProfile() StorageProfile {
+	if p := StorageProfile(textProfileSetting.Load()); !p.IsBinary() {
+		return p
+	}
+	return StorageText
+}
+
+// ProfiledID is an ID that is written and read in its own StorageProfile
+// rather than the process-wide ones, so a library or a column with its own
+// layout is unaffected by SetStorageProfile and SetTextProfile. Scan into a
+// ProfiledID with Profile already set to read 16-byte values in that layout.
+// Its text form uses Profile too, or StorageText for binary profiles.
+type ProfiledID struct {
+	Profile StorageProfile
+	ID      ID
+}
+
+// Value implements driver.Valuer, writing the ID in p.Profile
+func (p ProfiledID) Value() (driver.Value, error) {
+	return storedValue(p.Profile, p.ID)
+}
+
+// Scan implements sql.Scanner, accepting 16 bytes in the p.Profile layout,
+// a ULID string, base64url, or UUID text
+func (p *ProfiledID) Scan(src any) error {
+	return p.ID.scan(p.Profile, src)
+}
+
+// GobEncode implements gob.GobEncoder, tagging binary forms with p.Profile
+func (p ProfiledID) GobEncode() ([]byte, error) {
+	return encodeGob(p.Profile, p.ID)
+}
+
+// GobDecode implements gob.GobDecoder, reading untagged 16-byte values in
+// the p.Profile layout
+func (p *ProfiledID) GobDecode(data []byte) error {
+	parsed, err := decodeGob(p.Profile, data)
+	if err != nil {
+		return err
+	}
+	p.ID = parsed
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler in p.Profile, or as a ULID
+// for binary profiles
+func (p ProfiledID) MarshalText() ([]byte, error) {
+	if p.Profile.IsBinary() {
+		return StorageText.Encode(p.ID)
+	}
+	return p.Profile.Encode(p.ID)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting every text form
+func (p *ProfiledID) UnmarshalText(text []byte) error {
+	return p.ID.UnmarshalText(text)
+}
@@ -0,0 +1,269 @@
+package id
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"iter"
+	"time"
+
+	"github.com/oklog/ulid"
+)
+
+// streamChunk is how many IDs an unlimited stream generates per lock acquisition
+const streamChunk = 64
+
+// streamConfig holds the settings applied by StreamOptions
+type streamConfig struct {
+	interval time.Duration
+	buffer   int
+}
+
+// StreamOption configures Stream and StreamIDs
+type StreamOption func(*streamConfig)
+
+// WithRate limits a stream to perSecond IDs per second. Non-positive rates,
+// and rates too high to express as a tick interval, are unlimited.
+func WithRate(perSecond float64) StreamOption {
+	return func(c *streamConfig) {
+		c.interval = 0
+		if perSecond > 0 {
+			c.interval = time.Duration(float64(time.Second) / perSecond)
+		}
+	}
+}
+
+// WithStreamBuffer sets the channel capacity of Stream; the default is 64.
+// It has no effect on StreamIDs.
+func WithStreamBuffer(n int) StreamOption {
+	return func(c *streamConfig) {
+		c.buffer = max(n, 0)
+	}
+}
+
+// newStreamConfig applies opts over the defaults
+func newStreamConfig(opts []StreamOption) streamConfig {
+	c := streamConfig{buffer: streamChunk}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// Stream returns a channel that delivers new IDs until ctx is canceled, then
+// closes. Unlimited streams generate IDs in chunks and encode each chunk into
+// a single string, so IDs are not allocated one by one; an ID's timestamp is
+// when its chunk was generated. Rate-limited streams generate each ID when it
+// is due. Consumers must drain the channel or cancel ctx to release the
+// producing goroutine. Generators with another ID scheme issue one ID at a
+// time in that scheme. If the entropy source fails the channel closes early;
+// TryStream reports why.
+func (g *generator) Stream(ctx context.Context, opts ...StreamOption) <-chan string {
+	ch, _ := g.TryStream(ctx, opts...)
+	return ch
+}
+
+// TryStream is Stream with an error accessor. Once the channel is closed,
+// the accessor returns the entropy failure that ended the stream, such as
+// ulid.ErrMonotonicOverflow, or nil if ctx ended it.
+func (g *generator) TryStream(ctx context.Context, opts ...StreamOption) (<-chan string, func() error) {
+	cfg := newStreamConfig(opts)
+	ch := make(chan string, cfg.buffer)
+	var streamErr error
+
+	go func() {
+		defer close(ch)
+		send := func(s string) bool {
+			select {
+			case ch <- s:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+		if g.scheme != nil {
+			streamErr = g.produceScheme(ctx, cfg.interval, send)
+			return
+		}
+
+		var text []byte
+		var encoded []string
+		streamErr = g.produce(ctx, cfg.interval, func(ids []ID) bool {
+			encoded = g.encodeChunk(ids, &text, encoded[:0])
+			for _, s := range encoded {
+				if !send(s) {
+					return false
+				}
+			}
+			return true
+		})
+	}()
+	// The close of ch orders the write of streamErr before any read made
+	// after the channel is drained
+	return ch, func() error { return streamErr }
+}
+
+// StreamIDs returns an iterator over new IDs that runs until ctx is canceled
+// or the loop exits. IDs are generated on the iterating goroutine into a
+// reused buffer, in chunks when the stream is not rate limited. If the
+// entropy source fails the iteration ends early; TryStreamIDs reports why.
+// IDs are ULIDs, so generators with another ID scheme yield nothing.
+func (g *generator) StreamIDs(ctx context.Context, opts ...StreamOption) iter.Seq[ID] {
+	return func(yield func(ID) bool) {
+		for id, err := range g.TryStreamIDs(ctx, opts...) {
+			if err != nil || !yield(id) {
+				return
+			}
+		}
+	}
+}
+
+// TryStreamIDs is StreamIDs yielding a final zero ID with the entropy
+// failure, such as ulid.ErrMonotonicOverflow, that ended the stream, or
+// ErrSchemeUnsupported for generators with another ID scheme
+func (g *generator) TryStreamIDs(ctx context.Context, opts ...StreamOption) iter.Seq2[ID, error] {
+	cfg := newStreamConfig(opts)
+	return func(yield func(ID, error) bool) {
+		if err := g.requireULID("StreamIDs"); err != nil {
+			yield(ID{}, err)
+			return
+		}
+		err := g.produce(ctx, cfg.interval, func(ids []ID) bool {
+			for _, id := range ids {
+				if ctx.Err() != nil || !yield(id, nil) {
+					return false
+				}
+			}
+			return true
+		})
+		if err != nil {
+			yield(ID{}, err)
+		}
+	}
+}
+
+// produce repeatedly fills a reused buffer with new IDs and passes it to emit
+// until ctx is canceled or emit returns false. With a positive interval one
+// ID is produced per tick, starting immediately. If generation fails, the
+// IDs filled before the failure are emitted and the error is returned.
+func (g *generator) produce(ctx context.Context, interval time.Duration, emit func([]ID) bool) error {
+	var buf [streamChunk]ID
+
+	if interval <= 0 {
+		for ctx.Err() == nil {
+			n, err := g.fillIDs(buf[:])
+			if !emit(buf[:n]) || err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for ctx.Err() == nil {
+		n, err := g.fillIDs(buf[:1])
+		if !emit(buf[:n]) || err != nil {
+			return err
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+	return nil
+}
+
+// produceScheme passes new IDs in the generator's scheme to emit one at a
+// time, as produce does for ULIDs
+func (g *generator) produceScheme(ctx context.Context, interval time.Duration, emit func(string) bool) error {
+	var tick <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for ctx.Err() == nil {
+		id, err := g.schemeTryNew(g.now())
+		if err != nil {
+			return err
+		}
+		if !emit(id) {
+			return nil
+		}
+		if tick == nil {
+			continue
+		}
+		select {
+		case <-tick:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+	return nil
+}
+
+// monotonicReader is implemented by ulid.Monotonic entropy
+type monotonicReader interface {
+	MonotonicRead(ms uint64, entropy []byte) error
+}
+
+// fillIDs generates IDs into buf under a single lock acquisition, returning
+// how many it filled and the first time or entropy failure. Entropy is read
+// straight into buf, which unlike ulid.New does not allocate.
+func (g *generator) fillIDs(buf []ID) (int, error) {
+	g.lock()
+	defer g.unlock()
+
+	monotonic, _ := g.entropySource.(monotonicReader)
+	for i := range buf {
+		ms := ulid.Timestamp(g.now())
+		u := (*ulid.ULID)(&buf[i])
+		if err := u.SetTime(ms); err != nil {
+			return i, fmt.Errorf("%w: %w", ErrTimeOutOfRange, err)
+		}
+
+		var err error
+		if monotonic != nil {
+			err = monotonic.MonotonicRead(ms, buf[i][6:])
+		} else {
+			_, err = io.ReadFull(g.entropySource, buf[i][6:])
+		}
+		if err != nil {
+			return i, fmt.Errorf("reading entropy: %w", err)
+		}
+		if err := g.stamp(u); err != nil {
+			return i, fmt.Errorf("reading entropy: %w", err)
+		}
+		g.counters.record(ms)
+	}
+	return len(buf), nil
+}
+
+// encodeChunk appends ids, rendered in the generator's format, to dst.
+// Canonical IDs are encoded into text, which is reused across calls, and
+// sliced out of one string, so the whole chunk costs a single allocation.
+func (g *generator) encodeChunk(ids []ID, text *[]byte, dst []string) []string {
+	if !g.format.Canonical() {
+		for _, id := range ids {
+			dst = append(dst, g.encode(ulid.ULID(id)))
+		}
+		return dst
+	}
+
+	n := len(ids) * ulid.EncodedSize
+	if cap(*text) < n {
+		*text = make([]byte, n)
+	}
+	buf := (*text)[:n]
+	for i, id := range ids {
+		_ = ulid.ULID(id).MarshalTextTo(buf[i*ulid.EncodedSize : (i+1)*ulid.EncodedSize])
+	}
+	all := string(buf)
+	for i := range ids {
+		dst = append(dst, all[i*ulid.EncodedSize:(i+1)*ulid.EncodedSize])
+	}
+	return dst
+}
@@ -0,0 +1,202 @@
+package id_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/oklog/ulid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Stream(t *testing.T) {
+	gen := id.NewGenerator()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Act
+	stream := gen.Stream(ctx)
+	ids := make([]string, 0, 200)
+	for len(ids) < 200 {
+		ids = append(ids, <-stream)
+	}
+	cancel()
+	for range stream {
+	}
+
+	// Assert
+	for i, s := range ids {
+		assert.True(t, gen.IsIdValid(s), s)
+		if i > 0 {
+			assert.Less(t, ids[i-1], s)
+		}
+	}
+}
+
+func Test_Stream_Format(t *testing.T) {
+	gen := id.NewLowercaseGenerator()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Act
+	s := <-gen.Stream(ctx, id.WithStreamBuffer(0))
+
+	// Assert
+	assert.Equal(t, strings.ToLower(s), s)
+	assert.True(t, gen.IsIdValid(s))
+}
+
+func Test_Stream_ClosesOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := id.NewGenerator().Stream(ctx)
+
+	// Act
+	cancel()
+
+	// Assert
+	assert.Eventually(t, func() bool {
+		select {
+		case _, ok := <-stream:
+			return !ok
+		default:
+			return false
+		}
+	}, time.Second, time.Millisecond)
+}
+
+func Test_Stream_Rate(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := id.NewGenerator().Stream(ctx, id.WithRate(100))
+	start := time.Now()
+
+	// Act
+	for i := 0; i < 4; i++ {
+		<-stream
+	}
+
+	// Assert
+	assert.GreaterOrEqual(t, time.Since(start), 25*time.Millisecond)
+}
+
+func Test_StreamIDs(t *testing.T) {
+	gen := id.NewGenerator()
+	var ids []id.ID
+
+	// Act
+	for v := range gen.StreamIDs(context.Background()) {
+		ids = append(ids, v)
+		if len(ids) == 100 {
+			break
+		}
+	}
+
+	// Assert
+	require.Len(t, ids, 100)
+	for i := 1; i < len(ids); i++ {
+		assert.Equal(t, -1, ids[i-1].Compare(ids[i]))
+	}
+}
+
+func Test_StreamIDs_StopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	count := 0
+
+	// Act
+	for range id.NewGenerator().StreamIDs(ctx, id.WithRate(1000)) {
+		count++
+		if count == 3 {
+			cancel()
+		}
+	}
+
+	// Assert
+	assert.Equal(t, 3, count)
+}
+
+func Test_StreamIDs_Secure(t *testing.T) {
+	gen := id.NewSecureGenerator()
+	seen := map[id.ID]bool{}
+
+	// Act
+	for v := range gen.StreamIDs(context.Background()) {
+		seen[v] = true
+		if len(seen) == 100 {
+			break
+		}
+	}
+
+	// Assert
+	for v := range seen {
+		assert.WithinDuration(t, time.Now(), v.Timestamp(), time.Minute)
+	}
+}
+
+func Test_TryStream_ReportsEntropyFailure(t *testing.T) {
+	gen := id.NewGeneratorWithEntropy(failingReader{})
+
+	// Act
+	stream, errFn := gen.TryStream(context.Background())
+	var received []string
+	for s := range stream {
+		received = append(received, s)
+	}
+
+	// Assert
+	assert.Empty(t, received)
+	assert.ErrorContains(t, errFn(), "reading entropy")
+}
+
+func Test_TryStream_NilErrorOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, errFn := id.NewGenerator().TryStream(ctx)
+	<-stream
+
+	// Act
+	cancel()
+	for range stream {
+	}
+
+	// Assert
+	assert.NoError(t, errFn())
+}
+
+func Test_Stream_ClosesOnMonotonicOverflow(t *testing.T) {
+	// All-ones entropy cannot be incremented within the same millisecond
+	clock := id.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	gen := id.NewGenerator(id.WithEntropy(ulid.Monotonic(constReader(0xFF), 1)), id.WithClock(clock))
+
+	// Act
+	stream, errFn := gen.TryStream(context.Background())
+	var received []string
+	for s := range stream {
+		received = append(received, s)
+	}
+
+	// Assert
+	assert.Len(t, received, 1)
+	assert.ErrorIs(t, errFn(), ulid.ErrMonotonicOverflow)
+}
+
+func Test_TryStreamIDs_YieldsEntropyFailure(t *testing.T) {
+	gen := id.NewGeneratorWithEntropy(failingReader{})
+
+	// Act
+	var errs []error
+	for _, err := range gen.TryStreamIDs(context.Background()) {
+		errs = append(errs, err)
+	}
+	count := 0
+	for range gen.StreamIDs(context.Background()) {
+		count++
+	}
+
+	// Assert
+	require.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], "reading entropy")
+	assert.Zero(t, count)
+}
@@ -0,0 +1,54 @@
+package id
+
+import (
+	"fmt"
+	"text/template"
+	"time"
+)
+
+// DefaultShortLength is the number of characters ulidShort keeps by default
+const DefaultShortLength = 8
+
+// FuncMap returns template functions backed by a default generator:
+//
+//	ulid                 a new ULID
+//	ulidAt TIME          a ULID for a time.Time or an RFC 3339 string
+//	ulidTime ID          the timestamp embedded in a ULID
+//	ulidShort ID [N]     the first N characters of an ID (default 8), for display only
+//
+// The map works with both text/template and html/template.
+func FuncMap() template.FuncMap {
+	return FuncMapFor(NewGenerator())
+}
+
+// FuncMapFor returns the FuncMap template functions backed by gen
+func FuncMapFor(gen *generator) template.FuncMap {
+	return template.FuncMap{
+		"ulid": gen.Generate,
+		"ulidAt": func(at any) (string, error) {
+			switch v := at.(type) {
+			case time.Time:
+				return gen.GenerateWithTime(v), nil
+			case string:
+				t, err := time.Parse(time.RFC3339Nano, v)
+				if err != nil {
+					return "", err
+				}
+				return gen.GenerateWithTime(t), nil
+			default:
+				return "", fmt.Errorf("ulidAt: unsupported time type %T", at)
+			}
+		},
+		"ulidTime": gen.ExtractTimestamp,
+		"ulidShort": func(id string, n ...int) string {
+			length := DefaultShortLength
+			if len(n) > 0 && n[0] > 0 {
+				length = n[0]
+			}
+			if length >= len(id) {
+				return id
+			}
+			return id[:length]
+		},
+	}
+}
@@ -0,0 +1,52 @@
+package id_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func render(t *testing.T, text string, data any) (string, error) {
+	t.Helper()
+	tmpl, err := template.New("test").Funcs(id.FuncMap()).Parse(text)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, data)
+	return buf.String(), err
+}
+
+func Test_FuncMap(t *testing.T) {
+	gen := id.NewGenerator()
+	ulid := gen.GenerateWithTime(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+
+	// Act
+	minted, err := render(t, `{{ ulid }}`, nil)
+	require.NoError(t, err)
+	at, err := render(t, `{{ ulidAt "2024-01-01T12:00:00Z" | ulidTime }}`, nil)
+	require.NoError(t, err)
+	atTime, err := render(t, `{{ (ulidTime (ulidAt .)).UTC.Year }}`, time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	short, err := render(t, `{{ ulidShort . }} {{ ulidShort . 4 }} {{ ulidShort . 99 }}`, ulid)
+	require.NoError(t, err)
+
+	// Assert
+	assert.True(t, gen.IsIdValid(minted))
+	assert.Contains(t, at, "2024-01-01")
+	assert.Equal(t, "2030", atTime)
+	assert.Equal(t, strings.Join([]string{ulid[:8], ulid[:4], ulid}, " "), short)
+}
+
+func Test_FuncMap_Errors(t *testing.T) {
+	_, err := render(t, `{{ ulidTime "invalid" }}`, nil)
+	assert.Error(t, err)
+	_, err = render(t, `{{ ulidAt "yesterday" }}`, nil)
+	assert.Error(t, err)
+	_, err = render(t, `{{ ulidAt 42 }}`, nil)
+	assert.Error(t, err)
+}
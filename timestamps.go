@@ -0,0 +1,30 @@
+package id
+
+import (
+	"time"
+)
+
+// ExtractTimestamps returns the timestamp of every ID in one pass. Both
+// results are aligned with ids: invalid IDs get a zero time and a non-nil
+// error, valid IDs a nil error.
+func ExtractTimestamps(ids []string) ([]time.Time, []error) {
+	return timestampsOf(ParseBatch(ids))
+}
+
+// ExtractTimestampsParallel is ExtractTimestamps split across workers
+// goroutines, or GOMAXPROCS when workers is not positive. Small inputs are
+// processed on the calling goroutine.
+func ExtractTimestampsParallel(ids []string, workers int) ([]time.Time, []error) {
+	return timestampsOf(ParseBatch(ids, WithWorkers(workers)))
+}
+
+// timestampsOf converts the output of ParseBatch to timestamps
+func timestampsOf(parsed []ID, errs []error) ([]time.Time, []error) {
+	times := make([]time.Time, len(parsed))
+	for i, u := range parsed {
+		if errs[i] == nil {
+			times[i] = u.Timestamp()
+		}
+	}
+	return times, errs
+}
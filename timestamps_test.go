@@ -0,0 +1,70 @@
+package id_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ExtractTimestamps(t *testing.T) {
+	gen := id.NewGenerator()
+	ts := time.Date(2024, 5, 1, 8, 30, 0, 0, time.UTC)
+	ids := []string{gen.GenerateWithTime(ts), "invalid", gen.GenerateWithTime(ts.Add(time.Second))}
+
+	// Act
+	times, errs := id.ExtractTimestamps(ids)
+
+	// Assert
+	require.Len(t, times, 3)
+	require.Len(t, errs, 3)
+	assert.NoError(t, errs[0])
+	assert.Error(t, errs[1])
+	assert.NoError(t, errs[2])
+	assert.True(t, times[0].Equal(ts))
+	assert.True(t, times[1].IsZero())
+	assert.True(t, times[2].Equal(ts.Add(time.Second)))
+}
+
+func Test_ExtractTimestampsParallel_MatchesSerial(t *testing.T) {
+	gen := id.NewGenerator()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ids := gen.GenerateRange(start, start.Add(time.Hour), 10000)
+	ids[5000] = "bogus"
+
+	for _, workers := range []int{0, 1, 3, 64} {
+		// Act
+		times, errs := id.ExtractTimestampsParallel(ids, workers)
+		wantTimes, wantErrs := id.ExtractTimestamps(ids)
+
+		// Assert
+		assert.Equal(t, wantTimes, times, "workers=%d", workers)
+		assert.Equal(t, wantErrs, errs, "workers=%d", workers)
+	}
+}
+
+func Test_ExtractTimestamps_Empty(t *testing.T) {
+	times, errs := id.ExtractTimestampsParallel(nil, 4)
+	assert.Empty(t, times)
+	assert.Empty(t, errs)
+}
+
+func Test_AnalyzeIDs_Unsorted(t *testing.T) {
+	gen := id.NewGenerator()
+	ts := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	a := gen.GenerateWithTime(ts)
+	b := gen.GenerateWithTime(ts.Add(time.Minute))
+	c := gen.GenerateWithTime(ts.Add(2 * time.Minute))
+
+	// Act
+	stats, err := id.AnalyzeIDs([]string{b, c, "bad", a})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 3, stats.Count)
+	assert.Equal(t, a, stats.FirstID)
+	assert.Equal(t, c, stats.LastID)
+	assert.Equal(t, 2*time.Minute, stats.TimeSpan)
+}
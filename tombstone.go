@@ -0,0 +1,37 @@
+package id
+
+import (
+	"strings"
+
+	"github.com/oklog/ulid"
+)
+
+// TombstoneSuffix marks a tombstone. It lies outside the ULID alphabet and
+// the Crockford check symbols, so no live or checksummed ID ends with it.
+const TombstoneSuffix = "!"
+
+// Tombstone returns the deletion marker for id: the ID as given followed by
+// TombstoneSuffix. A ULID is a prefix of its tombstone and every other ULID
+// differs from it within the first 26 characters, so the tombstone sorts
+// immediately after the original and log-structured stores can record
+// deletions in the key itself. Tombstones are returned unchanged, and invalid
+// IDs yield "".
+func Tombstone(id string) string {
+	if IsTombstone(id) {
+		return id
+	}
+	if _, err := parseCanonical(id); err != nil {
+		return ""
+	}
+	return id + TombstoneSuffix
+}
+
+// IsTombstone reports whether id is a tombstone of a valid ULID
+func IsTombstone(id string) bool {
+	original, ok := strings.CutSuffix(id, TombstoneSuffix)
+	if !ok || len(original) != ulid.EncodedSize {
+		return false
+	}
+	_, err := parseCanonical(original)
+	return err == nil
+}
@@ -0,0 +1,43 @@
+package id_test
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Tombstone(t *testing.T) {
+	ulid := id.NewGenerator().Generate()
+
+	// Act
+	tomb := id.Tombstone(ulid)
+
+	// Assert
+	assert.Equal(t, ulid+id.TombstoneSuffix, tomb)
+	assert.True(t, id.IsTombstone(tomb))
+	assert.False(t, id.IsTombstone(ulid))
+	assert.Equal(t, tomb, id.Tombstone(tomb))
+	assert.Empty(t, id.Tombstone("invalid"))
+	assert.False(t, id.IsTombstone("invalid"+id.TombstoneSuffix))
+}
+
+func Test_Tombstone_SortsAfterOriginal(t *testing.T) {
+	ids := id.NewGenerator().GenerateBatch(50)
+	keys := append([]string(nil), ids...)
+	for _, ulid := range ids {
+		keys = append(keys, id.Tombstone(ulid))
+	}
+
+	// Act
+	sort.Strings(keys)
+
+	// Assert
+	for i, key := range keys {
+		if id.IsTombstone(key) {
+			assert.Equal(t, strings.TrimSuffix(key, id.TombstoneSuffix), keys[i-1])
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package id
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/oklog/ulid"
+)
+
+const (
+	// MinTruncatedLength is the shortest truncated ID: the 10-character timestamp plus one random character
+	MinTruncatedLength = timestampChars + 1
+	// MaxTruncatedLength is the longest truncated ID, equal to a full ULID
+	MaxTruncatedLength = ulid.EncodedSize
+)
+
+// TruncatedGenerator produces shortened time-sortable IDs: the 10-character
+// ULID timestamp followed by fewer random characters, each carrying 5 bits.
+// A truncated ID is exactly the prefix of a ULID, so it sorts the same way.
+// Fewer random bits means a far higher chance of collision within one
+// millisecond; use CollisionProbability to size the length for your load.
+type TruncatedGenerator struct {
+	length  int
+	mu      sync.Mutex
+	entropy io.Reader
+}
+
+// NewTruncatedGenerator creates a generator of length-character IDs using crypto/rand
+func NewTruncatedGenerator(length int) (*TruncatedGenerator, error) {
+	return NewTruncatedGeneratorWithEntropy(length, rand.Reader)
+}
+
+// NewTruncatedGeneratorWithEntropy creates a generator of length-character IDs
+// using a custom entropy source. Monotonic sources such as the default ULID
+// entropy must not be used: they increment the low bits that truncation drops.
+func NewTruncatedGeneratorWithEntropy(length int, entropy io.Reader) (*TruncatedGenerator, error) {
+	if length < MinTruncatedLength || length > MaxTruncatedLength {
+		return nil, fmt.Errorf("truncated ID length must be between %d and %d, got %d",
+			MinTruncatedLength, MaxTruncatedLength, length)
+	}
+	return &TruncatedGenerator{
+		length:  length,
+		entropy: entropy,
+	}, nil
+}
+
+// Length returns the number of characters in each ID
+func (g *TruncatedGenerator) Length() int {
+	return g.length
+}
+
+// EntropyBits returns the number of random bits in each ID
+func (g *TruncatedGenerator) EntropyBits() int {
+	return (g.length - timestampChars) * 5
+}
+
+// Generate provides a new truncated ID for the current time
+func (g *TruncatedGenerator) Generate() string {
+	return g.GenerateWithTime(time.Now())
+}
+
+// GenerateWithTime generates a truncated ID with a specific timestamp
+func (g *TruncatedGenerator) GenerateWithTime(t time.Time) string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	u := ulid.MustNew(ulid.Timestamp(t), g.entropy)
+	return u.String()[:g.length]
+}
+
+// IsIdValid validates that s is a truncated ID of this generator's length
+func (g *TruncatedGenerator) IsIdValid(s string) bool {
+	_, err := g.parse(s)
+	return err == nil
+}
+
+// ExtractTimestamp returns the timestamp component of a truncated ID
+func (g *TruncatedGenerator) ExtractTimestamp(id string) (time.Time, error) {
+	parsed, err := g.parse(id)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return ulid.Time(parsed.Time()), nil
+}
+
+// CollisionProbability returns the chance that at least two of perMillisecond
+// IDs generated within the same millisecond collide (birthday bound)
+func (g *TruncatedGenerator) CollisionProbability(perMillisecond int) float64 {
+	return CollisionProbability(g.EntropyBits(), perMillisecond)
+}
+
+// parse pads a truncated ID back to a full ULID
+func (g *TruncatedGenerator) parse(id string) (ulid.ULID, error) {
+	if len(id) != g.length {
+		return ulid.ULID{}, fmt.Errorf("invalid truncated ID: length is %d, want %d", len(id), g.length)
+	}
+	parsed, err := ulid.ParseStrict(strings.ToUpper(id) + strings.Repeat("0", ulid.EncodedSize-g.length))
+	if err != nil {
+		return ulid.ULID{}, fmt.Errorf("invalid truncated ID: %w", err)
+	}
+	return parsed, nil
+}
+
+// CollisionProbability returns the birthday-bound chance that at least two of
+// count values drawn uniformly from 2^bits possibilities collide
+func CollisionProbability(bits, count int) float64 {
+	return collisionProbability(float64(bits), count)
+}
+
+// collisionProbability is CollisionProbability for a space whose size is not
+// a power of two, such as the IDs of a ShortIDGenerator
+func collisionProbability(bits float64, count int) float64 {
+	if count < 2 {
+		return 0
+	}
+	k := float64(count)
+	return -math.Expm1(-k * (k - 1) / (2 * math.Exp2(bits)))
+}
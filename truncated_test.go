@@ -0,0 +1,70 @@
+package id_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TruncatedGenerator(t *testing.T) {
+	gen, err := id.NewTruncatedGenerator(16)
+	require.NoError(t, err)
+	testTime := time.Date(2023, 6, 15, 14, 30, 45, 123000000, time.UTC)
+
+	// Act
+	short := gen.GenerateWithTime(testTime)
+
+	// Assert
+	assert.Len(t, short, 16)
+	assert.Equal(t, 16, gen.Length())
+	assert.Equal(t, 30, gen.EntropyBits())
+	assert.True(t, gen.IsIdValid(short))
+	extracted, err := gen.ExtractTimestamp(short)
+	require.NoError(t, err)
+	assert.True(t, testTime.Equal(extracted))
+
+	// Shares its prefix ordering with full ULIDs
+	full := id.NewGenerator().GenerateWithTime(testTime)
+	assert.Equal(t, full[:10], short[:10])
+
+	assert.False(t, gen.IsIdValid(full))
+	assert.False(t, gen.IsIdValid("!!!!!!!!!!!!!!!!"))
+	_, err = gen.ExtractTimestamp("short")
+	assert.Error(t, err)
+}
+
+func Test_TruncatedGenerator_Sortable(t *testing.T) {
+	gen, err := id.NewTruncatedGenerator(20)
+	require.NoError(t, err)
+	base := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	earlier := gen.GenerateWithTime(base)
+	later := gen.GenerateWithTime(base.Add(time.Millisecond))
+
+	// Assert
+	assert.Less(t, earlier, later)
+}
+
+func Test_NewTruncatedGenerator_Bounds(t *testing.T) {
+	_, err := id.NewTruncatedGenerator(10)
+	assert.Error(t, err)
+	_, err = id.NewTruncatedGenerator(27)
+	assert.Error(t, err)
+	_, err = id.NewTruncatedGenerator(id.MaxTruncatedLength)
+	assert.NoError(t, err)
+}
+
+func Test_CollisionProbability(t *testing.T) {
+	// Act & Assert
+	assert.Zero(t, id.CollisionProbability(30, 1))
+	assert.InDelta(t, 0.5, id.CollisionProbability(32, 77163), 0.001) // Classic 32-bit birthday bound
+	assert.Less(t, id.CollisionProbability(80, 1_000_000), 1e-12)
+
+	gen, err := id.NewTruncatedGenerator(16)
+	require.NoError(t, err)
+	assert.Greater(t, gen.CollisionProbability(1000), id.CollisionProbability(50, 1000))
+}
@@ -0,0 +1,58 @@
+package id
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/oklog/ulid"
+)
+
+// ErrTimeOutOfRange is returned when a ULID is requested for a time before
+// the Unix epoch or after the largest 48-bit millisecond timestamp
+var ErrTimeOutOfRange = errors.New("time outside the ULID range")
+
+// maxULIDTime is the latest time a ULID can encode
+var maxULIDTime = ulid.Time(ulid.MaxTime())
+
+// checkULIDTime reports whether t can be encoded in a ULID timestamp
+func checkULIDTime(t time.Time) error {
+	if t.Before(time.Unix(0, 0)) || t.After(maxULIDTime) {
+		return fmt.Errorf("%w: %s", ErrTimeOutOfRange, t.UTC().Format(time.RFC3339Nano))
+	}
+	return nil
+}
+
+// TryGenerate provides a new ID like Generate, but returns an entropy
+// failure instead of panicking
+func (g *generator) TryGenerate() (string, error) {
+	return g.tryGenerateWithTime(g.now())
+}
+
+// TryGenerateWithTime generates an ID for t like GenerateWithTime, but
+// returns ErrTimeOutOfRange or an entropy failure instead of panicking
+func (g *generator) TryGenerateWithTime(t time.Time) (string, error) {
+	return g.tryGenerateWithTime(t)
+}
+
+// TryGenerateBatch creates count IDs like GenerateBatch, stopping at the
+// first entropy failure. It returns no IDs on failure.
+func (g *generator) TryGenerateBatch(count int) ([]string, error) {
+	return g.GenerateBatchContext(context.Background(), count)
+}
+
+// TryGenerateRange creates count IDs spread across a time range like
+// GenerateRange, but returns ErrTimeOutOfRange if the range leaves the ULID
+// range, or the first entropy failure. It returns no IDs on failure.
+func (g *generator) TryGenerateRange(start, end time.Time, count int) ([]string, error) {
+	if g.scheme == nil && count > 0 && !end.Before(start) {
+		if err := checkULIDTime(start); err != nil {
+			return nil, err
+		}
+		if err := checkULIDTime(end); err != nil {
+			return nil, err
+		}
+	}
+	return g.GenerateRangeContext(context.Background(), start, end, count)
+}
@@ -0,0 +1,70 @@
+package id_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TryGenerate(t *testing.T) {
+	gen := id.NewGenerator()
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	generated, err := gen.TryGenerate()
+	require.NoError(t, err)
+	withTime, err := gen.TryGenerateWithTime(at)
+	require.NoError(t, err)
+	batch, err := gen.TryGenerateBatch(3)
+	require.NoError(t, err)
+	spread, err := gen.TryGenerateRange(at, at.Add(time.Hour), 4)
+	require.NoError(t, err)
+
+	// Assert
+	assert.True(t, gen.IsIdValid(generated))
+	ts, err := gen.ExtractTimestamp(withTime)
+	require.NoError(t, err)
+	assert.True(t, at.Equal(ts))
+	assert.Len(t, batch, 3)
+	assert.Len(t, spread, 4)
+}
+
+func Test_TryGenerate_EntropyFailure(t *testing.T) {
+	gen := id.NewGeneratorWithEntropy(failingReader{})
+
+	// Act
+	_, errGenerate := gen.TryGenerate()
+	batch, errBatch := gen.TryGenerateBatch(3)
+	spread, errRange := gen.TryGenerateRange(time.Now(), time.Now().Add(time.Hour), 3)
+
+	// Assert
+	assert.Error(t, errGenerate)
+	assert.Error(t, errBatch)
+	assert.Nil(t, batch)
+	assert.Error(t, errRange)
+	assert.Nil(t, spread)
+	assert.Panics(t, func() { gen.Generate() })
+}
+
+func Test_TryGenerate_TimeOutOfRange(t *testing.T) {
+	gen := id.NewGenerator()
+	before := time.Date(1969, 12, 31, 23, 59, 59, 0, time.UTC)
+	after := time.UnixMilli(1 << 48)
+
+	// Act
+	_, errBefore := gen.TryGenerateWithTime(before)
+	_, errAfter := gen.TryGenerateWithTime(after)
+	_, errRange := gen.TryGenerateRange(before, time.Now(), 3)
+	latest, errLatest := gen.TryGenerateWithTime(time.UnixMilli(1<<48 - 1))
+
+	// Assert
+	assert.ErrorIs(t, errBefore, id.ErrTimeOutOfRange)
+	assert.ErrorIs(t, errAfter, id.ErrTimeOutOfRange)
+	assert.ErrorIs(t, errRange, id.ErrTimeOutOfRange)
+	require.NoError(t, errLatest)
+	assert.Equal(t, "7ZZZZZZZZZ", latest[:10])
+	assert.Panics(t, func() { gen.GenerateWithTime(before) })
+}
@@ -0,0 +1,179 @@
+package id
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/oklog/ulid"
+)
+
+const (
+	// DefaultDedupWindow is the window NewUniqueGenerator uses for non-positive windows
+	DefaultDedupWindow = time.Minute
+	// DefaultDedupCapacity is how many IDs a UniqueGenerator tracks per
+	// generation before starting a new one early
+	DefaultDedupCapacity = 1 << 20
+
+	// maxUniqueAttempts bounds regeneration after a collision, which only
+	// repeats with an entropy source returning the same bytes
+	maxUniqueAttempts = 100
+)
+
+// ErrDuplicateID is raised when a UniqueGenerator cannot draw an unseen ID
+var ErrDuplicateID = errors.New("generated ID was already issued")
+
+// UniqueGenerator guards a generator against issuing the same ID twice. It
+// remembers recently issued IDs and regenerates on the extremely rare
+// collision, counting each one. Memory stays bounded: IDs are kept in two
+// generations, and a new generation starts once an issued ID is window past
+// the start of the current one or the current one holds
+// DefaultDedupCapacity IDs, so every ID is remembered for at least one
+// window of ID time unless issuance outpaces the capacity. All other methods
+// are served directly by the underlying generator. It is safe for
+// concurrent use.
+type UniqueGenerator struct {
+	*generator
+	windowMs uint64
+
+	mu       sync.Mutex
+	current  map[ID]struct{}
+	previous map[ID]struct{}
+	// startMs is the timestamp of the first ID in current
+	startMs uint64
+
+	collisions atomic.Uint64
+}
+
+// NewUniqueGenerator creates a deduplicating generator remembering IDs for window
+func NewUniqueGenerator(window time.Duration) *UniqueGenerator {
+	return NewUniqueGeneratorFrom(NewGenerator(), window)
+}
+
+// NewUniqueGeneratorFrom deduplicates IDs produced by an existing generator.
+// It panics if base issues another ID scheme, since only ULIDs are tracked.
+func NewUniqueGeneratorFrom(base *generator, window time.Duration) *UniqueGenerator {
+	if err := base.requireULID("unique IDs"); err != nil {
+		panic("id: " + err.Error())
+	}
+	if window <= 0 {
+		window = DefaultDedupWindow
+	}
+	return &UniqueGenerator{
+		generator: base,
+		windowMs:  uint64(max(window.Milliseconds(), 1)), //nolint:gosec // G115: positive
+		current:   make(map[ID]struct{}),
+	}
+}
+
+// Generate provides a new ULID not issued within the window
+func (u *UniqueGenerator) Generate() string {
+	return u.GenerateWithTime(u.now())
+}
+
+// GenerateWithTime generates a ULID with a specific timestamp, not issued
+// within the window
+func (u *UniqueGenerator) GenerateWithTime(t time.Time) string {
+	return u.encode(u.unique(func() ulid.ULID { return u.newULID(t) }))
+}
+
+// GenerateBatch creates multiple unique ULIDs
+func (u *UniqueGenerator) GenerateBatch(count int) []string {
+	if count <= 0 {
+		return []string{}
+	}
+
+	result := make([]string, count)
+	for i := range result {
+		result[i] = u.Generate()
+	}
+	return result
+}
+
+// GenerateRange creates unique ULIDs within a time range, regenerating any
+// collision with the same timestamp
+func (u *UniqueGenerator) GenerateRange(start, end time.Time, count int) []string {
+	result := u.generator.GenerateRange(start, end, count)
+	for i, id := range result {
+		parsed, _ := u.parse(id) // Generated by u, so always parseable
+		first := true
+		result[i] = u.encode(u.unique(func() ulid.ULID {
+			if first {
+				first = false
+				return parsed
+			}
+			return u.newULID(ulid.Time(parsed.Time()))
+		}))
+	}
+	return result
+}
+
+// Collisions returns how many generated IDs were discarded as already issued
+func (u *UniqueGenerator) Collisions() uint64 {
+	return u.collisions.Load()
+}
+
+// Tracked returns how many recently issued IDs the generator remembers
+func (u *UniqueGenerator) Tracked() int {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return len(u.current) + len(u.previous)
+}
+
+// unique draws IDs from next until one has not been issued, recording it.
+// It panics with ErrDuplicateID if the entropy source keeps repeating.
+func (u *UniqueGenerator) unique(next func() ulid.ULID) ulid.ULID {
+	for range maxUniqueAttempts {
+		candidate := next()
+		if u.admit(ID(candidate)) {
+			return candidate
+		}
+		u.collisions.Add(1)
+	}
+	panic(fmt.Errorf("%w: %d attempts collided", ErrDuplicateID, maxUniqueAttempts))
+}
+
+// admit records id and reports whether it was unseen
+func (u *UniqueGenerator) admit(id ID) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if _, seen := u.current[id]; seen {
+		return false
+	}
+	if _, seen := u.previous[id]; seen {
+		return false
+	}
+
+	ms := id.Time()
+	if len(u.current) == 0 {
+		u.startMs = ms
+	} else if ms >= u.startMs+u.windowMs || len(u.current) >= DefaultDedupCapacity {
+		u.previous, u.current = u.current, make(map[ID]struct{}, len(u.current))
+		u.startMs = ms
+	}
+	u.current[id] = struct{}{}
+	return true
+}
+
+// CheckDuplicates returns every entry of ids that repeats an earlier valid ID,
+// ignoring case, as given and in input order, so the repeats can be dropped
+// from a bulk import. FindDuplicates instead lists each duplicated ID once.
+func CheckDuplicates(ids []string) []string {
+	parsed, errs := ParseBatch(ids)
+	seen := make(map[ID]struct{}, len(ids))
+	result := []string{}
+	for i, err := range errs {
+		if err != nil {
+			continue
+		}
+		if _, ok := seen[parsed[i]]; ok {
+			result = append(result, ids[i])
+			continue
+		}
+		seen[parsed[i]] = struct{}{}
+	}
+	return result
+}
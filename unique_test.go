@@ -0,0 +1,91 @@
+package id_test
+
+import (
+	"crypto/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// repeatingReader returns zeros for its first reads, then random bytes
+type repeatingReader struct {
+	zeros int
+}
+
+func (r *repeatingReader) Read(p []byte) (int, error) {
+	if r.zeros > 0 {
+		r.zeros--
+		clear(p)
+		return len(p), nil
+	}
+	return rand.Read(p)
+}
+
+func Test_UniqueGenerator_RegeneratesCollisions(t *testing.T) {
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	base := id.NewGenerator(id.WithEntropy(&repeatingReader{zeros: 3}), id.WithClock(id.NewFakeClock(at)))
+	gen := id.NewUniqueGeneratorFrom(base, time.Minute)
+
+	// Act
+	ids := gen.GenerateBatch(3)
+
+	// Assert
+	assert.Len(t, ids, 3)
+	assert.Empty(t, id.CheckDuplicates(ids))
+	assert.Equal(t, uint64(2), gen.Collisions())
+	assert.Equal(t, 3, gen.Tracked())
+}
+
+func Test_UniqueGenerator_GenerateRange(t *testing.T) {
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	gen := id.NewUniqueGeneratorFrom(id.NewGeneratorWithEntropy(&repeatingReader{zeros: 2}), 0)
+
+	// Act
+	ids := gen.GenerateRange(at, at, 2)
+
+	// Assert
+	assert.Len(t, ids, 2)
+	assert.Empty(t, id.CheckDuplicates(ids))
+	assert.Equal(t, uint64(1), gen.Collisions())
+}
+
+func Test_UniqueGenerator_ForgetsOldGenerations(t *testing.T) {
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	gen := id.NewUniqueGenerator(time.Second)
+
+	// Act
+	gen.GenerateWithTime(at)
+	gen.GenerateWithTime(at.Add(time.Second))     // starts a second generation
+	gen.GenerateWithTime(at.Add(2 * time.Second)) // drops the first
+
+	// Assert
+	assert.Equal(t, 2, gen.Tracked())
+	assert.Zero(t, gen.Collisions())
+}
+
+func Test_UniqueGenerator_StuckEntropyPanics(t *testing.T) {
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	gen := id.NewUniqueGeneratorFrom(id.NewGeneratorWithEntropy(zeroReader{}), time.Minute)
+	gen.GenerateWithTime(at)
+
+	// Act & Assert
+	assert.PanicsWithError(t, "generated ID was already issued: 100 attempts collided", func() {
+		gen.GenerateWithTime(at)
+	})
+}
+
+func Test_CheckDuplicates(t *testing.T) {
+	gen := id.NewGenerator()
+	a, b := gen.Generate(), gen.Generate()
+
+	// Act
+	dups := id.CheckDuplicates([]string{a, b, strings.ToLower(a), "invalid", "invalid", a, b})
+
+	// Assert
+	assert.Equal(t, []string{strings.ToLower(a), a, b}, dups)
+	require.Empty(t, id.CheckDuplicates(nil))
+}
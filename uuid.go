@@ -0,0 +1,42 @@
+package id
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+// errInvalidUUID is returned when a string is not an RFC 4122 UUID
+var errInvalidUUID = errors.New("invalid UUID")
+
+// parseUUID decodes an RFC 4122 UUID string, with or without hyphens, in either case
+func parseUUID(s string) ([16]byte, error) {
+	var result [16]byte
+
+	if len(s) == 36 {
+		if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+			return result, errInvalidUUID
+		}
+		s = s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:]
+	}
+	if len(s) != 32 {
+		return result, errInvalidUUID
+	}
+	if _, err := hex.Decode(result[:], []byte(s)); err != nil {
+		return result, errInvalidUUID
+	}
+	return result, nil
+}
+
+// formatUUID renders 16 bytes in canonical lowercase 8-4-4-4-12 form
+func formatUUID(b [16]byte) string {
+	var sb strings.Builder
+	sb.Grow(36)
+	for i, c := range b {
+		if i == 4 || i == 6 || i == 8 || i == 10 {
+			sb.WriteByte('-')
+		}
+		sb.WriteString(hex.EncodeToString([]byte{c}))
+	}
+	return sb.String()
+}
@@ -0,0 +1,100 @@
+package id
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/oklog/ulid"
+)
+
+// ErrLossyUUIDv7 is returned when an ID's version and variant bits are not
+// those of a UUIDv7, so converting it between ULID and UUIDv7 would change it
+var ErrLossyUUIDv7 = errors.New("ID does not carry the UUIDv7 version and variant bits")
+
+// The UUIDv7 version and variant fields, as offsets into the ULID entropy.
+// Node and region stamps sit clear of both.
+const (
+	uuidv7VersionOffset = 0
+	uuidv7VariantOffset = 16
+)
+
+// WithUUIDv7Layout makes the generator write the UUIDv7 version and variant
+// bits into every ULID it issues, leaving 74 random bits. Its ULIDs are then
+// valid UUIDv7s byte for byte, so ToUUIDv7Exact and FromUUIDv7 convert them
+// in both directions without loss.
+func WithUUIDv7Layout() Option {
+	return func(g *generator) {
+		withStamp(entropyStamp{offset: uuidv7VersionOffset, width: 4, value: 0x7})(g)
+		withStamp(entropyStamp{offset: uuidv7VariantOffset, width: 2, value: 0b10})(g)
+	}
+}
+
+// GenerateUUIDv7 creates an RFC 9562 UUIDv7 for the current time in
+// lowercase hyphenated form, drawing entropy like Generate
+func (g *generator) GenerateUUIDv7() string {
+	return formatUUID(stampUUIDv7(g.newULID(g.now())))
+}
+
+// ToUUIDv7 converts a ULID to a UUIDv7 with the same timestamp. The version
+// and variant fields overwrite 6 of the 80 entropy bits, so the conversion
+// is one-way: FromUUID recovers the timestamp but not those bits. UUIDs keep
+// the chronological order of the ULIDs they came from. ToUUIDv7Exact is the
+// lossless conversion for ULIDs issued with WithUUIDv7Layout.
+func (g *generator) ToUUIDv7(id string) (string, error) {
+	parsed, err := g.parse(id)
+	if err != nil {
+		return "", fmt.Errorf("invalid ULID: %w", err)
+	}
+	return formatUUID(stampUUIDv7(parsed)), nil
+}
+
+// ToUUIDv7Exact converts a ULID to the UUIDv7 with the same 16 bytes, which
+// FromUUIDv7 converts back. It returns ErrLossyUUIDv7 unless the ULID already
+// carries the UUIDv7 version and variant bits, as every ULID issued with
+// WithUUIDv7Layout does.
+func (g *generator) ToUUIDv7Exact(id string) (string, error) {
+	parsed, err := g.parse(id)
+	if err != nil {
+		return "", fmt.Errorf("invalid ULID: %w", err)
+	}
+	if !isUUIDv7(parsed) {
+		return "", fmt.Errorf("%w: %s", ErrLossyUUIDv7, id)
+	}
+	return formatUUID(parsed), nil
+}
+
+// FromUUIDv7 converts a UUIDv7 to the ULID with the same 16 bytes, the
+// inverse of ToUUIDv7Exact. It returns ErrLossyUUIDv7 for other UUIDs.
+func (g *generator) FromUUIDv7(uuid string) (string, error) {
+	raw, err := parseUUID(uuid)
+	if err != nil {
+		return "", fmt.Errorf("%w %q", errInvalidUUID, uuid)
+	}
+	if !isUUIDv7(raw) {
+		return "", fmt.Errorf("%w: %s", ErrLossyUUIDv7, uuid)
+	}
+	return g.encode(ulid.ULID(raw)), nil
+}
+
+// FromUUID converts any RFC 4122 UUID string, hyphenated or not and in
+// either case, to a ULID with the same 16 bytes. For UUIDv7 input the ULID
+// carries the UUID's timestamp and sorts in the same order.
+func (g *generator) FromUUID(uuid string) (string, error) {
+	raw, err := parseUUID(uuid)
+	if err != nil {
+		return "", fmt.Errorf("%w %q", errInvalidUUID, uuid)
+	}
+	return g.encode(ulid.ULID(raw)), nil
+}
+
+// isUUIDv7 reports whether b carries the UUIDv7 version and RFC 4122 variant bits
+func isUUIDv7(b [16]byte) bool {
+	return b[6]>>4 == 7 && b[8]&0xC0 == 0x80
+}
+
+// stampUUIDv7 sets the UUIDv7 version and RFC 4122 variant bits on a ULID
+func stampUUIDv7(u ulid.ULID) [16]byte {
+	u[6] = u[6]&0x0F | 0x70
+	u[8] = u[8]&0x3F | 0x80
+	return u
+}
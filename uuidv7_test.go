@@ -0,0 +1,124 @@
+package id_test
+
+import (
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GenerateUUIDv7(t *testing.T) {
+	gen := id.NewGenerator()
+	policy := id.SchemePolicy{Accept: []id.Scheme{id.SchemeUUIDv7}}
+
+	// Act
+	uuid := gen.GenerateUUIDv7()
+	match, err := policy.Validate(uuid)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, id.SchemeUUIDv7, match.Scheme)
+	assert.Equal(t, uuid, match.Canonical)
+
+	ulid, err := gen.FromUUID(uuid)
+	require.NoError(t, err)
+	age, err := gen.Age(ulid)
+	require.NoError(t, err)
+	assert.Less(t, age, time.Minute)
+}
+
+func Test_ToUUIDv7_PreservesTimeAndOrder(t *testing.T) {
+	gen := id.NewGenerator()
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	ulids := gen.GenerateRange(start, start.Add(time.Hour), 100)
+
+	// Act
+	uuids := make([]string, len(ulids))
+	for i, u := range ulids {
+		var err error
+		uuids[i], err = gen.ToUUIDv7(u)
+		require.NoError(t, err)
+	}
+
+	// Assert
+	assert.True(t, sort.StringsAreSorted(uuids))
+	for i, uuid := range uuids {
+		assert.Equal(t, "7", uuid[14:15])
+		assert.Contains(t, "89ab", uuid[19:20])
+
+		back, err := gen.FromUUID(uuid)
+		require.NoError(t, err)
+		want, err := gen.ExtractTimestamp(ulids[i])
+		require.NoError(t, err)
+		got, err := gen.ExtractTimestamp(back)
+		require.NoError(t, err)
+		assert.True(t, want.Equal(got))
+	}
+}
+
+func Test_FromUUID(t *testing.T) {
+	gen := id.NewGenerator()
+	ulid := gen.Generate()
+	uuid, err := gen.ToUUID(ulid)
+	require.NoError(t, err)
+
+	// Act
+	hyphenated, err := gen.FromUUID(uuid)
+	require.NoError(t, err)
+	compact, err := gen.FromUUID(strings.ToUpper(strings.ReplaceAll(uuid, "-", "")))
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, ulid, hyphenated)
+	assert.Equal(t, ulid, compact)
+
+	_, err = gen.FromUUID("not-a-uuid")
+	assert.Error(t, err)
+	_, err = gen.ToUUIDv7("not-a-ulid")
+	assert.Error(t, err)
+}
+
+func Test_WithUUIDv7Layout_RoundTrips(t *testing.T) {
+	gen := id.NewGenerator(id.WithUUIDv7Layout(), id.WithNode(5))
+	policy := id.SchemePolicy{Accept: []id.Scheme{id.SchemeUUIDv7}}
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	ulids := append(gen.GenerateRange(start, start.Add(time.Hour), 50), gen.Generate(), gen.GenerateID().String())
+
+	for _, ulid := range ulids {
+		// Act
+		uuid, err := gen.ToUUIDv7Exact(ulid)
+		require.NoError(t, err)
+		back, err := gen.FromUUIDv7(uuid)
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, ulid, back)
+		lossy, err := gen.ToUUIDv7(ulid)
+		require.NoError(t, err)
+		assert.Equal(t, uuid, lossy, "the lossy conversion leaves such ULIDs unchanged")
+		assert.True(t, policy.IsIdValid(uuid))
+		node, err := id.ExtractNode(ulid)
+		require.NoError(t, err)
+		assert.Equal(t, uint16(5), node)
+	}
+}
+
+func Test_ToUUIDv7Exact_RejectsLossyInput(t *testing.T) {
+	gen := id.NewGenerator()
+	plain := "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+	v4 := "0f3b8a62-5d1c-4e2f-9a7b-3c6d8e1f2a4b"
+
+	// Act
+	_, toErr := gen.ToUUIDv7Exact(plain)
+	_, fromErr := gen.FromUUIDv7(v4)
+
+	// Assert
+	assert.ErrorIs(t, toErr, id.ErrLossyUUIDv7)
+	assert.ErrorIs(t, fromErr, id.ErrLossyUUIDv7)
+	_, err := gen.FromUUIDv7("not-a-uuid")
+	assert.Error(t, err)
+}
@@ -0,0 +1,95 @@
+package id
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/oklog/ulid"
+)
+
+var (
+	// ErrEmpty is returned when an ID is the empty string
+	ErrEmpty = errors.New("empty ULID string")
+	// ErrWrongLength is returned when an ID is not 26 characters long
+	ErrWrongLength = errors.New("wrong ULID length")
+	// ErrTimestampOverflow is returned when an ID's first character exceeds
+	// '7', encoding a timestamp beyond 48 bits
+	ErrTimestampOverflow = errors.New("ULID timestamp overflows 48 bits")
+	// ErrTruncated is returned by ValidateFull for a shortened display form,
+	// such as one made by ID.ShortString or id[:8]+"..."
+	ErrTruncated = errors.New("truncated ULID")
+)
+
+// ErrInvalidCharacter is returned when an ID contains a character outside
+// the Crockford Base32 alphabet. Match it with errors.As to read the
+// position, or errors.Is(err, ErrInvalidCharacter{}) to classify it.
+type ErrInvalidCharacter struct {
+	// Pos is the byte offset of the character
+	Pos int
+	// Rune is the offending character
+	Rune rune
+}
+
+// Error describes the offending character and its position
+func (e ErrInvalidCharacter) Error() string {
+	return fmt.Sprintf("invalid character %q at position %d", e.Rune, e.Pos)
+}
+
+// Is reports whether target is an ErrInvalidCharacter, whatever its position
+func (e ErrInvalidCharacter) Is(target error) bool {
+	_, ok := target.(ErrInvalidCharacter)
+	return ok
+}
+
+// Validate checks that id is a canonical ULID in either case, returning
+// ErrEmpty, ErrWrongLength, ErrInvalidCharacter, or ErrTimestampOverflow
+// so callers can report or count exactly what was wrong
+func Validate(id string) error {
+	_, err := parseCanonical(id)
+	return err
+}
+
+// ValidateFull is a stricter Validate for IDs headed for storage. After
+// trimming surrounding whitespace, the ID must be exactly 26 characters of a
+// ULID in either case. Input shorter than that or containing an ellipsis,
+// the signs of a display form leaking back in, fails with ErrTruncated as
+// well as ErrWrongLength or ErrInvalidCharacter.
+func ValidateFull(id string) error {
+	trimmed := strings.TrimSpace(id)
+	_, err := parseCanonical(trimmed)
+	if err == nil {
+		return nil
+	}
+	if trimmed != "" && (len(trimmed) < ulid.EncodedSize || strings.Contains(trimmed, "...") || strings.Contains(trimmed, ShortStringEllipsis)) {
+		return fmt.Errorf("%w: %w", ErrTruncated, err)
+	}
+	return err
+}
+
+// parseCanonical parses a 26-character ULID in either case, diagnosing
+// failures as typed errors
+func parseCanonical(id string) (ulid.ULID, error) {
+	parsed, err := ulid.ParseStrict(id)
+	if err == nil {
+		return parsed, nil
+	}
+
+	switch {
+	case id == "":
+		return ulid.ULID{}, ErrEmpty
+	case len(id) != ulid.EncodedSize:
+		return ulid.ULID{}, fmt.Errorf("%w: got %d characters, want %d", ErrWrongLength, len(id), ulid.EncodedSize)
+	}
+	for i := 0; i < len(id); i++ {
+		if strings.IndexByte(ulid.Encoding, upperASCII(id[i])) < 0 {
+			r, _ := utf8.DecodeRuneInString(id[i:])
+			return ulid.ULID{}, ErrInvalidCharacter{Pos: i, Rune: r}
+		}
+	}
+	if errors.Is(err, ulid.ErrOverflow) {
+		return ulid.ULID{}, fmt.Errorf("%w: first character %q", ErrTimestampOverflow, id[0])
+	}
+	return ulid.ULID{}, err
+}
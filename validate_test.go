@@ -0,0 +1,99 @@
+package id_test
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Validate(t *testing.T) {
+	valid := id.NewGenerator().Generate()
+
+	tests := []struct {
+		name  string
+		input string
+		want  error
+	}{
+		{"empty", "", id.ErrEmpty},
+		{"too short", valid[:25], id.ErrWrongLength},
+		{"too long", valid + "0", id.ErrWrongLength},
+		{"invalid character", valid[:5] + "U" + valid[6:], id.ErrInvalidCharacter{}},
+		{"overflow", "8" + valid[1:], id.ErrTimestampOverflow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, id.Validate(tt.input), tt.want)
+		})
+	}
+
+	assert.NoError(t, id.Validate(valid))
+	assert.NoError(t, id.Validate(strings.ToLower(valid)))
+}
+
+func Test_Validate_InvalidCharacterPosition(t *testing.T) {
+	valid := id.NewGenerator().Generate()
+
+	// Act
+	err := id.Validate(valid[:10] + "é" + valid[12:])
+
+	// Assert
+	var charErr id.ErrInvalidCharacter
+	require.True(t, errors.As(err, &charErr))
+	assert.Equal(t, 10, charErr.Pos)
+	assert.Equal(t, 'é', charErr.Rune)
+	assert.EqualError(t, err, `invalid character 'é' at position 10`)
+}
+
+func Test_Generator_TypedErrors(t *testing.T) {
+	gen := id.NewGenerator()
+
+	// Act
+	_, normalizeErr := gen.ValidateAndNormalize("")
+	_, timestampErr := gen.ExtractTimestamp("!!!!!!!!!!!!!!!!!!!!!!!!!!")
+	_, compareErr := gen.Compare("short", gen.Generate())
+
+	// Assert
+	assert.ErrorIs(t, normalizeErr, id.ErrEmpty)
+	assert.ErrorIs(t, timestampErr, id.ErrInvalidCharacter{})
+	assert.ErrorIs(t, compareErr, id.ErrWrongLength)
+	assert.False(t, gen.IsIdValid("!!!!!!!!!!!!!!!!!!!!!!!!!!"))
+}
+
+func Test_ValidateFull(t *testing.T) {
+	valid := id.NewGenerator().Generate()
+	parsed, err := id.CrockfordLower.Decode(valid)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		input string
+		want  []error
+	}{
+		{"empty", "  ", []error{id.ErrEmpty}},
+		{"prefix", valid[:8], []error{id.ErrTruncated, id.ErrWrongLength}},
+		{"dotted display form", valid[:8] + "...", []error{id.ErrTruncated, id.ErrWrongLength}},
+		{"padded display form", valid[:23] + "...", []error{id.ErrTruncated, id.ErrInvalidCharacter{}}},
+		{"short string", parsed.ShortString(25), []error{id.ErrTruncated, id.ErrWrongLength}},
+		{"too long", valid + "0", []error{id.ErrWrongLength}},
+		{"invalid character", valid[:5] + "U" + valid[6:], []error{id.ErrInvalidCharacter{}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := id.ValidateFull(tt.input)
+			for _, want := range tt.want {
+				assert.ErrorIs(t, err, want)
+			}
+			if !slices.Contains(tt.want, id.ErrTruncated) {
+				assert.NotErrorIs(t, err, id.ErrTruncated)
+			}
+		})
+	}
+
+	assert.NoError(t, id.ValidateFull(valid))
+	assert.NoError(t, id.ValidateFull(" "+strings.ToLower(valid)+"\n"))
+}
@@ -0,0 +1,78 @@
+package id
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/oklog/ulid"
+)
+
+// ID is a ULID held as its 16 raw bytes. Unlike the string API it can be
+// parsed once and then compared and inspected without further allocation.
+type ID [16]byte
+
+// Timestamp returns the time component of the ID
+func (id ID) Timestamp() time.Time {
+	return ulid.Time(id.Time())
+}
+
+// Time returns the time component of the ID in milliseconds since the Unix epoch
+func (id ID) Time() uint64 {
+	return ulid.ULID(id).Time()
+}
+
+// Compare returns -1, 0, or 1 for chronological ordering, breaking ties by entropy
+func (id ID) Compare(other ID) int {
+	return bytes.Compare(id[:], other[:])
+}
+
+// String returns the canonical 26-character representation
+func (id ID) String() string {
+	return ulid.ULID(id).String()
+}
+
+// ShortStringEllipsis ends every ID.ShortString
+const ShortStringEllipsis = "…"
+
+// ShortString returns the first n characters of the ID followed by
+// ShortStringEllipsis, for logs and UIs. The result is for display only: the
+// ellipsis keeps it from ever parsing as an ID, and ValidateFull reports it
+// as ErrTruncated, so it cannot pass for a real ID in storage.
+func (id ID) ShortString(n int) string {
+	return id.String()[:min(max(n, 0), ulid.EncodedSize)] + ShortStringEllipsis
+}
+
+// Bytes returns a copy of the raw bytes. Slice the ID directly to avoid the copy.
+func (id ID) Bytes() []byte {
+	return append([]byte(nil), id[:]...)
+}
+
+// IsZero reports whether the ID is the zero value
+func (id ID) IsZero() bool {
+	return id == ID{}
+}
+
+// GenerateID creates an ID for the current time
+func (g *generator) GenerateID() ID {
+	return ID(g.newULID(g.now()))
+}
+
+// GenerateIDWithTime creates an ID with a specific timestamp
+func (g *generator) GenerateIDWithTime(t time.Time) ID {
+	return ID(g.newULID(t))
+}
+
+// ParseID decodes an ID in the generator's format
+func (g *generator) ParseID(s string) (ID, error) {
+	parsed, err := g.parse(s)
+	if err != nil {
+		return ID{}, fmt.Errorf("invalid ULID: %w", err)
+	}
+	return ID(parsed), nil
+}
+
+// FormatID renders an ID in the generator's format
+func (g *generator) FormatID(id ID) string {
+	return g.encode(ulid.ULID(id))
+}
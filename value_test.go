@@ -0,0 +1,100 @@
+package id_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ID_RoundTrip(t *testing.T) {
+	gen := id.NewGenerator()
+	ts := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	// Act
+	value := gen.GenerateIDWithTime(ts)
+	parsed, err := gen.ParseID(value.String())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, value, parsed)
+	assert.True(t, value.Timestamp().Equal(ts))
+	assert.Equal(t, uint64(ts.UnixMilli()), value.Time())
+	assert.Equal(t, value[:], value.Bytes())
+	assert.Equal(t, value.String(), gen.FormatID(value))
+	assert.False(t, value.IsZero())
+	assert.True(t, id.ID{}.IsZero())
+}
+
+func Test_ID_MatchesStringAPI(t *testing.T) {
+	gen := id.NewGenerator()
+	s := gen.Generate()
+
+	// Act
+	value, err := gen.ParseID(s)
+	require.NoError(t, err)
+	raw, err := gen.ToBytes(s)
+	require.NoError(t, err)
+	ts, err := gen.ExtractTimestamp(s)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, id.ID(raw), value)
+	assert.True(t, value.Timestamp().Equal(ts))
+}
+
+func Test_ID_Compare(t *testing.T) {
+	gen := id.NewGenerator()
+	a := gen.GenerateIDWithTime(time.UnixMilli(1000))
+	b := gen.GenerateIDWithTime(time.UnixMilli(2000))
+
+	// Assert
+	assert.Equal(t, -1, a.Compare(b))
+	assert.Equal(t, 1, b.Compare(a))
+	assert.Equal(t, 0, a.Compare(a))
+}
+
+func Test_ID_BytesIsCopy(t *testing.T) {
+	value := id.NewGenerator().GenerateID()
+
+	// Act
+	b := value.Bytes()
+	b[0] ^= 0xFF
+
+	// Assert
+	assert.NotEqual(t, value[0], b[0])
+}
+
+func Test_ParseID_Invalid(t *testing.T) {
+	_, err := id.NewGenerator().ParseID("not-a-ulid")
+	assert.Error(t, err)
+}
+
+func Test_ID_FormatProfile(t *testing.T) {
+	gen := id.NewGenerator().WithFormatProfile(id.FormatProfile{Case: id.CaseLower})
+	value := gen.GenerateID()
+
+	// Act
+	s := gen.FormatID(value)
+	parsed, err := gen.ParseID(s)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, value, parsed)
+	assert.NotEqual(t, value.String(), s)
+}
+
+func Test_ID_ShortString(t *testing.T) {
+	value := id.NewGenerator().GenerateID()
+
+	// Act & Assert
+	assert.Equal(t, value.String()[:8]+"…", value.ShortString(8))
+	assert.Equal(t, "…", value.ShortString(-1))
+	assert.Equal(t, value.String()+"…", value.ShortString(100))
+	for _, n := range []int{0, 8, 26} {
+		assert.Error(t, id.Validate(value.ShortString(n)))
+		assert.ErrorIs(t, id.ValidateFull(value.ShortString(n)), id.ErrTruncated)
+	}
+}
@@ -0,0 +1,76 @@
+package id_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// referenceVectorsFile is the JSON form of the vectors published for ports
+const referenceVectorsFile = "testdata/reference_vectors.json"
+
+func Test_ReferenceVectors_Verify(t *testing.T) {
+	// Act
+	err := id.VerifyReferenceVectors(id.ReferenceVectors())
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func Test_ReferenceVectors_MatchGeneration(t *testing.T) {
+	for _, v := range id.ReferenceVectors() {
+		entropy, err := hex.DecodeString(v.Entropy)
+		require.NoError(t, err)
+
+		// Act
+		generated := id.NewGeneratorWithEntropy(&constBytesReader{b: entropy}).GenerateWithTime(v.Time())
+		uuid, err := id.NewGenerator().ToUUID(generated)
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, v.ULID, generated, v.Name)
+		assert.Equal(t, v.UUID, uuid, v.Name)
+	}
+}
+
+func Test_ReferenceVectors_File(t *testing.T) {
+	want, err := json.MarshalIndent(id.ReferenceVectors(), "", "  ")
+	require.NoError(t, err)
+
+	// Act
+	got, err := os.ReadFile(referenceVectorsFile)
+	require.NoError(t, err)
+
+	// Assert
+	assert.JSONEq(t, string(want), string(got), "regenerate %s from id.ReferenceVectors", referenceVectorsFile)
+}
+
+func Test_ReferenceVector_VerifyDetectsMismatch(t *testing.T) {
+	v := id.ReferenceVectors()[2]
+	v.Base58 = "1111111111111111111111"
+
+	// Act
+	err := v.Verify()
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Base58")
+
+	v = id.ReferenceVectors()[0]
+	v.Entropy = "zz"
+	assert.Error(t, id.VerifyReferenceVectors([]id.ReferenceVector{v}))
+}
+
+// constBytesReader returns b on every read
+type constBytesReader struct {
+	b []byte
+}
+
+func (r *constBytesReader) Read(p []byte) (int, error) {
+	return copy(p, r.b), nil
+}
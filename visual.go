@@ -0,0 +1,110 @@
+package id
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"math"
+)
+
+const (
+	// identiconGrid is the number of cells along each side of an identicon
+	identiconGrid = 5
+
+	// colorSaturation and colorLightness keep derived colors vivid and legible
+	// on both light and dark backgrounds; only the hue varies
+	colorSaturation = 0.65
+	colorLightness  = 0.5
+)
+
+var (
+	// invalidColor is the neutral gray used for invalid IDs
+	invalidColor = color.RGBA{R: 128, G: 128, B: 128, A: 255}
+	// identiconBackground is the pale background behind identicon cells
+	identiconBackground = color.RGBA{R: 240, G: 240, B: 240, A: 255}
+)
+
+// Color derives a stable color from an ID's entropy, so UIs can tell records
+// apart at a glance. The entropy is hashed first, so IDs from the same
+// millisecond, which share most of their entropy under monotonic generation,
+// still get unrelated hues. Invalid IDs get a neutral gray.
+func Color(id string) (r, g, b uint8) {
+	c := idColor(id)
+	return c.R, c.G, c.B
+}
+
+// Identicon draws a size-by-size pixel, horizontally symmetric 5x5 pattern
+// in Color(id) on a pale background, derived from the same entropy hash.
+// Invalid IDs draw an empty background. A non-positive size yields an empty
+// image.
+func Identicon(id string, size int) image.Image {
+	size = max(size, 0)
+	img := image.NewPaletted(image.Rect(0, 0, size, size), color.Palette{identiconBackground, idColor(id)})
+
+	digest, ok := entropyDigest(id)
+	if !ok {
+		return img
+	}
+	// 15 bits fill the left three columns, which mirror onto the right two
+	cells := binary.BigEndian.Uint64(digest[8:16])
+	for y := 0; y < size; y++ {
+		row := y * identiconGrid / size
+		for x := 0; x < size; x++ {
+			col := x * identiconGrid / size
+			col = min(col, identiconGrid-1-col)
+			if cells>>(row*3+col)&1 == 1 {
+				img.SetColorIndex(x, y, 1)
+			}
+		}
+	}
+	return img
+}
+
+// idColor maps the hash of an ID's entropy to a hue
+func idColor(id string) color.RGBA {
+	digest, ok := entropyDigest(id)
+	if !ok {
+		return invalidColor
+	}
+	hue := float64(binary.BigEndian.Uint64(digest[:8])%360) / 360
+	return hslToRGB(hue, colorSaturation, colorLightness)
+}
+
+// entropyDigest hashes the entropy of a ULID given in either case
+func entropyDigest(id string) ([sha256.Size]byte, bool) {
+	parsed, err := parseCanonical(id)
+	if err != nil {
+		return [sha256.Size]byte{}, false
+	}
+	return sha256.Sum256(parsed[6:]), true
+}
+
+// hslToRGB converts a hue, saturation, and lightness in [0, 1] to an opaque color
+func hslToRGB(h, s, l float64) color.RGBA {
+	chroma := (1 - math.Abs(2*l-1)) * s
+	sector := h * 6
+	x := chroma * (1 - math.Abs(math.Mod(sector, 2)-1))
+
+	var r, g, b float64
+	switch int(sector) % 6 {
+	case 0:
+		r, g = chroma, x
+	case 1:
+		r, g = x, chroma
+	case 2:
+		g, b = chroma, x
+	case 3:
+		g, b = x, chroma
+	case 4:
+		r, b = x, chroma
+	default:
+		r, b = chroma, x
+	}
+
+	m := l - chroma/2
+	channel := func(v float64) uint8 {
+		return uint8(math.Round((v + m) * 255))
+	}
+	return color.RGBA{R: channel(r), G: channel(g), B: channel(b), A: 255}
+}
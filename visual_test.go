@@ -0,0 +1,58 @@
+package id_test
+
+import (
+	"image/color"
+	"strings"
+	"testing"
+
+	"github.com/bold-minds/id"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Color(t *testing.T) {
+	ids := id.NewGenerator().GenerateBatch(50)
+
+	// Act
+	r, g, b := id.Color(ids[0])
+
+	// Assert
+	r2, g2, b2 := id.Color(strings.ToLower(ids[0]))
+	assert.Equal(t, [3]uint8{r, g, b}, [3]uint8{r2, g2, b2}, "stable across case")
+
+	distinct := map[[3]uint8]bool{}
+	for _, s := range ids {
+		r, g, b := id.Color(s)
+		distinct[[3]uint8{r, g, b}] = true
+	}
+	assert.Greater(t, len(distinct), 40, "same-millisecond IDs get different colors")
+
+	r, g, b = id.Color("invalid")
+	assert.Equal(t, [3]uint8{128, 128, 128}, [3]uint8{r, g, b})
+}
+
+func Test_Identicon(t *testing.T) {
+	ulid := id.NewGenerator().Generate()
+
+	// Act
+	img := id.Identicon(ulid, 50)
+
+	// Assert
+	assert.Equal(t, 50, img.Bounds().Dx())
+	assert.Equal(t, 50, img.Bounds().Dy())
+	r, g, b := id.Color(ulid)
+	fg := color.RGBA{R: r, G: g, B: b, A: 255}
+	for y := 0; y < 50; y++ {
+		for x := 0; x < 50; x++ {
+			c := img.At(x, y)
+			assert.Equal(t, c, img.At(49-x, y), "symmetric at (%d, %d)", x, y)
+			if c != fg {
+				assert.Equal(t, color.RGBA{R: 240, G: 240, B: 240, A: 255}, c)
+			}
+		}
+	}
+	assert.Equal(t, img, id.Identicon(ulid, 50), "deterministic")
+
+	assert.Zero(t, id.Identicon(ulid, 0).Bounds().Dx())
+	blank := id.Identicon("invalid", 10)
+	assert.Equal(t, color.RGBA{R: 240, G: 240, B: 240, A: 255}, blank.At(5, 5))
+}